@@ -2,9 +2,22 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrNotFound is returned by a GetOrCompute compute function to report that the key has no
+// value. With WithNegativeTTL, the miss is cached so later calls return ErrNotFound
+// without recomputing until the negative TTL elapses.
+var ErrNotFound = errors.New("cache: not found")
+
+// ErrNotInteger is returned by Increment and Decrement when the existing value is not an
+// integer, or is an unsigned integer too large for int64.
+var ErrNotInteger = errors.New("cache: value is not an integer")
+
+// ErrOverflow is returned by Increment and Decrement when the result would not fit in an int64.
+var ErrOverflow = errors.New("cache: integer overflow")
+
 // Cache defines a minimal key/value in-memory cache with TTL support.
 // Implementations must be safe for concurrent use.
 type Cache interface {
@@ -21,15 +34,71 @@ type Cache interface {
 	// Close releases resources (e.g., background janitor). It is safe to call multiple times.
 	Close()
 	// GetOrCompute returns the cached value for key or invokes compute to produce and store it.
-	// If compute returns an error, nothing is cached and the error is returned.
+	// Concurrent calls for the same key share a single compute; waiters receive its result.
+	// If compute returns an error, nothing is cached and the error is returned to all callers,
+	// except that errors wrapping ErrNotFound are cached as a negative entry when
+	// WithNegativeTTL is set. compute runs with the first caller's ctx; if that ctx ends and
+	// compute returns its error, waiters whose own ctx is still live compute again. A panic in
+	// compute is returned to waiters as an error and re-raised in the first caller.
 	GetOrCompute(ctx context.Context, key string, ttl time.Duration, compute func(context.Context) (any, error)) (any, error)
 	// Info returns the expiration time and last-access time for key, if present and not expired.
 	// If last-access tracking is disabled or not yet accessed, lastAccess may be zero.
 	Info(key string) (expiresAt time.Time, lastAccess time.Time, ok bool)
-	// Stats returns hits, misses, evictions (due to expiry), and current size.
+	// TTL returns the remaining time-to-live for key. Entries without expiration report 0.
+	// ok is false if the key is missing or expired.
+	TTL(key string) (remaining time.Duration, ok bool)
+	// Keys returns the keys of all live (non-expired) entries in no particular order.
+	Keys() []string
+	// Range calls fn for each live entry until fn returns false. It iterates over a snapshot,
+	// so fn may safely call back into the cache.
+	Range(fn func(key string, value any) bool)
+	// Stats returns hits, misses, evictions (due to expiry or capacity), and current size.
 	Stats() (hits, misses, evictions, size int)
 }
 
+// Counter is implemented by caches that support atomic integer counters, including the
+// cache returned by NewMemory. Discover it with a type assertion: c.(cache.Counter).
+type Counter interface {
+	// Increment atomically adds delta to the integer stored at key and returns the new value.
+	// A missing or expired key is created with value delta and the given ttl (as with Set);
+	// an existing entry keeps its expiration. Values are stored as int64; existing values of
+	// other integer types are accepted, and non-integer values return an error wrapping ErrNotInteger.
+	// A result outside the int64 range returns an error wrapping ErrOverflow and leaves the value unchanged.
+	Increment(key string, delta int64, ttl time.Duration) (int64, error)
+	// Decrement is Increment with -delta.
+	Decrement(key string, delta int64, ttl time.Duration) (int64, error)
+}
+
+// EvictReason describes why an entry was removed from the cache.
+type EvictReason int
+
+const (
+	// EvictExpired means the entry's TTL elapsed.
+	EvictExpired EvictReason = iota
+	// EvictCapacity means the entry was the least recently used when the cache was full.
+	EvictCapacity
+	// EvictDeleted means the entry was removed by Delete.
+	EvictDeleted
+	// EvictCleared means the entry was removed by Clear.
+	EvictCleared
+)
+
+// String returns a lowercase name for the reason, suitable for logs and metric labels.
+func (r EvictReason) String() string {
+	switch r {
+	case EvictExpired:
+		return "expired"
+	case EvictCapacity:
+		return "capacity"
+	case EvictDeleted:
+		return "deleted"
+	case EvictCleared:
+		return "cleared"
+	default:
+		return "unknown"
+	}
+}
+
 // Option configures a Memory cache.
 type Option func(*memory)
 
@@ -40,6 +109,15 @@ func WithDefaultTTL(ttl time.Duration) Option {
 	}
 }
 
+// WithNegativeTTL caches ErrNotFound results of GetOrCompute for ttl, so repeated lookups
+// of a missing key don't reach the backend. Negative entries read as misses in Get, Keys,
+// Range, Info and TTL, are replaced by Set, and count toward Size and WithMaxEntries.
+func WithNegativeTTL(ttl time.Duration) Option {
+	return func(m *memory) {
+		m.negativeTTL = ttl
+	}
+}
+
 // WithCleanupInterval sets the periodic cleanup tick. If <=0, cleanup runs opportunistically on access only.
 func WithCleanupInterval(d time.Duration) Option {
 	return func(m *memory) {
@@ -68,6 +146,26 @@ func WithStats() Option {
 	}
 }
 
+// WithMaxEntries bounds the cache to n entries. Inserting a new key at capacity evicts the
+// least-recently-used entry in constant time; it is reported as EvictExpired if its TTL had
+// already elapsed. Enables last-access tracking.
+func WithMaxEntries(n int) Option {
+	return func(m *memory) {
+		if n > 0 {
+			m.maxEntries = n
+			m.trackAccess = true
+		}
+	}
+}
+
+// WithOnEvict sets a callback invoked when an entry is removed by expiry, capacity eviction,
+// Delete, or Clear. It runs after the cache lock is released, so it may call back into the cache.
+func WithOnEvict(f func(key string, value any, reason EvictReason)) Option {
+	return func(m *memory) {
+		m.onEvict = f
+	}
+}
+
 // NewMemory returns a new in-memory cache.
 func NewMemory(opts ...Option) Cache {
 	return newMemory(opts...)
@@ -2,6 +2,13 @@ package cache
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -90,3 +97,600 @@ func TestStats(t *testing.T) {
 		t.Fatalf("unexpected stats: h=%d m=%d e=%d size=%d", h, m, e, size)
 	}
 }
+
+func TestMaxEntriesEvictsLRU(t *testing.T) {
+	c := NewMemory(WithMaxEntries(3), WithStats())
+	defer c.Close()
+
+	c.Set("a", 1, 0)
+	c.Set("b", 2, 0)
+	c.Set("c", 3, 0)
+	// Touch "a" so "b" becomes the least recently used.
+	if _, ok := c.Get("a"); !ok {
+		t.Fatalf("expected a present")
+	}
+	c.Set("d", 4, 0)
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatalf("expected b evicted")
+	}
+	for _, k := range []string{"a", "c", "d"} {
+		if _, ok := c.Get(k); !ok {
+			t.Fatalf("expected %s present", k)
+		}
+	}
+	if _, _, e, size := c.Stats(); e != 1 || size != 3 {
+		t.Fatalf("want evictions=1 size=3, got evictions=%d size=%d", e, size)
+	}
+
+	// Overwriting an existing key never evicts.
+	c.Set("a", 10, 0)
+	if _, _, e, size := c.Stats(); e != 1 || size != 3 {
+		t.Fatalf("overwrite changed stats: evictions=%d size=%d", e, size)
+	}
+}
+
+func TestMaxEntriesInsertOrder(t *testing.T) {
+	c := NewMemory(WithMaxEntries(2), WithStats())
+	defer c.Close()
+
+	c.Set("first", 1, 0)
+	c.Set("second", 2, 0)
+	c.Set("third", 3, 0)
+
+	if _, ok := c.Get("first"); ok {
+		t.Fatalf("expected oldest entry evicted")
+	}
+	if _, _, e, _ := c.Stats(); e != 1 {
+		t.Fatalf("want evictions=1, got %d", e)
+	}
+}
+
+func TestGetOrComputeSingleFlight(t *testing.T) {
+	c := NewMemory()
+	defer c.Close()
+
+	const n = 32
+	var calls atomic.Int32
+	release := make(chan struct{})
+	compute := func(context.Context) (any, error) {
+		calls.Add(1)
+		<-release
+		return "value", nil
+	}
+
+	var ready, wg sync.WaitGroup
+	ready.Add(n)
+	wg.Add(n)
+	results := make([]any, n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			ready.Done()
+			v, err := c.GetOrCompute(context.Background(), "cold", 0, compute)
+			if err != nil {
+				t.Errorf("GetOrCompute: %v", err)
+			}
+			results[i] = v
+		}(i)
+	}
+	ready.Wait()
+	time.Sleep(20 * time.Millisecond) // let callers pile up behind the first compute
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("compute ran %d times, want 1", got)
+	}
+	for i, v := range results {
+		if v != "value" {
+			t.Fatalf("caller %d got %v", i, v)
+		}
+	}
+}
+
+func TestGetOrComputeSingleFlightError(t *testing.T) {
+	c := NewMemory()
+	defer c.Close()
+
+	const n = 8
+	wantErr := errors.New("db down")
+	var calls atomic.Int32
+	release := make(chan struct{})
+	compute := func(context.Context) (any, error) {
+		calls.Add(1)
+		<-release
+		return nil, wantErr
+	}
+
+	var ready, wg sync.WaitGroup
+	ready.Add(n)
+	wg.Add(n)
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			ready.Done()
+			_, errs[i] = c.GetOrCompute(context.Background(), "cold", 0, compute)
+		}(i)
+	}
+	ready.Wait()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("compute ran %d times, want 1", got)
+	}
+	for i, err := range errs {
+		if !errors.Is(err, wantErr) {
+			t.Fatalf("caller %d got err %v", i, err)
+		}
+	}
+	if _, ok := c.Get("cold"); ok {
+		t.Fatalf("errors must not be cached")
+	}
+}
+
+func TestGetOrComputePanicReachesWaiters(t *testing.T) {
+	c := NewMemory()
+	defer c.Close()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	leaderDone := make(chan any, 1)
+	go func() {
+		defer func() { leaderDone <- recover() }()
+		_, _ = c.GetOrCompute(context.Background(), "k", 0, func(context.Context) (any, error) {
+			close(started)
+			<-release
+			panic("compute failed")
+		})
+	}()
+	<-started
+
+	waiter := make(chan error, 1)
+	go func() {
+		_, err := c.GetOrCompute(context.Background(), "k", 0, func(context.Context) (any, error) {
+			return "unexpected", nil
+		})
+		waiter <- err
+	}()
+	time.Sleep(20 * time.Millisecond) // let the waiter join the flight
+	close(release)
+
+	if r := <-leaderDone; r != "compute failed" {
+		t.Fatalf("leader should re-panic, recovered %v", r)
+	}
+	if err := <-waiter; err == nil || !strings.Contains(err.Error(), "panicked") {
+		t.Fatalf("waiter err = %v, want panic error", err)
+	}
+	if _, ok := c.Get("k"); ok {
+		t.Fatalf("a panicking compute must not cache a value")
+	}
+}
+
+func TestGetOrComputeLeaderCancelDoesNotFailWaiters(t *testing.T) {
+	c := NewMemory()
+	defer c.Close()
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	var calls atomic.Int32
+	compute := func(ctx context.Context) (any, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return "value", nil
+	}
+
+	leader := make(chan error, 1)
+	go func() {
+		_, err := c.GetOrCompute(leaderCtx, "k", 0, compute)
+		leader <- err
+	}()
+	<-started
+
+	waiter := make(chan any, 1)
+	go func() {
+		v, err := c.GetOrCompute(context.Background(), "k", 0, compute)
+		if err != nil {
+			t.Errorf("waiter: %v", err)
+		}
+		waiter <- v
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if err := <-leader; !errors.Is(err, context.Canceled) {
+		t.Fatalf("leader err = %v, want context.Canceled", err)
+	}
+	if v := <-waiter; v != "value" {
+		t.Fatalf("waiter got %v, want recomputed value", v)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("compute ran %d times, want 2", got)
+	}
+}
+
+func TestGetOrComputeNegativeTTL(t *testing.T) {
+	c := NewMemory(WithNegativeTTL(50 * time.Millisecond))
+	defer c.Close()
+
+	var calls int
+	compute := func(context.Context) (any, error) {
+		calls++
+		return nil, fmt.Errorf("user 42: %w", ErrNotFound)
+	}
+
+	_, err := c.GetOrCompute(context.Background(), "user:42", time.Minute, compute)
+	if !errors.Is(err, ErrNotFound) || calls != 1 {
+		t.Fatalf("first call: err=%v calls=%d", err, calls)
+	}
+	_, err = c.GetOrCompute(context.Background(), "user:42", time.Minute, compute)
+	if !errors.Is(err, ErrNotFound) || calls != 1 {
+		t.Fatalf("negative entry should prevent a second compute: err=%v calls=%d", err, calls)
+	}
+
+	// The negative entry is not a value
+	if v, ok := c.Get("user:42"); ok || v != nil {
+		t.Fatalf("negative entry must read as a miss, got %v ok=%v", v, ok)
+	}
+	if keys := c.Keys(); len(keys) != 0 {
+		t.Fatalf("negative entry must not be listed, got %v", keys)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, err = c.GetOrCompute(context.Background(), "user:42", time.Minute, compute); calls != 2 {
+		t.Fatalf("want recompute after negative TTL, calls=%d err=%v", calls, err)
+	}
+
+	// A real value replaces the negative entry
+	c.Set("user:42", "alice", 0)
+	if v, err := c.GetOrCompute(context.Background(), "user:42", time.Minute, compute); err != nil || v != "alice" || calls != 2 {
+		t.Fatalf("want stored value, got %v err=%v calls=%d", v, err, calls)
+	}
+}
+
+func TestGetOrComputeNotFoundWithoutNegativeTTL(t *testing.T) {
+	c := NewMemory()
+	defer c.Close()
+
+	var calls int
+	compute := func(context.Context) (any, error) {
+		calls++
+		return nil, ErrNotFound
+	}
+	c.GetOrCompute(context.Background(), "missing", 0, compute)
+	c.GetOrCompute(context.Background(), "missing", 0, compute)
+	if calls != 2 {
+		t.Fatalf("not-found must not be cached by default, calls=%d", calls)
+	}
+}
+
+func TestKeysSkipsExpired(t *testing.T) {
+	c := NewMemory()
+	defer c.Close()
+	c.Set("live", 1, 0)
+	c.Set("also-live", 2, time.Minute)
+	c.Set("expired", 3, 5*time.Millisecond)
+	time.Sleep(15 * time.Millisecond)
+
+	keys := c.Keys()
+	sort.Strings(keys)
+	if len(keys) != 2 || keys[0] != "also-live" || keys[1] != "live" {
+		t.Fatalf("unexpected keys: %v", keys)
+	}
+}
+
+func TestRange(t *testing.T) {
+	c := NewMemory()
+	defer c.Close()
+	for i := 0; i < 5; i++ {
+		c.Set(string(rune('a'+i)), i, 0)
+	}
+	c.Set("expired", 99, 5*time.Millisecond)
+	time.Sleep(15 * time.Millisecond)
+
+	sum := 0
+	c.Range(func(key string, value any) bool {
+		if key == "expired" {
+			t.Fatalf("expired entry visited")
+		}
+		sum += value.(int)
+		return true
+	})
+	if sum != 0+1+2+3+4 {
+		t.Fatalf("sum=%d, want 10", sum)
+	}
+
+	visited := 0
+	c.Range(func(key string, value any) bool {
+		visited++
+		// Callbacks may write to the cache without deadlocking.
+		c.Set("seen:"+key, true, 0)
+		return visited < 2
+	})
+	if visited != 2 {
+		t.Fatalf("range should stop early, visited %d", visited)
+	}
+}
+
+func TestRemainingTTL(t *testing.T) {
+	c := NewMemory()
+	defer c.Close()
+
+	c.Set("ttl", 1, time.Minute)
+	d, ok := c.TTL("ttl")
+	if !ok || d <= 0 || d > time.Minute {
+		t.Fatalf("unexpected ttl: %v ok=%v", d, ok)
+	}
+
+	c.Set("forever", 1, 0)
+	if d, ok := c.TTL("forever"); !ok || d != 0 {
+		t.Fatalf("no-expiry entry: got %v ok=%v", d, ok)
+	}
+
+	c.Set("short", 1, 5*time.Millisecond)
+	time.Sleep(15 * time.Millisecond)
+	if _, ok := c.TTL("short"); ok {
+		t.Fatalf("expired entry should report ok=false")
+	}
+	if _, ok := c.TTL("missing"); ok {
+		t.Fatalf("missing key should report ok=false")
+	}
+}
+
+type evictRecord struct {
+	key    string
+	value  any
+	reason EvictReason
+}
+
+type evictRecorder struct {
+	mu      sync.Mutex
+	records []evictRecord
+}
+
+func (r *evictRecorder) record(key string, value any, reason EvictReason) {
+	r.mu.Lock()
+	r.records = append(r.records, evictRecord{key, value, reason})
+	r.mu.Unlock()
+}
+
+func (r *evictRecorder) byReason(reason EvictReason) []evictRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []evictRecord
+	for _, rec := range r.records {
+		if rec.reason == reason {
+			out = append(out, rec)
+		}
+	}
+	return out
+}
+
+func TestOnEvictReasons(t *testing.T) {
+	rec := &evictRecorder{}
+	c := NewMemory(WithMaxEntries(2), WithOnEvict(rec.record))
+	defer c.Close()
+
+	// expiry (observed by Get)
+	c.Set("short", "s", 5*time.Millisecond)
+	time.Sleep(15 * time.Millisecond)
+	if _, ok := c.Get("short"); ok {
+		t.Fatalf("expected short expired")
+	}
+	if got := rec.byReason(EvictExpired); len(got) != 1 || got[0].key != "short" || got[0].value != "s" {
+		t.Fatalf("expired records: %+v", got)
+	}
+
+	// capacity
+	c.Set("a", 1, 0)
+	c.Set("b", 2, 0)
+	c.Set("c", 3, 0)
+	if got := rec.byReason(EvictCapacity); len(got) != 1 || got[0].key != "a" {
+		t.Fatalf("capacity records: %+v", got)
+	}
+
+	// delete (missing keys are not reported)
+	c.Delete("b")
+	c.Delete("missing")
+	if got := rec.byReason(EvictDeleted); len(got) != 1 || got[0].key != "b" || got[0].value != 2 {
+		t.Fatalf("deleted records: %+v", got)
+	}
+
+	// clear
+	c.Clear()
+	if got := rec.byReason(EvictCleared); len(got) != 1 || got[0].key != "c" {
+		t.Fatalf("cleared records: %+v", got)
+	}
+}
+
+func TestOnEvictJanitorAndReentrancy(t *testing.T) {
+	var c Cache
+	evicted := make(chan string, 1)
+	c = NewMemory(WithCleanupInterval(5*time.Millisecond), WithOnEvict(func(key string, value any, reason EvictReason) {
+		if reason != EvictExpired {
+			return
+		}
+		// Touching the cache from the callback must not deadlock.
+		c.Set("evicted:"+key, true, 0)
+		evicted <- key
+	}))
+	defer c.Close()
+
+	c.Set("k", 1, 5*time.Millisecond)
+	select {
+	case key := <-evicted:
+		if key != "k" {
+			t.Fatalf("unexpected key %q", key)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("janitor did not report expiry")
+	}
+	if _, ok := c.Get("evicted:k"); !ok {
+		t.Fatalf("callback write missing")
+	}
+}
+
+func TestEvictReasonString(t *testing.T) {
+	for reason, want := range map[EvictReason]string{
+		EvictExpired:    "expired",
+		EvictCapacity:   "capacity",
+		EvictDeleted:    "deleted",
+		EvictCleared:    "cleared",
+		EvictReason(99): "unknown",
+	} {
+		if got := reason.String(); got != want {
+			t.Fatalf("%d.String()=%q, want %q", reason, got, want)
+		}
+	}
+}
+
+func TestIncrementConcurrent(t *testing.T) {
+	c := NewMemory()
+	defer c.Close()
+	counter := c.(Counter)
+
+	const goroutines, perGoroutine = 16, 100
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				if _, err := counter.Increment("hits", 1, time.Minute); err != nil {
+					t.Errorf("increment: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	v, ok := c.Get("hits")
+	if !ok || v.(int64) != goroutines*perGoroutine {
+		t.Fatalf("want %d, got %v", goroutines*perGoroutine, v)
+	}
+}
+
+func TestIncrementDecrement(t *testing.T) {
+	c := NewMemory()
+	defer c.Close()
+	counter := c.(Counter)
+
+	if n, err := counter.Increment("n", 5, 0); err != nil || n != 5 {
+		t.Fatalf("create: n=%d err=%v", n, err)
+	}
+	if n, err := counter.Decrement("n", 2, 0); err != nil || n != 3 {
+		t.Fatalf("decrement: n=%d err=%v", n, err)
+	}
+	if n, err := counter.Decrement("fresh", 4, 0); err != nil || n != -4 {
+		t.Fatalf("decrement missing key: n=%d err=%v", n, err)
+	}
+
+	// Existing integers of other widths are accepted.
+	c.Set("small", int32(7), 0)
+	if n, err := counter.Increment("small", 1, 0); err != nil || n != 8 {
+		t.Fatalf("int32 value: n=%d err=%v", n, err)
+	}
+
+	c.Set("name", "bob", 0)
+	if _, err := counter.Increment("name", 1, 0); !errors.Is(err, ErrNotInteger) {
+		t.Fatalf("want ErrNotInteger, got %v", err)
+	}
+	if v, _ := c.Get("name"); v != "bob" {
+		t.Fatalf("non-integer value was modified: %v", v)
+	}
+
+	// Unsigned values beyond int64 are rejected rather than wrapped.
+	c.Set("huge", uint64(math.MaxUint64), 0)
+	if _, err := counter.Increment("huge", 1, 0); !errors.Is(err, ErrNotInteger) {
+		t.Fatalf("want ErrNotInteger for uint64 overflow, got %v", err)
+	}
+}
+
+func TestIncrementOverflow(t *testing.T) {
+	c := NewMemory()
+	defer c.Close()
+	counter := c.(Counter)
+
+	if _, err := counter.Increment("max", math.MaxInt64, 0); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := counter.Increment("max", 1, 0); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("want ErrOverflow, got %v", err)
+	}
+	if v, _ := c.Get("max"); v != int64(math.MaxInt64) {
+		t.Fatalf("value changed on overflow: %v", v)
+	}
+
+	if _, err := counter.Decrement("min", math.MaxInt64, 0); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := counter.Decrement("min", 2, 0); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("want ErrOverflow, got %v", err)
+	}
+	if _, err := counter.Decrement("other", math.MinInt64, 0); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("want ErrOverflow negating MinInt64, got %v", err)
+	}
+}
+
+func TestIncrementTTL(t *testing.T) {
+	c := NewMemory()
+	defer c.Close()
+	counter := c.(Counter)
+
+	if _, err := counter.Increment("window", 1, 30*time.Millisecond); err != nil {
+		t.Fatalf("increment: %v", err)
+	}
+	// Later increments keep the original expiration.
+	time.Sleep(20 * time.Millisecond)
+	_, _ = counter.Increment("window", 1, time.Hour)
+	if remaining, ok := c.TTL("window"); !ok || remaining > 30*time.Millisecond {
+		t.Fatalf("expiration should not be extended, remaining=%v ok=%v", remaining, ok)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if n, err := counter.Increment("window", 1, time.Hour); err != nil || n != 1 {
+		t.Fatalf("expired counter should restart at delta: n=%d err=%v", n, err)
+	}
+}
+
+func TestMaxEntriesRecencyAcrossOperations(t *testing.T) {
+	c := NewMemory(WithMaxEntries(3))
+	defer c.Close()
+	counter := c.(Counter)
+
+	c.Set("a", 1, 0)
+	c.Set("b", 2, 0)
+	c.Set("c", 3, 0)
+	// Increment and overwrite both count as use; "c" becomes the least recently used.
+	if _, err := counter.Increment("a", 1, 0); err != nil {
+		t.Fatalf("increment: %v", err)
+	}
+	c.Set("b", 20, 0)
+	c.Set("d", 4, 0)
+	if _, ok := c.Get("c"); ok {
+		t.Fatalf("expected c evicted")
+	}
+
+	// Deleted and cleared keys leave the recency order too.
+	c.Delete("a")
+	c.Set("e", 5, 0)
+	for _, k := range []string{"b", "d", "e"} {
+		if _, ok := c.Get(k); !ok {
+			t.Fatalf("expected %s present", k)
+		}
+	}
+	c.Clear()
+	for _, k := range []string{"x", "y", "z"} {
+		c.Set(k, k, 0)
+	}
+	if size := c.Size(); size != 3 {
+		t.Fatalf("size = %d, want 3", size)
+	}
+}
@@ -0,0 +1,26 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Memoize wraps fn so results are stored in c for ttl and looked up by argument.
+// Keys are built with fmt.Sprint, so distinct arguments that print the same share an entry;
+// use MemoizeWithKey to namespace keys when several functions share one cache.
+// Concurrent calls with the same argument share one invocation of fn, and errors are not
+// cached except for ErrNotFound under WithNegativeTTL, as with GetOrCompute.
+func Memoize[K comparable, V any](c Cache, ttl time.Duration, fn func(context.Context, K) (V, error)) func(context.Context, K) (V, error) {
+	return MemoizeWithKey(c, ttl, func(k K) string { return fmt.Sprint(k) }, fn)
+}
+
+// MemoizeWithKey is like Memoize but derives the cache key for each argument with key.
+func MemoizeWithKey[K comparable, V any](c Cache, ttl time.Duration, key func(K) string, fn func(context.Context, K) (V, error)) func(context.Context, K) (V, error) {
+	typed := NewTyped[V](c)
+	return func(ctx context.Context, k K) (V, error) {
+		return typed.GetOrCompute(ctx, key(k), ttl, func(ctx context.Context) (V, error) {
+			return fn(ctx, k)
+		})
+	}
+}
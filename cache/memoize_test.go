@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMemoize_RunsOncePerKey(t *testing.T) {
+	c := NewMemory()
+	defer c.Close()
+
+	calls := map[int]int{}
+	square := Memoize(c, 0, func(ctx context.Context, n int) (int, error) {
+		calls[n]++
+		return n * n, nil
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		for _, n := range []int{2, 3} {
+			got, err := square(ctx, n)
+			if err != nil || got != n*n {
+				t.Fatalf("square(%d) = %d, %v", n, got, err)
+			}
+		}
+	}
+	if calls[2] != 1 || calls[3] != 1 {
+		t.Fatalf("calls = %v, want one per key", calls)
+	}
+}
+
+func TestMemoize_ErrorsNotCached(t *testing.T) {
+	c := NewMemory()
+	defer c.Close()
+
+	var calls atomic.Int32
+	boom := errors.New("boom")
+	fail := Memoize(c, 0, func(ctx context.Context, id string) (string, error) {
+		calls.Add(1)
+		return "", boom
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := fail(context.Background(), "x"); !errors.Is(err, boom) {
+			t.Fatalf("err = %v, want boom", err)
+		}
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("calls = %d, want 2", got)
+	}
+}
+
+func TestMemoizeWithKey(t *testing.T) {
+	c := NewMemory()
+	defer c.Close()
+
+	var calls atomic.Int32
+	lookup := MemoizeWithKey(c, 0, func(id int) string { return "user:" + strconv.Itoa(id) },
+		func(ctx context.Context, id int) (user, error) {
+			calls.Add(1)
+			return user{ID: id}, nil
+		})
+
+	for i := 0; i < 2; i++ {
+		if u, err := lookup(context.Background(), 7); err != nil || u.ID != 7 {
+			t.Fatalf("lookup = %+v, %v", u, err)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("calls = %d, want 1", got)
+	}
+	if _, ok := c.Get("user:7"); !ok {
+		t.Fatalf("expected entry under custom key")
+	}
+}
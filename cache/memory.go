@@ -1,7 +1,11 @@
 package cache
 
 import (
+	"container/list"
 	"context"
+	"errors"
+	"fmt"
+	"math"
 	"sync"
 	"time"
 )
@@ -11,12 +15,14 @@ type entry struct {
 	exp        time.Time // zero means no expiration
 	lastAccess time.Time
 	ttl        time.Duration // original TTL used for sliding TTL
+	negative   bool          // not-found marker stored by GetOrCompute; reads treat it as a miss
 }
 
 type memory struct {
 	mu           sync.RWMutex
 	items        map[string]entry
 	defaultTTL   time.Duration
+	negativeTTL  time.Duration
 	cleanupEvery time.Duration
 	stop         chan struct{}
 
@@ -24,21 +30,52 @@ type memory struct {
 	trackAccess bool
 	sliding     bool
 	trackStats  bool
+	maxEntries  int
+	onEvict     func(key string, value any, reason EvictReason)
+
+	// lru orders keys from most to least recently used when maxEntries is set
+	lru      *list.List
+	lruElems map[string]*list.Element
 
 	// stats
 	hits      int
 	misses    int
 	evictions int
+
+	// single-flight state for GetOrCompute
+	flightMu sync.Mutex
+	inflight map[string]*flight
+}
+
+// eviction records a removed entry so OnEvict can be notified after the lock is released.
+type eviction struct {
+	key    string
+	val    any
+	reason EvictReason
+}
+
+// flight is an in-progress GetOrCompute computation shared by concurrent callers.
+type flight struct {
+	done chan struct{}
+	val  any
+	err  error
+	// canceled is set when err comes from the leader's context ending, so waiters retry.
+	canceled bool
 }
 
 func newMemory(opts ...Option) *memory {
 	m := &memory{
-		items: make(map[string]entry),
-		stop:  make(chan struct{}),
+		items:    make(map[string]entry),
+		stop:     make(chan struct{}),
+		inflight: make(map[string]*flight),
 	}
 	for _, opt := range opts {
 		opt(m)
 	}
+	if m.maxEntries > 0 {
+		m.lru = list.New()
+		m.lruElems = make(map[string]*list.Element, m.maxEntries)
+	}
 	if m.cleanupEvery > 0 {
 		go m.janitor()
 	}
@@ -60,16 +97,34 @@ func (m *memory) janitor() {
 
 func (m *memory) cleanup() {
 	now := time.Now()
+	var evicted []eviction
 	m.mu.Lock()
 	for k, e := range m.items {
 		if !e.exp.IsZero() && now.After(e.exp) {
-			delete(m.items, k)
+			m.remove(k)
 			if m.trackStats {
 				m.evictions++
 			}
+			evicted = m.recordEviction(evicted, k, e.val, EvictExpired)
 		}
 	}
 	m.mu.Unlock()
+	m.notifyEvicted(evicted)
+}
+
+// recordEviction appends an eviction for later notification when an OnEvict hook is set.
+func (m *memory) recordEviction(evicted []eviction, key string, val any, reason EvictReason) []eviction {
+	if m.onEvict == nil {
+		return evicted
+	}
+	return append(evicted, eviction{key: key, val: val, reason: reason})
+}
+
+// notifyEvicted invokes the OnEvict hook; callers must not hold m.mu.
+func (m *memory) notifyEvicted(evicted []eviction) {
+	for _, ev := range evicted {
+		m.onEvict(ev.key, ev.val, ev.reason)
+	}
 }
 
 func (m *memory) Get(key string) (any, bool) {
@@ -85,12 +140,20 @@ func (m *memory) Get(key string) (any, bool) {
 	}
 	// expired?
 	if !e.exp.IsZero() && now.After(e.exp) {
-		delete(m.items, key)
+		m.remove(key)
 		if m.trackStats {
 			m.evictions++
 			m.misses++
 		}
 		m.mu.Unlock()
+		m.notifyEvicted(m.recordEviction(nil, key, e.val, EvictExpired))
+		return nil, false
+	}
+	if e.negative {
+		if m.trackStats {
+			m.misses++
+		}
+		m.mu.Unlock()
 		return nil, false
 	}
 	// last access
@@ -101,6 +164,7 @@ func (m *memory) Get(key string) (any, bool) {
 			e.exp = now.Add(e.ttl)
 		}
 		m.items[key] = e
+		m.touch(key)
 	}
 	if m.trackStats {
 		m.hits++
@@ -111,6 +175,11 @@ func (m *memory) Get(key string) (any, bool) {
 }
 
 func (m *memory) Set(key string, value any, ttl time.Duration) {
+	m.store(key, value, ttl, false)
+}
+
+// store inserts an entry, evicting to make room if the cache is bounded.
+func (m *memory) store(key string, value any, ttl time.Duration, negative bool) {
 	exp := time.Time{}
 	origTTL := time.Duration(0)
 	if ttl <= 0 {
@@ -122,25 +191,186 @@ func (m *memory) Set(key string, value any, ttl time.Duration) {
 		exp = time.Now().Add(ttl)
 		origTTL = ttl
 	}
-	e := entry{val: value, exp: exp, ttl: origTTL}
+	e := entry{val: value, exp: exp, ttl: origTTL, negative: negative}
 	if m.trackAccess {
 		e.lastAccess = time.Now()
 	}
+	var evicted []eviction
 	m.mu.Lock()
+	if _, exists := m.items[key]; !exists && m.maxEntries > 0 {
+		for len(m.items) >= m.maxEntries {
+			evicted = m.evictLRU(evicted)
+		}
+	}
 	m.items[key] = e
+	m.touch(key)
 	m.mu.Unlock()
+	m.notifyEvicted(evicted)
 }
 
-func (m *memory) Delete(key string) {
+func (m *memory) Increment(key string, delta int64, ttl time.Duration) (int64, error) {
+	now := time.Now()
+	var evicted []eviction
 	m.mu.Lock()
+	e, ok := m.items[key]
+	if ok && !e.exp.IsZero() && now.After(e.exp) {
+		m.remove(key)
+		if m.trackStats {
+			m.evictions++
+		}
+		evicted = m.recordEviction(evicted, key, e.val, EvictExpired)
+		ok = false
+	}
+
+	var next int64
+	if ok && !e.negative {
+		current, isInt := toInt64(e.val)
+		if !isInt {
+			m.mu.Unlock()
+			m.notifyEvicted(evicted)
+			return 0, fmt.Errorf("%w: key %q holds %T", ErrNotInteger, key, e.val)
+		}
+		if (delta > 0 && current > math.MaxInt64-delta) || (delta < 0 && current < math.MinInt64-delta) {
+			m.mu.Unlock()
+			m.notifyEvicted(evicted)
+			return 0, fmt.Errorf("%w: key %q holds %d, adding %d", ErrOverflow, key, current, delta)
+		}
+		next = current + delta
+		e.val = next
+		if m.trackAccess {
+			e.lastAccess = now
+			if m.sliding && e.ttl > 0 {
+				e.exp = now.Add(e.ttl)
+			}
+		}
+	} else {
+		if !ok && m.maxEntries > 0 {
+			for len(m.items) >= m.maxEntries {
+				evicted = m.evictLRU(evicted)
+			}
+		}
+		next = delta
+		e = entry{val: next}
+		if ttl <= 0 {
+			ttl = m.defaultTTL
+		}
+		if ttl > 0 {
+			e.exp = now.Add(ttl)
+			e.ttl = ttl
+		}
+		if m.trackAccess {
+			e.lastAccess = now
+		}
+	}
+	m.items[key] = e
+	m.touch(key)
+	m.mu.Unlock()
+	m.notifyEvicted(evicted)
+	return next, nil
+}
+
+func (m *memory) Decrement(key string, delta int64, ttl time.Duration) (int64, error) {
+	if delta == math.MinInt64 {
+		return 0, fmt.Errorf("%w: cannot negate %d", ErrOverflow, delta)
+	}
+	return m.Increment(key, -delta, ttl)
+}
+
+// toInt64 converts integer values of any width to int64, rejecting unsigned values above MaxInt64.
+func toInt64(v any) (int64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return n, true
+	case int:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case int16:
+		return int64(n), true
+	case int8:
+		return int64(n), true
+	case uint:
+		return int64(n), uint64(n) <= math.MaxInt64
+	case uint64:
+		return int64(n), n <= math.MaxInt64
+	case uint32:
+		return int64(n), true
+	case uint16:
+		return int64(n), true
+	case uint8:
+		return int64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// evictLRU removes the least-recently-used entry, reporting it as expired if its TTL has
+// already elapsed. Callers must hold m.mu.
+func (m *memory) evictLRU(evicted []eviction) []eviction {
+	back := m.lru.Back()
+	if back == nil {
+		return evicted
+	}
+	victim := back.Value.(string)
+	e := m.items[victim]
+	reason := EvictCapacity
+	if !e.exp.IsZero() && time.Now().After(e.exp) {
+		reason = EvictExpired
+	}
+	m.remove(victim)
+	if m.trackStats {
+		m.evictions++
+	}
+	return m.recordEviction(evicted, victim, e.val, reason)
+}
+
+// touch marks key as the most recently used. Callers must hold m.mu.
+func (m *memory) touch(key string) {
+	if m.lru == nil {
+		return
+	}
+	if el, ok := m.lruElems[key]; ok {
+		m.lru.MoveToFront(el)
+		return
+	}
+	m.lruElems[key] = m.lru.PushFront(key)
+}
+
+// remove deletes key from the cache and its recency list. Callers must hold m.mu.
+func (m *memory) remove(key string) {
 	delete(m.items, key)
+	if m.lru == nil {
+		return
+	}
+	if el, ok := m.lruElems[key]; ok {
+		m.lru.Remove(el)
+		delete(m.lruElems, key)
+	}
+}
+
+func (m *memory) Delete(key string) {
+	m.mu.Lock()
+	e, ok := m.items[key]
+	m.remove(key)
 	m.mu.Unlock()
+	if ok {
+		m.notifyEvicted(m.recordEviction(nil, key, e.val, EvictDeleted))
+	}
 }
 
 func (m *memory) Clear() {
+	var evicted []eviction
 	m.mu.Lock()
+	for k, e := range m.items {
+		evicted = m.recordEviction(evicted, k, e.val, EvictCleared)
+	}
 	m.items = make(map[string]entry)
+	if m.lru != nil {
+		m.lru.Init()
+		m.lruElems = make(map[string]*list.Element, m.maxEntries)
+	}
 	m.mu.Unlock()
+	m.notifyEvicted(evicted)
 }
 
 func (m *memory) Size() int {
@@ -166,12 +396,93 @@ func (m *memory) GetOrCompute(ctx context.Context, key string, ttl time.Duration
 	if compute == nil {
 		return nil, nil
 	}
-	v, err := compute(ctx)
-	if err != nil {
-		return nil, err
+	if m.negativeHit(key) {
+		return nil, ErrNotFound
+	}
+
+	for {
+		m.flightMu.Lock()
+		f, ok := m.inflight[key]
+		if !ok {
+			f = &flight{done: make(chan struct{})}
+			m.inflight[key] = f
+			m.flightMu.Unlock()
+			return m.lead(ctx, key, ttl, compute, f)
+		}
+		m.flightMu.Unlock()
+
+		select {
+		case <-f.done:
+			// The leader's own cancellation says nothing about the value; compute it anew.
+			if f.canceled && ctx.Err() == nil {
+				continue
+			}
+			return f.val, f.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// lead runs compute for key on behalf of every caller waiting on f. A panic in compute is
+// reported to the waiters as an error and then re-raised.
+func (m *memory) lead(ctx context.Context, key string, ttl time.Duration, compute func(context.Context) (any, error), f *flight) (any, error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			f.val, f.err = nil, fmt.Errorf("cache: compute for %q panicked: %v", key, r)
+		}
+		m.flightMu.Lock()
+		delete(m.inflight, key)
+		m.flightMu.Unlock()
+		close(f.done)
+		if r != nil {
+			panic(r)
+		}
+	}()
+
+	// A previous flight may have stored the value after our initial miss.
+	if v, ok := m.peek(key); ok {
+		f.val = v
+		return v, nil
+	}
+	if m.negativeHit(key) {
+		f.err = ErrNotFound
+		return nil, f.err
+	}
+	f.val, f.err = compute(ctx)
+	if f.err != nil {
+		f.val = nil
+		f.canceled = ctx.Err() != nil && errors.Is(f.err, ctx.Err())
+		if m.negativeTTL > 0 && errors.Is(f.err, ErrNotFound) {
+			m.store(key, nil, m.negativeTTL, true)
+		}
+		return nil, f.err
+	}
+	m.Set(key, f.val, ttl)
+	return f.val, nil
+}
+
+// peek returns the live value for key without updating stats or access time.
+func (m *memory) peek(key string) (any, bool) {
+	m.mu.RLock()
+	e, ok := m.items[key]
+	m.mu.RUnlock()
+	if !ok || e.negative || (!e.exp.IsZero() && time.Now().After(e.exp)) {
+		return nil, false
+	}
+	return e.val, true
+}
+
+// negativeHit reports whether key holds a live not-found marker.
+func (m *memory) negativeHit(key string) bool {
+	if m.negativeTTL <= 0 {
+		return false
 	}
-	m.Set(key, v, ttl)
-	return v, nil
+	m.mu.RLock()
+	e, ok := m.items[key]
+	m.mu.RUnlock()
+	return ok && e.negative && time.Now().Before(e.exp)
 }
 
 func (m *memory) Info(key string) (expiresAt time.Time, lastAccess time.Time, ok bool) {
@@ -179,7 +490,7 @@ func (m *memory) Info(key string) (expiresAt time.Time, lastAccess time.Time, ok
 	m.mu.RLock()
 	e, ok := m.items[key]
 	m.mu.RUnlock()
-	if !ok {
+	if !ok || e.negative {
 		return time.Time{}, time.Time{}, false
 	}
 	if !e.exp.IsZero() && now.After(e.exp) {
@@ -188,10 +499,67 @@ func (m *memory) Info(key string) (expiresAt time.Time, lastAccess time.Time, ok
 	return e.exp, e.lastAccess, true
 }
 
+func (m *memory) TTL(key string) (time.Duration, bool) {
+	now := time.Now()
+	m.mu.RLock()
+	e, ok := m.items[key]
+	m.mu.RUnlock()
+	if !ok || e.negative {
+		return 0, false
+	}
+	if e.exp.IsZero() {
+		return 0, true
+	}
+	if now.After(e.exp) {
+		return 0, false
+	}
+	return e.exp.Sub(now), true
+}
+
+func (m *memory) Keys() []string {
+	now := time.Now()
+	m.mu.RLock()
+	keys := make([]string, 0, len(m.items))
+	for k, e := range m.items {
+		if e.negative || (!e.exp.IsZero() && now.After(e.exp)) {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	m.mu.RUnlock()
+	return keys
+}
+
+func (m *memory) Range(fn func(key string, value any) bool) {
+	type kv struct {
+		key string
+		val any
+	}
+	now := time.Now()
+	m.mu.RLock()
+	snapshot := make([]kv, 0, len(m.items))
+	for k, e := range m.items {
+		if e.negative || (!e.exp.IsZero() && now.After(e.exp)) {
+			continue
+		}
+		snapshot = append(snapshot, kv{k, e.val})
+	}
+	m.mu.RUnlock()
+
+	for _, e := range snapshot {
+		if !fn(e.key, e.val) {
+			return
+		}
+	}
+}
+
 func (m *memory) Stats() (hits, misses, evictions, size int) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	return m.hits, m.misses, m.evictions, len(m.items)
 }
 
-var _ Cache = (*memory)(nil)
+var (
+	_ Cache   = (*memory)(nil)
+	_ Counter = (*memory)(nil)
+)
@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// L2 is a shared second-tier cache, such as Redis, behind a Tiered cache.
+// A miss is reported as ok=false with a nil error. Implementations must be safe for concurrent use.
+type L2 interface {
+	Get(ctx context.Context, key string) (value any, ok bool, err error)
+	Set(ctx context.Context, key string, value any, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
+
+// TieredOption configures a Tiered cache.
+type TieredOption func(*Tiered)
+
+// WithL1TTL caps how long entries stay in L1, so instances pick up L2 changes within d.
+// Entries promoted from L2 use d as their L1 TTL. If unset, L1 uses the TTL passed to
+// Set (or its own default for promoted entries).
+func WithL1TTL(d time.Duration) TieredOption {
+	return func(t *Tiered) {
+		t.l1TTL = d
+	}
+}
+
+// WithL2ErrorHandler sets a callback invoked when an L2 operation fails. op is "get", "set" or "delete".
+// L2 read errors are otherwise treated as misses.
+func WithL2ErrorHandler(f func(op, key string, err error)) TieredOption {
+	return func(t *Tiered) {
+		t.onL2Error = f
+	}
+}
+
+// Tiered is a two-tier cache: a small local L1 in front of a shared L2.
+// Reads check L1, then L2, promoting L2 hits into L1; writes go through to both.
+type Tiered struct {
+	l1        Cache
+	l2        L2
+	l1TTL     time.Duration
+	onL2Error func(op, key string, err error)
+}
+
+// NewTiered returns a Tiered cache using l1 as the local tier and l2 as the shared tier.
+func NewTiered(l1 Cache, l2 L2, opts ...TieredOption) *Tiered {
+	t := &Tiered{l1: l1, l2: l2}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Get returns the value for key from L1, or from L2 on an L1 miss, storing L2 hits in L1.
+// L2 errors are reported to the error handler and treated as misses.
+func (t *Tiered) Get(ctx context.Context, key string) (any, bool) {
+	if v, ok := t.l1.Get(key); ok {
+		return v, true
+	}
+	v, ok := t.getL2(ctx, key)
+	if !ok {
+		return nil, false
+	}
+	t.l1.Set(key, v, t.l1TTL)
+	return v, true
+}
+
+// Set stores value in L1 and writes it through to L2. The L1 entry is kept even if the
+// L2 write fails; the L2 error is reported and returned.
+func (t *Tiered) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	t.l1.Set(key, value, t.localTTL(ttl))
+	return t.setL2(ctx, key, value, ttl)
+}
+
+// Delete removes key from both tiers, returning the L2 error if any.
+func (t *Tiered) Delete(ctx context.Context, key string) error {
+	t.l1.Delete(key)
+	if err := t.l2.Delete(ctx, key); err != nil {
+		t.report("delete", key, err)
+		return err
+	}
+	return nil
+}
+
+// GetOrCompute returns the value for key from either tier, or invokes compute and stores
+// the result in both. Concurrent local misses for a key share one L2 lookup and compute,
+// as with Cache.GetOrCompute. A failed L2 write does not fail the call; it is reported.
+func (t *Tiered) GetOrCompute(ctx context.Context, key string, ttl time.Duration, compute func(context.Context) (any, error)) (any, error) {
+	if v, ok := t.l1.Get(key); ok {
+		return v, nil
+	}
+	if compute == nil {
+		v, _ := t.Get(ctx, key)
+		return v, nil
+	}
+
+	return t.l1.GetOrCompute(ctx, key, t.localTTL(ttl), func(ctx context.Context) (any, error) {
+		if v, ok := t.getL2(ctx, key); ok {
+			return v, nil
+		}
+		v, err := compute(ctx)
+		if err != nil {
+			return nil, err
+		}
+		_ = t.setL2(ctx, key, v, ttl)
+		return v, nil
+	})
+}
+
+// localTTL returns the TTL to use in L1 for an entry stored with ttl.
+func (t *Tiered) localTTL(ttl time.Duration) time.Duration {
+	if t.l1TTL > 0 && (ttl <= 0 || ttl > t.l1TTL) {
+		return t.l1TTL
+	}
+	return ttl
+}
+
+func (t *Tiered) getL2(ctx context.Context, key string) (any, bool) {
+	v, ok, err := t.l2.Get(ctx, key)
+	if err != nil {
+		t.report("get", key, err)
+		return nil, false
+	}
+	return v, ok
+}
+
+func (t *Tiered) setL2(ctx context.Context, key string, value any, ttl time.Duration) error {
+	if err := t.l2.Set(ctx, key, value, ttl); err != nil {
+		t.report("set", key, err)
+		return err
+	}
+	return nil
+}
+
+func (t *Tiered) report(op, key string, err error) {
+	if t.onL2Error != nil {
+		t.onL2Error(op, key, err)
+	}
+}
@@ -0,0 +1,180 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeL2 is an in-memory L2 that counts calls and can be made to fail.
+type fakeL2 struct {
+	mu    sync.Mutex
+	items map[string]any
+	err   error
+	gets  int
+	sets  int
+}
+
+func newFakeL2() *fakeL2 {
+	return &fakeL2{items: make(map[string]any)}
+}
+
+func (f *fakeL2) Get(_ context.Context, key string) (any, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.gets++
+	if f.err != nil {
+		return nil, false, f.err
+	}
+	v, ok := f.items[key]
+	return v, ok, nil
+}
+
+func (f *fakeL2) Set(_ context.Context, key string, value any, _ time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sets++
+	if f.err != nil {
+		return f.err
+	}
+	f.items[key] = value
+	return nil
+}
+
+func (f *fakeL2) Delete(_ context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	delete(f.items, key)
+	return nil
+}
+
+func TestTiered_PromotesL2Hit(t *testing.T) {
+	l1 := NewMemory()
+	defer l1.Close()
+	l2 := newFakeL2()
+	l2.items["user:42"] = "alice"
+	c := NewTiered(l1, l2, WithL1TTL(time.Minute))
+	ctx := context.Background()
+
+	v, ok := c.Get(ctx, "user:42")
+	if !ok || v != "alice" {
+		t.Fatalf("want L2 hit, got %v ok=%v", v, ok)
+	}
+	if got, ok := l1.Get("user:42"); !ok || got != "alice" {
+		t.Fatalf("want value promoted to L1, got %v ok=%v", got, ok)
+	}
+	if left, ok := l1.TTL("user:42"); !ok || left <= 0 || left > time.Minute {
+		t.Fatalf("want promoted entry to use the L1 TTL, got %v", left)
+	}
+
+	if _, ok := c.Get(ctx, "user:42"); !ok || l2.gets != 1 {
+		t.Fatalf("want second read served from L1, L2 gets=%d", l2.gets)
+	}
+}
+
+func TestTiered_SetWritesThrough(t *testing.T) {
+	l1 := NewMemory()
+	defer l1.Close()
+	l2 := newFakeL2()
+	c := NewTiered(l1, l2, WithL1TTL(time.Second))
+	ctx := context.Background()
+
+	if err := c.Set(ctx, "k", 1, time.Hour); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if v, ok := l1.Get("k"); !ok || v != 1 {
+		t.Fatalf("want L1 entry, got %v ok=%v", v, ok)
+	}
+	if v := l2.items["k"]; v != 1 {
+		t.Fatalf("want L2 entry, got %v", v)
+	}
+	if left, _ := l1.TTL("k"); left > time.Second {
+		t.Fatalf("want L1 TTL capped at 1s, got %v", left)
+	}
+
+	if err := c.Delete(ctx, "k"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, ok := c.Get(ctx, "k"); ok {
+		t.Fatalf("want key removed from both tiers")
+	}
+}
+
+func TestTiered_L2Errors(t *testing.T) {
+	l1 := NewMemory()
+	defer l1.Close()
+	l2 := newFakeL2()
+	l2.err = errors.New("connection refused")
+	var ops []string
+	c := NewTiered(l1, l2, WithL2ErrorHandler(func(op, key string, err error) {
+		ops = append(ops, op+":"+key)
+	}))
+	ctx := context.Background()
+
+	if _, ok := c.Get(ctx, "k"); ok {
+		t.Fatalf("want L2 error treated as miss")
+	}
+	if err := c.Set(ctx, "k", 1, 0); !errors.Is(err, l2.err) {
+		t.Fatalf("want L2 set error returned, got %v", err)
+	}
+	if v, ok := c.Get(ctx, "k"); !ok || v != 1 {
+		t.Fatalf("want L1 entry kept after failed L2 write, got %v ok=%v", v, ok)
+	}
+
+	v, err := c.GetOrCompute(ctx, "other", 0, func(context.Context) (any, error) { return 2, nil })
+	if err != nil || v != 2 {
+		t.Fatalf("want compute result despite L2 errors, got %v err=%v", v, err)
+	}
+
+	want := []string{"get:k", "set:k", "get:other", "set:other"}
+	if len(ops) != len(want) {
+		t.Fatalf("want reported ops %v, got %v", want, ops)
+	}
+	for i := range want {
+		if ops[i] != want[i] {
+			t.Fatalf("want reported ops %v, got %v", want, ops)
+		}
+	}
+}
+
+func TestTiered_GetOrCompute(t *testing.T) {
+	l1 := NewMemory()
+	defer l1.Close()
+	l2 := newFakeL2()
+	l2.items["cached"] = "from-l2"
+	c := NewTiered(l1, l2)
+	ctx := context.Background()
+
+	calls := 0
+	compute := func(context.Context) (any, error) {
+		calls++
+		return "computed", nil
+	}
+
+	if v, err := c.GetOrCompute(ctx, "cached", 0, compute); err != nil || v != "from-l2" || calls != 0 {
+		t.Fatalf("want L2 value without compute, got %v err=%v calls=%d", v, err, calls)
+	}
+	if v, ok := l1.Get("cached"); !ok || v != "from-l2" {
+		t.Fatalf("want L2 value promoted to L1, got %v ok=%v", v, ok)
+	}
+
+	if v, err := c.GetOrCompute(ctx, "fresh", 0, compute); err != nil || v != "computed" || calls != 1 {
+		t.Fatalf("want computed value, got %v err=%v calls=%d", v, err, calls)
+	}
+	if l2.items["fresh"] != "computed" {
+		t.Fatalf("want computed value written to L2, got %v", l2.items["fresh"])
+	}
+
+	wantErr := errors.New("boom")
+	if _, err := c.GetOrCompute(ctx, "bad", 0, func(context.Context) (any, error) { return nil, wantErr }); !errors.Is(err, wantErr) {
+		t.Fatalf("want compute error, got %v", err)
+	}
+	if _, ok := l2.items["bad"]; ok {
+		t.Fatalf("want nothing written to L2 on compute error")
+	}
+}
@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Typed is a type-safe view over a Cache that stores values of type T.
+type Typed[T any] struct {
+	c Cache
+}
+
+// NewTyped wraps c so values are read and written as T.
+func NewTyped[T any](c Cache) *Typed[T] {
+	return &Typed[T]{c: c}
+}
+
+// Cache returns the underlying untyped cache.
+func (t *Typed[T]) Cache() Cache {
+	return t.c
+}
+
+// Get returns the value for key if present, not expired, and of type T.
+// A stored value of another type is reported as a miss.
+func (t *Typed[T]) Get(key string) (T, bool) {
+	var zero T
+	v, ok := t.c.Get(key)
+	if !ok {
+		return zero, false
+	}
+	tv, ok := asT[T](v)
+	if !ok {
+		return zero, false
+	}
+	return tv, true
+}
+
+// asT converts a stored value to T. A nil value is the zero T when T is an interface
+// type, since v.(T) rejects it even though a nil T is what was stored.
+func asT[T any](v any) (T, bool) {
+	var zero T
+	if v == nil {
+		return zero, any(zero) == nil
+	}
+	tv, ok := v.(T)
+	return tv, ok
+}
+
+// Set stores value for key with the same TTL semantics as Cache.Set.
+func (t *Typed[T]) Set(key string, value T, ttl time.Duration) {
+	t.c.Set(key, value, ttl)
+}
+
+// Delete removes a key.
+func (t *Typed[T]) Delete(key string) {
+	t.c.Delete(key)
+}
+
+// GetOrCompute returns the cached value for key or invokes compute to produce and store it.
+// A stored value of another type is treated as a miss and replaced by the computed value;
+// compute never runs twice in one call.
+func (t *Typed[T]) GetOrCompute(ctx context.Context, key string, ttl time.Duration, compute func(context.Context) (T, error)) (T, error) {
+	var zero T
+	if compute == nil {
+		v, _ := t.Get(key)
+		return v, nil
+	}
+	var computed bool
+	var result T
+	v, err := t.c.GetOrCompute(ctx, key, ttl, func(ctx context.Context) (any, error) {
+		computed = true
+		tv, err := compute(ctx)
+		result = tv
+		return tv, err
+	})
+	if err != nil {
+		return zero, err
+	}
+	if computed {
+		return result, nil
+	}
+	if tv, ok := asT[T](v); ok {
+		return tv, nil
+	}
+
+	tv, err := compute(ctx)
+	if err != nil {
+		return zero, err
+	}
+	t.c.Set(key, tv, ttl)
+	return tv, nil
+}
@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type user struct {
+	ID   int
+	Name string
+}
+
+func TestTyped_GetHitAndMiss(t *testing.T) {
+	c := NewMemory()
+	defer c.Close()
+	users := NewTyped[user](c)
+
+	if _, ok := users.Get("u:1"); ok {
+		t.Fatalf("expected miss")
+	}
+
+	users.Set("u:1", user{ID: 1, Name: "ada"}, 0)
+	u, ok := users.Get("u:1")
+	if !ok || u.Name != "ada" {
+		t.Fatalf("got %+v ok=%v", u, ok)
+	}
+}
+
+func TestTyped_TypeMismatch(t *testing.T) {
+	c := NewMemory()
+	defer c.Close()
+	c.Set("u:1", "not a user", 0)
+
+	users := NewTyped[user](c)
+	u, ok := users.Get("u:1")
+	if ok || u != (user{}) {
+		t.Fatalf("mismatch should be zero/false, got %+v ok=%v", u, ok)
+	}
+
+	// GetOrCompute replaces the mismatched value.
+	u, err := users.GetOrCompute(context.Background(), "u:1", 0, func(context.Context) (user, error) {
+		return user{ID: 1}, nil
+	})
+	if err != nil || u.ID != 1 {
+		t.Fatalf("got %+v err=%v", u, err)
+	}
+	if u, ok := users.Get("u:1"); !ok || u.ID != 1 {
+		t.Fatalf("computed value not stored: %+v ok=%v", u, ok)
+	}
+}
+
+func TestTyped_GetOrCompute(t *testing.T) {
+	c := NewMemory()
+	defer c.Close()
+	counts := NewTyped[int](c)
+
+	calls := 0
+	compute := func(context.Context) (int, error) {
+		calls++
+		return 42, nil
+	}
+	for i := 0; i < 2; i++ {
+		v, err := counts.GetOrCompute(context.Background(), "n", 0, compute)
+		if err != nil || v != 42 {
+			t.Fatalf("got %d err=%v", v, err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("compute should run once, ran %d times", calls)
+	}
+
+	wantErr := errors.New("boom")
+	v, err := counts.GetOrCompute(context.Background(), "fail", 0, func(context.Context) (int, error) {
+		return 0, wantErr
+	})
+	if !errors.Is(err, wantErr) || v != 0 {
+		t.Fatalf("want error, got %d err=%v", v, err)
+	}
+	if _, ok := counts.Get("fail"); ok {
+		t.Fatalf("errors must not be cached")
+	}
+}
+
+func TestTyped_GetOrComputeNilInterface(t *testing.T) {
+	c := NewMemory()
+	defer c.Close()
+	results := NewTyped[error](c)
+
+	calls := 0
+	compute := func(context.Context) (error, error) {
+		calls++
+		return nil, nil
+	}
+	for i := 0; i < 3; i++ {
+		v, err := results.GetOrCompute(context.Background(), "last", 0, compute)
+		if err != nil || v != nil {
+			t.Fatalf("got %v err=%v", v, err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("a nil result should be cached after one run, ran %d times", calls)
+	}
+	if v, ok := results.Get("last"); !ok || v != nil {
+		t.Fatalf("want cached nil, got %v ok=%v", v, ok)
+	}
+}
@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// WarmSource loads a bulk set of entries to preload into a cache.
+type WarmSource func(ctx context.Context) (map[string]any, error)
+
+// WarmOption configures Warm.
+type WarmOption func(*warmConfig)
+
+type warmConfig struct {
+	refreshEvery   time.Duration
+	onRefreshError func(error)
+}
+
+// WithRefresh re-runs the warm load every interval in the background until ctx is done.
+func WithRefresh(interval time.Duration) WarmOption {
+	return func(c *warmConfig) {
+		c.refreshEvery = interval
+	}
+}
+
+// WithOnRefreshError sets a callback invoked when a background refresh fails.
+func WithOnRefreshError(f func(error)) WarmOption {
+	return func(c *warmConfig) {
+		c.onRefreshError = f
+	}
+}
+
+// Warm loads all entries from source into c with the given ttl and returns the number loaded.
+// Cancellation of ctx aborts loading between entries; entries already stored are kept and counted.
+// With WithRefresh, the initial load runs synchronously and later loads run in a goroutine
+// that stops when ctx is done.
+func Warm(ctx context.Context, c Cache, source WarmSource, ttl time.Duration, opts ...WarmOption) (int, error) {
+	var cfg warmConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	n, err := warmOnce(ctx, c, source, ttl)
+	if err != nil {
+		return n, err
+	}
+
+	if cfg.refreshEvery > 0 {
+		go refresh(ctx, c, source, ttl, cfg)
+	}
+	return n, nil
+}
+
+func warmOnce(ctx context.Context, c Cache, source WarmSource, ttl time.Duration) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	entries, err := source(ctx)
+	if err != nil {
+		return 0, err
+	}
+	loaded := 0
+	for k, v := range entries {
+		if err := ctx.Err(); err != nil {
+			return loaded, err
+		}
+		c.Set(k, v, ttl)
+		loaded++
+	}
+	return loaded, nil
+}
+
+func refresh(ctx context.Context, c Cache, source WarmSource, ttl time.Duration, cfg warmConfig) {
+	t := time.NewTicker(cfg.refreshEvery)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if _, err := warmOnce(ctx, c, source, ttl); err != nil && ctx.Err() == nil && cfg.onRefreshError != nil {
+				cfg.onRefreshError(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWarm(t *testing.T) {
+	c := NewMemory()
+	defer c.Close()
+
+	want := map[string]any{"a": 1, "b": 2, "c": 3}
+	n, err := Warm(context.Background(), c, func(context.Context) (map[string]any, error) {
+		return want, nil
+	}, time.Minute)
+	if err != nil || n != len(want) {
+		t.Fatalf("warm: n=%d err=%v", n, err)
+	}
+	for k, v := range want {
+		got, ok := c.Get(k)
+		if !ok || got != v {
+			t.Fatalf("key %q: got %v ok=%v, want %v", k, got, ok, v)
+		}
+	}
+}
+
+func TestWarm_SourceError(t *testing.T) {
+	c := NewMemory()
+	defer c.Close()
+
+	wantErr := errors.New("db down")
+	n, err := Warm(context.Background(), c, func(context.Context) (map[string]any, error) {
+		return nil, wantErr
+	}, 0)
+	if !errors.Is(err, wantErr) || n != 0 {
+		t.Fatalf("want source error, got n=%d err=%v", n, err)
+	}
+}
+
+// cancelAfterCache cancels a context after a fixed number of Set calls.
+type cancelAfterCache struct {
+	Cache
+	remaining int
+	cancel    context.CancelFunc
+}
+
+func (c *cancelAfterCache) Set(key string, value any, ttl time.Duration) {
+	c.Cache.Set(key, value, ttl)
+	c.remaining--
+	if c.remaining == 0 {
+		c.cancel()
+	}
+}
+
+func TestWarm_CancellationAbortsPartway(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mem := NewMemory()
+	defer mem.Close()
+	c := &cancelAfterCache{Cache: mem, remaining: 10, cancel: cancel}
+
+	entries := make(map[string]any, 100)
+	for i := 0; i < 100; i++ {
+		entries[fmt.Sprintf("k%d", i)] = i
+	}
+
+	n, err := Warm(ctx, c, func(context.Context) (map[string]any, error) {
+		return entries, nil
+	}, 0)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("want context.Canceled, got %v", err)
+	}
+	if n != 10 || mem.Size() != 10 {
+		t.Fatalf("want 10 loaded before cancel, got n=%d size=%d", n, mem.Size())
+	}
+}
+
+func TestWarm_Refresh(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := NewMemory()
+	defer c.Close()
+
+	var loads atomic.Int32
+	source := func(context.Context) (map[string]any, error) {
+		n := loads.Add(1)
+		return map[string]any{"version": int(n)}, nil
+	}
+
+	if _, err := Warm(ctx, c, source, 0, WithRefresh(5*time.Millisecond)); err != nil {
+		t.Fatalf("warm: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for loads.Load() < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("refresh did not run, loads=%d", loads.Load())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if v, _ := c.Get("version"); v.(int) < 2 {
+		t.Fatalf("expected refreshed value, got %v", v)
+	}
+
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	stopped := loads.Load()
+	time.Sleep(20 * time.Millisecond)
+	if loads.Load() != stopped {
+		t.Fatalf("refresh kept running after cancel")
+	}
+}
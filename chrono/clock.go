@@ -9,6 +9,20 @@ type Clock interface {
 	Now() time.Time
 	// Since returns the duration since t.
 	Since(t time.Time) time.Duration
+	// After waits for d to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+	// NewTimer creates a Timer that sends the current time on its channel after d.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is the Clock counterpart of time.Timer.
+type Timer interface {
+	// C returns the channel on which the time is delivered.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing and reports whether it was active.
+	Stop() bool
+	// Reset changes the timer to expire after d and reports whether it was active.
+	Reset(d time.Duration) bool
 }
 
 // SystemClock is the default Clock using the standard library time package.
@@ -21,6 +35,18 @@ func (SystemClock) Now() time.Time { return time.Now() }
 // Since implements Clock.
 func (SystemClock) Since(t time.Time) time.Duration { return time.Since(t) }
 
+// After implements Clock.
+func (SystemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// NewTimer implements Clock.
+func (SystemClock) NewTimer(d time.Duration) Timer { return systemTimer{time.NewTimer(d)} }
+
+type systemTimer struct{ t *time.Timer }
+
+func (s systemTimer) C() <-chan time.Time        { return s.t.C }
+func (s systemTimer) Stop() bool                 { return s.t.Stop() }
+func (s systemTimer) Reset(d time.Duration) bool { return s.t.Reset(d) }
+
 // Default is the package-level clock used by helper functions.
 var Default Clock = SystemClock{}
 
@@ -0,0 +1,129 @@
+package chrono
+
+import (
+	"sync"
+	"time"
+)
+
+// MockClock is a manually driven Clock for tests. Time only moves when Advance or Set
+// is called; timers and After channels fire once the mock time reaches their deadline.
+// It is safe for concurrent use.
+type MockClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*mockTimer
+}
+
+// NewMockClock returns a MockClock set to t.
+func NewMockClock(t time.Time) *MockClock {
+	return &MockClock{now: t}
+}
+
+// Now implements Clock.
+func (m *MockClock) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.now
+}
+
+// Since implements Clock.
+func (m *MockClock) Since(t time.Time) time.Duration {
+	return m.Now().Sub(t)
+}
+
+// After implements Clock.
+func (m *MockClock) After(d time.Duration) <-chan time.Time {
+	return m.NewTimer(d).C()
+}
+
+// NewTimer implements Clock. A timer with d <= 0 fires immediately.
+func (m *MockClock) NewTimer(d time.Duration) Timer {
+	t := &mockTimer{clock: m, ch: make(chan time.Time, 1)}
+	m.mu.Lock()
+	m.schedule(t, d)
+	m.mu.Unlock()
+	return t
+}
+
+// Advance moves the mock time forward by d and fires any timers that became due.
+func (m *MockClock) Advance(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.now = m.now.Add(d)
+	m.fire()
+}
+
+// Set moves the mock time to t and fires any timers that became due.
+func (m *MockClock) Set(t time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.now = t
+	m.fire()
+}
+
+// schedule arms t to fire after d; callers must hold m.mu.
+func (m *MockClock) schedule(t *mockTimer, d time.Duration) {
+	t.deadline = m.now.Add(d)
+	t.active = true
+	if d <= 0 {
+		t.send(m.now)
+		return
+	}
+	if !t.queued {
+		t.queued = true
+		m.timers = append(m.timers, t)
+	}
+}
+
+// fire delivers due timers; callers must hold m.mu.
+func (m *MockClock) fire() {
+	pending := m.timers[:0]
+	for _, t := range m.timers {
+		if t.active && t.deadline.After(m.now) {
+			pending = append(pending, t)
+			continue
+		}
+		t.queued = false
+		if t.active {
+			t.send(m.now)
+		}
+	}
+	m.timers = pending
+}
+
+type mockTimer struct {
+	clock    *MockClock
+	ch       chan time.Time
+	deadline time.Time
+	active   bool // armed and not yet fired or stopped
+	queued   bool // present in clock.timers
+}
+
+// send delivers now without blocking, like time.Timer; callers must hold clock.mu.
+func (t *mockTimer) send(now time.Time) {
+	t.active = false
+	select {
+	case t.ch <- now:
+	default:
+	}
+}
+
+func (t *mockTimer) C() <-chan time.Time { return t.ch }
+
+func (t *mockTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	wasActive := t.active
+	t.active = false
+	return wasActive
+}
+
+func (t *mockTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	wasActive := t.active
+	t.clock.schedule(t, d)
+	return wasActive
+}
+
+var _ Clock = (*MockClock)(nil)
@@ -0,0 +1,113 @@
+package chrono
+
+import (
+	"testing"
+	"time"
+)
+
+var mockBase = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func fired(ch <-chan time.Time) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestMockClock_AdvanceAndSet(t *testing.T) {
+	m := NewMockClock(mockBase)
+	start := m.Now()
+
+	m.Advance(90 * time.Second)
+	if got := m.Since(start); got != 90*time.Second {
+		t.Fatalf("Since after Advance = %v, want 90s", got)
+	}
+
+	m.Set(mockBase.Add(time.Hour))
+	if got := m.Since(start); got != time.Hour {
+		t.Fatalf("Since after Set = %v, want 1h", got)
+	}
+}
+
+func TestMockClock_DefaultDrivesHelpers(t *testing.T) {
+	m := NewMockClock(mockBase)
+	SetDefault(m)
+	defer SetDefault(nil)
+
+	start := Now()
+	m.Advance(5 * time.Minute)
+	if got := Since(start); got != 5*time.Minute {
+		t.Fatalf("chrono.Since = %v, want 5m", got)
+	}
+	if !IsExpired(start) {
+		t.Fatalf("start should be expired after advancing")
+	}
+}
+
+func TestMockClock_After(t *testing.T) {
+	m := NewMockClock(mockBase)
+	ch := m.After(time.Second)
+
+	m.Advance(999 * time.Millisecond)
+	if fired(ch) {
+		t.Fatalf("After fired early")
+	}
+	m.Advance(time.Millisecond)
+	select {
+	case got := <-ch:
+		if !got.Equal(mockBase.Add(time.Second)) {
+			t.Fatalf("After delivered %v", got)
+		}
+	default:
+		t.Fatalf("After did not fire at deadline")
+	}
+}
+
+func TestMockClock_TimerStopReset(t *testing.T) {
+	m := NewMockClock(mockBase)
+	timer := m.NewTimer(time.Minute)
+
+	if !timer.Stop() {
+		t.Fatalf("Stop on active timer should report true")
+	}
+	m.Advance(2 * time.Minute)
+	if fired(timer.C()) {
+		t.Fatalf("stopped timer fired")
+	}
+	if timer.Stop() {
+		t.Fatalf("Stop on stopped timer should report false")
+	}
+
+	if timer.Reset(time.Second) {
+		t.Fatalf("Reset on stopped timer should report false")
+	}
+	m.Advance(time.Second)
+	if !fired(timer.C()) {
+		t.Fatalf("reset timer did not fire")
+	}
+	m.Advance(time.Hour)
+	if fired(timer.C()) {
+		t.Fatalf("timer fired twice")
+	}
+
+	if m.NewTimer(0) == nil || !fired(m.After(0)) {
+		t.Fatalf("zero-duration timer should fire immediately")
+	}
+}
+
+func TestSystemClock_Timer(t *testing.T) {
+	var c Clock = SystemClock{}
+	timer := c.NewTimer(time.Millisecond)
+	select {
+	case <-timer.C():
+	case <-time.After(time.Second):
+		t.Fatalf("system timer did not fire")
+	}
+	select {
+	case <-c.After(time.Millisecond):
+	case <-time.After(time.Second):
+		t.Fatalf("system After did not fire")
+	}
+}
@@ -0,0 +1,100 @@
+package chrono
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDuration parses a duration string like time.ParseDuration, additionally accepting
+// "d" (24h days) and "w" (7-day weeks), e.g. "7d", "2w" or "1w2d3h30m". Units must be
+// lowercase; "m" always means minutes. Input without units (other than "0"), unknown units,
+// whitespace and overflow are rejected.
+func ParseDuration(s string) (time.Duration, error) {
+	orig := s
+	if s == "" {
+		return 0, fmt.Errorf("chrono: invalid duration %q", orig)
+	}
+	neg := false
+	if s[0] == '-' || s[0] == '+' {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if s == "0" {
+		return 0, nil
+	}
+	if s == "" {
+		return 0, fmt.Errorf("chrono: invalid duration %q", orig)
+	}
+
+	var total time.Duration
+	for s != "" {
+		// number
+		i := 0
+		for i < len(s) && (s[i] == '.' || (s[i] >= '0' && s[i] <= '9')) {
+			i++
+		}
+		num := s[:i]
+		if num == "" || num == "." {
+			return 0, fmt.Errorf("chrono: invalid duration %q", orig)
+		}
+		s = s[i:]
+		// unit
+		j := 0
+		for j < len(s) && s[j] != '.' && (s[j] < '0' || s[j] > '9') {
+			j++
+		}
+		unit := s[:j]
+		s = s[j:]
+		if unit == "" {
+			return 0, fmt.Errorf("chrono: missing unit in duration %q", orig)
+		}
+
+		var d time.Duration
+		var err error
+		switch unit {
+		case "d":
+			d, err = scaleDuration(num, Day)
+		case "w":
+			d, err = scaleDuration(num, Week)
+		default:
+			d, err = time.ParseDuration(num + unit)
+			if err != nil {
+				err = fmt.Errorf("unknown unit %q", unit)
+			}
+		}
+		if err != nil {
+			return 0, fmt.Errorf("chrono: invalid duration %q: %w", orig, err)
+		}
+		if total > math.MaxInt64-d {
+			return 0, fmt.Errorf("chrono: invalid duration %q: overflow", orig)
+		}
+		total += d
+	}
+	if neg {
+		total = -total
+	}
+	return total, nil
+}
+
+// scaleDuration multiplies a decimal number by unit, rejecting overflow.
+func scaleDuration(num string, unit time.Duration) (time.Duration, error) {
+	if !strings.Contains(num, ".") {
+		n, err := strconv.ParseInt(num, 10, 64)
+		if err != nil || n > math.MaxInt64/int64(unit) {
+			return 0, fmt.Errorf("overflow or malformed number %q", num)
+		}
+		return time.Duration(n) * unit, nil
+	}
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("malformed number %q", num)
+	}
+	f := v * float64(unit)
+	if f >= math.MaxInt64 {
+		return 0, fmt.Errorf("overflow")
+	}
+	return time.Duration(f), nil
+}
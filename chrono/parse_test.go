@@ -0,0 +1,55 @@
+package chrono
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	cases := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"7d", 7 * Day},
+		{"2w", 2 * Week},
+		{"1w2d3h", Week + 2*Day + 3*time.Hour},
+		{"1d12h30m", Day + 12*time.Hour + 30*time.Minute},
+		{"1.5d", 36 * time.Hour},
+		{"-2d", -2 * Day},
+		{"+1w", Week},
+		{"90m", 90 * time.Minute},
+		{"1h30m15s250ms", time.Hour + 30*time.Minute + 15*time.Second + 250*time.Millisecond},
+		{"300d1ns", 300*Day + time.Nanosecond},
+		{"0", 0},
+	}
+	for _, c := range cases {
+		got, err := ParseDuration(c.in)
+		if err != nil {
+			t.Fatalf("ParseDuration(%q): %v", c.in, err)
+		}
+		if got != c.want {
+			t.Fatalf("ParseDuration(%q)=%v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestParseDuration_Invalid(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"-",
+		"d",
+		"7",      // missing unit
+		"7D",     // uppercase unit
+		"7 d",    // whitespace
+		"1x",     // unknown unit
+		"1dd",    // unknown unit
+		"1.2.3d", // malformed number
+		"1d-2h",  // sign inside
+		"100000000w",
+		"15000w2000w", // sum overflows
+	} {
+		if d, err := ParseDuration(in); err == nil {
+			t.Fatalf("ParseDuration(%q)=%v, want error", in, d)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package chrono
 
-import "time"
+import (
+	"math"
+	"time"
+)
 
 const (
 	FiveMinutes    = 5 * time.Minute
@@ -21,7 +24,7 @@ func IsExpired(t time.Time) bool { return t.Before(Now()) }
 // FormatApprox formats a duration into a short human-ish form (e.g., 1h2m, 3m4s, 5s, 250ms).
 func FormatApprox(d time.Duration) string {
 	if d < 0 {
-		return "-" + FormatApprox(-d)
+		return "-" + FormatApprox(abs(d))
 	}
 	switch {
 	case d >= time.Hour:
@@ -47,6 +50,59 @@ func FormatApprox(d time.Duration) string {
 	}
 }
 
+// Ago formats t relative to now on the Default clock, using the largest whole unit:
+// "just now" (under a minute), "5m ago", "2h ago", "3d ago", "2w ago".
+// Future times are formatted as by Until.
+func Ago(t time.Time) string { return formatRelative(Since(t), false) }
+
+// Until formats a future time t relative to now on the Default clock:
+// "in a moment" (under a minute), "in 5m", "in 2h", "in 3d", "in 2w".
+// Past times are formatted as by Ago.
+func Until(t time.Time) string { return formatRelative(Since(t), true) }
+
+// formatRelative formats the elapsed duration since a time: positive for the past,
+// negative for the future. future decides which form a zero duration takes.
+func formatRelative(elapsed time.Duration, future bool) string {
+	if elapsed > 0 || (elapsed == 0 && !future) {
+		if r := relative(elapsed); r != "" {
+			return r + " ago"
+		}
+		return "just now"
+	}
+	if r := relative(abs(elapsed)); r != "" {
+		return "in " + r
+	}
+	return "in a moment"
+}
+
+// abs returns the magnitude of d, clamping math.MinInt64 (which has no positive counterpart)
+// to the largest representable duration.
+func abs(d time.Duration) time.Duration {
+	if d == math.MinInt64 {
+		return math.MaxInt64
+	}
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// relative truncates a non-negative d to its largest whole unit, or returns "" under a minute.
+func relative(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return ""
+	case d < time.Hour:
+		return FormatApprox(d.Truncate(time.Minute))
+	case d < Day:
+		return FormatApprox(d.Truncate(time.Hour))
+	case d < Week:
+		return itoa(int(d/Day)) + "d"
+	default:
+		return itoa(int(d/Week)) + "w"
+	}
+}
+
 // itoa is a tiny, allocation-free integer to ASCII converter for small non-negative ints.
 func itoa(n int) string {
 	if n == 0 {
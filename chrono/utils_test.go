@@ -1,18 +1,14 @@
 package chrono
 
 import (
+	"math"
 	"testing"
 	"time"
 )
 
-type fakeClock struct{ t time.Time }
-
-func (f fakeClock) Now() time.Time                  { return f.t }
-func (f fakeClock) Since(t time.Time) time.Duration { return f.t.Sub(t) }
-
 func TestIsExpired(t *testing.T) {
 	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
-	SetDefault(fakeClock{t: base})
+	SetDefault(NewMockClock(base))
 	defer SetDefault(nil)
 	if IsExpired(base.Add(1 * time.Second)) {
 		t.Fatalf("future should not be expired")
@@ -38,3 +34,73 @@ func TestFormatApprox(t *testing.T) {
 		}
 	}
 }
+
+func TestAgoAndUntil(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	SetDefault(NewMockClock(base))
+	defer SetDefault(nil)
+
+	cases := []struct {
+		d     time.Duration
+		ago   string
+		until string
+	}{
+		{0, "just now", "in a moment"},
+		{59 * time.Second, "just now", "in a moment"},
+		{time.Minute, "1m ago", "in 1m"},
+		{5*time.Minute + 30*time.Second, "5m ago", "in 5m"},
+		{59*time.Minute + 59*time.Second, "59m ago", "in 59m"},
+		{time.Hour, "1h ago", "in 1h"},
+		{2*time.Hour + 45*time.Minute, "2h ago", "in 2h"},
+		{Day - time.Second, "23h ago", "in 23h"},
+		{Day, "1d ago", "in 1d"},
+		{3*Day + 5*time.Hour, "3d ago", "in 3d"},
+		{Week - time.Second, "6d ago", "in 6d"},
+		{Week, "1w ago", "in 1w"},
+		{3*Week + 2*Day, "3w ago", "in 3w"},
+	}
+	for _, c := range cases {
+		if got := Ago(base.Add(-c.d)); got != c.ago {
+			t.Fatalf("Ago(now-%v)=%q, want %q", c.d, got, c.ago)
+		}
+		if got := Until(base.Add(c.d)); got != c.until {
+			t.Fatalf("Until(now+%v)=%q, want %q", c.d, got, c.until)
+		}
+	}
+
+	// Each formatter defers to the other for times on the wrong side of now.
+	if got := Ago(base.Add(2 * time.Hour)); got != "in 2h" {
+		t.Fatalf("Ago(future)=%q, want %q", got, "in 2h")
+	}
+	if got := Until(base.Add(-2 * time.Hour)); got != "2h ago" {
+		t.Fatalf("Until(past)=%q, want %q", got, "2h ago")
+	}
+}
+
+func TestAgoAndUntil_Extremes(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	SetDefault(NewMockClock(base))
+	defer SetDefault(nil)
+
+	// Since saturates at the duration limits; neither side may overflow when negated.
+	future := time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC)
+	past := time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+	maxWeeks := itoa(int(time.Duration(math.MaxInt64) / Week))
+	for _, c := range []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Ago(far future)", Ago(future), "in " + maxWeeks + "w"},
+		{"Until(far future)", Until(future), "in " + maxWeeks + "w"},
+		{"Ago(far past)", Ago(past), maxWeeks + "w ago"},
+		{"Until(far past)", Until(past), maxWeeks + "w ago"},
+	} {
+		if c.got != c.want {
+			t.Fatalf("%s=%q, want %q", c.name, c.got, c.want)
+		}
+	}
+	if got := FormatApprox(math.MinInt64); got[0] != '-' {
+		t.Fatalf("FormatApprox(MinInt64)=%q, want negative", got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	stdctx "context"
 	"errors"
 	"strings"
+	"sync"
 	"time"
 
 	chrono "core/chrono"
@@ -76,6 +77,48 @@ func Into(ctx stdctx.Context, rc *RequestContext) stdctx.Context {
 	return stdctx.WithValue(ctx, requestContextKey, rc)
 }
 
+// Merge combines two RequestContexts into a new one, leaving both inputs unchanged.
+// dst takes precedence: each of src's non-empty scalar fields (IDs and StartTime) is used
+// only where dst's field is empty, and Labels are the union of both with dst's value kept
+// for keys present in each. A nil argument is treated as empty; Merge(nil, nil) returns nil.
+func Merge(dst, src *RequestContext) *RequestContext {
+	if dst == nil && src == nil {
+		return nil
+	}
+	if dst == nil {
+		dst = &RequestContext{}
+	}
+	if src == nil {
+		src = &RequestContext{}
+	}
+	out := *dst
+	fill := func(field *string, value string) {
+		if *field == "" {
+			*field = value
+		}
+	}
+	fill(&out.TraceID, src.TraceID)
+	fill(&out.RequestID, src.RequestID)
+	fill(&out.UserID, src.UserID)
+	fill(&out.TenantID, src.TenantID)
+	fill(&out.SessionID, src.SessionID)
+	if out.StartTime.IsZero() {
+		out.StartTime = src.StartTime
+	}
+
+	out.Labels = nil
+	if len(dst.Labels)+len(src.Labels) > 0 {
+		out.Labels = make(map[string]string, len(dst.Labels)+len(src.Labels))
+		for k, v := range src.Labels {
+			out.Labels[k] = v
+		}
+		for k, v := range dst.Labels {
+			out.Labels[k] = v
+		}
+	}
+	return &out
+}
+
 // WithTrace sets the TraceID on the RequestContext inside ctx without overwriting other fields.
 func WithTrace(ctx stdctx.Context, traceID string) stdctx.Context {
 	if traceID == "" {
@@ -218,20 +261,80 @@ func Fields(ctx stdctx.Context) map[string]any {
 	return fields
 }
 
+var (
+	allowlistMu    sync.RWMutex
+	fieldAllowlist map[string]struct{}
+)
+
+// SetFieldAllowlist sets the package-level default for FieldsFiltered, limiting the keys
+// loggers receive (e.g. to keep user_id and session_id away from PII-restricted sinks).
+// An empty or nil list clears the allowlist so all fields are included again.
+func SetFieldAllowlist(keys []string) {
+	allowlistMu.Lock()
+	defer allowlistMu.Unlock()
+	if len(keys) == 0 {
+		fieldAllowlist = nil
+		return
+	}
+	fieldAllowlist = make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		fieldAllowlist[k] = struct{}{}
+	}
+}
+
+// FieldsFiltered returns the subset of Fields whose keys are listed in include.
+// With no include keys it applies the allowlist set by SetFieldAllowlist, and with
+// neither it returns all fields.
+func FieldsFiltered(ctx stdctx.Context, include ...string) map[string]any {
+	fields := Fields(ctx)
+	if len(include) > 0 {
+		allowed := make(map[string]struct{}, len(include))
+		for _, k := range include {
+			allowed[k] = struct{}{}
+		}
+		return filterFields(fields, allowed)
+	}
+
+	allowlistMu.RLock()
+	defer allowlistMu.RUnlock()
+	if len(fieldAllowlist) == 0 {
+		return fields
+	}
+	return filterFields(fields, fieldAllowlist)
+}
+
+// filterFields deletes keys not in allowed from fields and returns it.
+func filterFields(fields map[string]any, allowed map[string]struct{}) map[string]any {
+	for k := range fields {
+		if _, ok := allowed[k]; !ok {
+			delete(fields, k)
+		}
+	}
+	return fields
+}
+
+// Size limits enforced by Validate.
+const (
+	maxIDLen         = 128
+	maxLabels        = 32
+	maxLabelKeyLen   = 64
+	maxLabelValueLen = 256
+)
+
 // Validate performs basic size/format checks on IDs and labels.
 // This function is conservative and avoids external dependencies.
 func Validate(rc *RequestContext) error {
 	if rc == nil {
 		return errors.New("nil RequestContext")
 	}
-	if len(rc.TraceID) > 128 || len(rc.RequestID) > 128 || len(rc.UserID) > 128 || len(rc.TenantID) > 128 || len(rc.SessionID) > 128 {
+	if len(rc.TraceID) > maxIDLen || len(rc.RequestID) > maxIDLen || len(rc.UserID) > maxIDLen || len(rc.TenantID) > maxIDLen || len(rc.SessionID) > maxIDLen {
 		return errors.New("identifier too long")
 	}
-	if len(rc.Labels) > 32 { // keep labels small
+	if len(rc.Labels) > maxLabels { // keep labels small
 		return errors.New("too many labels")
 	}
 	for k, v := range rc.Labels {
-		if len(k) > 64 || len(v) > 256 {
+		if len(k) > maxLabelKeyLen || len(v) > maxLabelValueLen {
 			return errors.New("label size exceeded")
 		}
 	}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 	"time"
+
+	chrono "core/chrono"
 )
 
 func TestNewAndFrom(t *testing.T) {
@@ -75,3 +77,114 @@ func TestValidate(t *testing.T) {
 		t.Fatalf("expected error for too many labels")
 	}
 }
+
+func TestDurationUsesChronoDefault(t *testing.T) {
+	mock := chrono.NewMockClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	chrono.SetDefault(mock)
+	defer chrono.SetDefault(nil)
+
+	ctx, _ := New(context.Background())
+	mock.Advance(250 * time.Millisecond)
+	if d := Duration(ctx); d != 250*time.Millisecond {
+		t.Fatalf("want 250ms from mock clock, got %v", d)
+	}
+}
+
+func TestFieldsFiltered(t *testing.T) {
+	ctx, _ := New(context.Background())
+	ctx = WithTrace(ctx, "t-1")
+	ctx = WithUser(ctx, "u-1")
+	ctx = WithSession(ctx, "s-1")
+
+	got := FieldsFiltered(ctx, "trace_id", "tenant_id")
+	if len(got) != 1 || got["trace_id"] != "t-1" {
+		t.Fatalf("want only trace_id, got %v", got)
+	}
+}
+
+func TestFieldAllowlist(t *testing.T) {
+	ctx, _ := New(context.Background())
+	ctx = WithTrace(ctx, "t-1")
+	ctx = WithRequestID(ctx, "r-1")
+	ctx = WithUser(ctx, "u-1")
+	ctx = WithSession(ctx, "s-1")
+
+	// Without an allowlist every field is included, as with Fields.
+	if got, want := len(FieldsFiltered(ctx)), len(Fields(ctx)); got != want {
+		t.Fatalf("empty allowlist: got %d fields, want %d", got, want)
+	}
+
+	SetFieldAllowlist([]string{"trace_id", "request_id"})
+	defer SetFieldAllowlist(nil)
+
+	got := FieldsFiltered(ctx)
+	if len(got) != 2 || got["trace_id"] != "t-1" || got["request_id"] != "r-1" {
+		t.Fatalf("want only allowlisted fields, got %v", got)
+	}
+	if _, ok := got["user_id"]; ok {
+		t.Fatalf("user_id should be omitted")
+	}
+	// Explicit keys take precedence over the package allowlist.
+	if got := FieldsFiltered(ctx, "user_id"); len(got) != 1 || got["user_id"] != "u-1" {
+		t.Fatalf("explicit include: got %v", got)
+	}
+
+	SetFieldAllowlist([]string{})
+	if _, ok := FieldsFiltered(ctx)["session_id"]; !ok {
+		t.Fatalf("clearing the allowlist should include all fields again")
+	}
+}
+
+func TestMerge_ScalarPrecedence(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	dst := &RequestContext{TraceID: "http-trace", UserID: "u-1"}
+	src := &RequestContext{TraceID: "msg-trace", RequestID: "msg-req", UserID: "u-2", TenantID: "ten-1", StartTime: start}
+
+	got := Merge(dst, src)
+	if got.TraceID != "http-trace" || got.UserID != "u-1" {
+		t.Fatalf("dst fields should win: %+v", got)
+	}
+	if got.RequestID != "msg-req" || got.TenantID != "ten-1" || !got.StartTime.Equal(start) {
+		t.Fatalf("empty dst fields should be filled from src: %+v", got)
+	}
+	if got.SessionID != "" {
+		t.Fatalf("fields empty in both should stay empty: %+v", got)
+	}
+	if dst.RequestID != "" || src.TraceID != "msg-trace" {
+		t.Fatalf("inputs must not be modified: dst=%+v src=%+v", dst, src)
+	}
+}
+
+func TestMerge_LabelUnion(t *testing.T) {
+	dst := &RequestContext{Labels: map[string]string{"region": "eu", "tier": "gold"}}
+	src := &RequestContext{Labels: map[string]string{"region": "us", "source": "queue"}}
+
+	got := Merge(dst, src)
+	want := map[string]string{"region": "eu", "tier": "gold", "source": "queue"}
+	if len(got.Labels) != len(want) {
+		t.Fatalf("want %v, got %v", want, got.Labels)
+	}
+	for k, v := range want {
+		if got.Labels[k] != v {
+			t.Fatalf("label %s: want %q, got %q", k, v, got.Labels[k])
+		}
+	}
+	got.Labels["tier"] = "silver"
+	if dst.Labels["tier"] != "gold" {
+		t.Fatalf("merged labels must not alias dst")
+	}
+}
+
+func TestMerge_Nil(t *testing.T) {
+	if Merge(nil, nil) != nil {
+		t.Fatalf("want nil")
+	}
+	src := &RequestContext{TraceID: "t-1", Labels: map[string]string{"a": "1"}}
+	got := Merge(nil, src)
+	if got == src || got.TraceID != "t-1" || got.Labels["a"] != "1" {
+		t.Fatalf("want copy of src, got %+v", got)
+	}
+	if got := Merge(&RequestContext{UserID: "u"}, nil); got.UserID != "u" || got.Labels != nil {
+		t.Fatalf("want copy of dst, got %+v", got)
+	}
+}
@@ -0,0 +1,71 @@
+package ctx
+
+import stdctx "context"
+
+// contextError annotates an error with correlation fields captured from a RequestContext.
+type contextError struct {
+	err    error
+	fields map[string]any
+}
+
+func (e *contextError) Error() string { return e.err.Error() }
+
+func (e *contextError) Unwrap() error { return e.err }
+
+// WrapError attaches the TraceID and RequestID from ctx to err, so the code that finally
+// logs the error can recover them via ErrorFields even if its own context differs.
+// The wrapper is transparent to errors.Is and errors.As. It returns err unchanged if err
+// is nil or ctx carries no correlation IDs.
+func WrapError(ctx stdctx.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	rc, ok := From(ctx)
+	if !ok {
+		return err
+	}
+	fields := make(map[string]any, 2)
+	if rc.TraceID != "" {
+		fields["trace_id"] = rc.TraceID
+	}
+	if rc.RequestID != "" {
+		fields["request_id"] = rc.RequestID
+	}
+	if len(fields) == 0 {
+		return err
+	}
+	return &contextError{err: err, fields: fields}
+}
+
+// ErrorFields returns the correlation fields attached to err or any error it wraps by
+// WrapError, using the same keys as Fields. When wrapped more than once, the outermost
+// value wins. It returns an empty map if err carries no fields.
+func ErrorFields(err error) map[string]any {
+	fields := make(map[string]any, 2)
+	collectErrorFields(err, fields)
+	return fields
+}
+
+// collectErrorFields walks err's tree depth-first, keeping the first value seen per key.
+func collectErrorFields(err error, fields map[string]any) {
+	for err != nil {
+		if ce, ok := err.(*contextError); ok {
+			for k, v := range ce.fields {
+				if _, exists := fields[k]; !exists {
+					fields[k] = v
+				}
+			}
+		}
+		switch e := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, inner := range e.Unwrap() {
+				collectErrorFields(inner, fields)
+			}
+			return
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		default:
+			return
+		}
+	}
+}
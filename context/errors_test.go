@@ -0,0 +1,72 @@
+package ctx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var errNotFound = errors.New("not found")
+
+func TestWrapErrorRoundTrip(t *testing.T) {
+	ctx, _ := New(context.Background())
+	ctx = WithTrace(ctx, "t-1")
+	ctx = WithRequestID(ctx, "r-1")
+
+	err := fmt.Errorf("load user: %w", WrapError(ctx, errNotFound))
+	if !errors.Is(err, errNotFound) {
+		t.Fatalf("expected errors.Is to see through the wrapper")
+	}
+	if err.Error() != "load user: not found" {
+		t.Fatalf("unexpected message: %q", err.Error())
+	}
+	fields := ErrorFields(err)
+	if fields["trace_id"] != "t-1" || fields["request_id"] != "r-1" || len(fields) != 2 {
+		t.Fatalf("unexpected fields: %v", fields)
+	}
+}
+
+func TestWrapErrorNoCorrelation(t *testing.T) {
+	if WrapError(context.Background(), nil) != nil {
+		t.Fatalf("expected nil for nil error")
+	}
+	if err := WrapError(context.Background(), errNotFound); err != errNotFound {
+		t.Fatalf("expected error unchanged without RequestContext, got %v", err)
+	}
+	ctx, _ := New(context.Background())
+	ctx = WithUser(ctx, "u-1")
+	if err := WrapError(ctx, errNotFound); err != errNotFound {
+		t.Fatalf("expected error unchanged without correlation IDs, got %v", err)
+	}
+	if fields := ErrorFields(errNotFound); len(fields) != 0 {
+		t.Fatalf("expected no fields, got %v", fields)
+	}
+}
+
+func TestErrorFieldsOutermostWins(t *testing.T) {
+	inner, _ := New(context.Background())
+	inner = WithTrace(inner, "t-inner")
+	inner = WithRequestID(inner, "r-inner")
+	outer, _ := New(context.Background())
+	outer = WithRequestID(outer, "r-outer")
+
+	err := WrapError(outer, fmt.Errorf("call: %w", WrapError(inner, errNotFound)))
+	fields := ErrorFields(err)
+	if fields["request_id"] != "r-outer" || fields["trace_id"] != "t-inner" {
+		t.Fatalf("unexpected fields: %v", fields)
+	}
+}
+
+func TestErrorFieldsJoined(t *testing.T) {
+	ctx, _ := New(context.Background())
+	ctx = WithTrace(ctx, "t-1")
+
+	err := errors.Join(errors.New("cleanup failed"), WrapError(ctx, errNotFound))
+	if !errors.Is(err, errNotFound) {
+		t.Fatalf("expected errors.Is to find the joined error")
+	}
+	if fields := ErrorFields(err); fields["trace_id"] != "t-1" {
+		t.Fatalf("expected fields from joined error, got %v", fields)
+	}
+}
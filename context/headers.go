@@ -1,5 +1,12 @@
 package ctx
 
+import (
+	stdctx "context"
+	"net/url"
+	"sort"
+	"strings"
+)
+
 // Canonical header keys for simple request correlation and tenancy.
 // These are optional conveniences and may be mapped to project-specific names.
 const (
@@ -8,4 +15,158 @@ const (
 	HeaderUserID    = "X-User-Id"
 	HeaderTenantID  = "X-Tenant-Id"
 	HeaderSessionID = "X-Session-Id"
+	HeaderLabels    = "X-Labels" // URL query encoded, e.g. "plan=pro&region=eu"
 )
+
+// DefaultHeaderPrefix is the prefix of the canonical header keys.
+const DefaultHeaderPrefix = "X-"
+
+// HeaderOption configures ToHeaders and FromHeaders.
+type HeaderOption func(*headerConfig)
+
+type headerConfig struct {
+	prefix string
+}
+
+// WithHeaderPrefix replaces DefaultHeaderPrefix, so "X-Acme-" yields "X-Acme-Trace-Id".
+func WithHeaderPrefix(prefix string) HeaderOption {
+	return func(c *headerConfig) { c.prefix = prefix }
+}
+
+// headerNames maps a RequestContext field to its header name without prefix.
+var headerNames = []struct {
+	name  string
+	field func(*RequestContext) *string
+}{
+	{"Trace-Id", func(rc *RequestContext) *string { return &rc.TraceID }},
+	{"Request-Id", func(rc *RequestContext) *string { return &rc.RequestID }},
+	{"User-Id", func(rc *RequestContext) *string { return &rc.UserID }},
+	{"Tenant-Id", func(rc *RequestContext) *string { return &rc.TenantID }},
+	{"Session-Id", func(rc *RequestContext) *string { return &rc.SessionID }},
+}
+
+const labelsHeaderName = "Labels"
+
+func newHeaderConfig(opts []HeaderOption) headerConfig {
+	cfg := headerConfig{prefix: DefaultHeaderPrefix}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// ToHeaders serializes the RequestContext in ctx into outbound headers. Empty fields are
+// omitted, labels are sent as one URL query encoded header, and StartTime is not propagated.
+// It returns nil if ctx carries no RequestContext.
+func ToHeaders(ctx stdctx.Context, opts ...HeaderOption) map[string]string {
+	rc, ok := From(ctx)
+	if !ok {
+		return nil
+	}
+	cfg := newHeaderConfig(opts)
+
+	headers := make(map[string]string, len(headerNames)+1)
+	for _, h := range headerNames {
+		if v := *h.field(rc); v != "" {
+			headers[cfg.prefix+h.name] = v
+		}
+	}
+	if len(rc.Labels) > 0 {
+		values := make(url.Values, len(rc.Labels))
+		for k, v := range rc.Labels {
+			values.Set(k, v)
+		}
+		headers[cfg.prefix+labelsHeaderName] = values.Encode()
+	}
+	return headers
+}
+
+// FromHeaders rebuilds a RequestContext from inbound headers and attaches it to parent,
+// on top of any RequestContext parent already carries. Header names match case-insensitively,
+// as gRPC metadata is lower-cased. Values exceeding the limits of Validate and malformed
+// label pairs are dropped. If no known header is present, parent is returned unchanged.
+func FromHeaders(parent stdctx.Context, headers map[string]string, opts ...HeaderOption) stdctx.Context {
+	if len(headers) == 0 {
+		return parent
+	}
+	cfg := newHeaderConfig(opts)
+	lower := make(map[string]string, len(headers))
+	for k, v := range headers {
+		lower[strings.ToLower(k)] = v
+	}
+	lookup := func(name string) string {
+		return strings.TrimSpace(lower[strings.ToLower(cfg.prefix+name)])
+	}
+
+	var found bool
+	var fields RequestContext
+	for _, h := range headerNames {
+		if v := lookup(h.name); v != "" && len(v) <= maxIDLen {
+			*h.field(&fields) = v
+			found = true
+		}
+	}
+	labels := parseLabels(lookup(labelsHeaderName))
+	if !found && len(labels) == 0 {
+		return parent
+	}
+
+	ctx, _ := New(parent, func(rc *RequestContext) {
+		for _, h := range headerNames {
+			if v := *h.field(&fields); v != "" {
+				*h.field(rc) = v
+			}
+		}
+		if len(labels) > 0 && rc.Labels == nil {
+			rc.Labels = make(map[string]string, len(labels))
+		}
+		// Header labels override the parent's, but the merged set stays within the
+		// Validate limit; new keys are added in sorted order until it is reached.
+		keys := make([]string, 0, len(labels))
+		for k := range labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if _, dup := rc.Labels[k]; !dup && len(rc.Labels) >= maxLabels {
+				continue
+			}
+			rc.Labels[k] = labels[k]
+		}
+	})
+	return ctx
+}
+
+// parseLabels decodes a labels header, skipping malformed pairs and those over the Validate limits.
+func parseLabels(raw string) map[string]string {
+	if raw == "" {
+		return nil
+	}
+	var labels map[string]string
+	for _, pair := range strings.Split(raw, "&") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		key, err := url.QueryUnescape(k)
+		if err != nil {
+			continue
+		}
+		value, err := url.QueryUnescape(v)
+		if err != nil {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		if key == "" || len(key) > maxLabelKeyLen || len(value) > maxLabelValueLen {
+			continue
+		}
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		if _, dup := labels[key]; !dup && len(labels) >= maxLabels {
+			break
+		}
+		labels[key] = value
+	}
+	return labels
+}
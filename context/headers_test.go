@@ -0,0 +1,127 @@
+package ctx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestHeaders_RoundTrip(t *testing.T) {
+	ctx, _ := New(context.Background(), func(rc *RequestContext) {
+		rc.TraceID = "t-1"
+		rc.RequestID = "r-1"
+		rc.UserID = "u-1"
+		rc.TenantID = "acme"
+		rc.SessionID = "s-1"
+		rc.Labels = map[string]string{"plan": "pro", "note": "a=b&c d"}
+	})
+
+	headers := ToHeaders(ctx)
+	if headers[HeaderTraceID] != "t-1" || headers[HeaderTenantID] != "acme" {
+		t.Fatalf("unexpected headers: %v", headers)
+	}
+	if _, ok := headers[HeaderLabels]; !ok || len(headers) != 6 {
+		t.Fatalf("expected 5 id headers and one labels header, got %v", headers)
+	}
+
+	got, ok := From(FromHeaders(context.Background(), headers))
+	if !ok {
+		t.Fatalf("expected RequestContext")
+	}
+	if got.TraceID != "t-1" || got.RequestID != "r-1" || got.UserID != "u-1" || got.TenantID != "acme" || got.SessionID != "s-1" {
+		t.Fatalf("ids not restored: %+v", got)
+	}
+	if len(got.Labels) != 2 || got.Labels["plan"] != "pro" || got.Labels["note"] != "a=b&c d" {
+		t.Fatalf("labels not restored: %v", got.Labels)
+	}
+}
+
+func TestHeaders_PrefixAndCaseInsensitive(t *testing.T) {
+	ctx := WithTrace(context.Background(), "t-1")
+	headers := ToHeaders(ctx, WithHeaderPrefix("X-Acme-"))
+	if headers["X-Acme-Trace-Id"] != "t-1" || len(headers) != 1 {
+		t.Fatalf("unexpected headers: %v", headers)
+	}
+
+	// gRPC metadata keys arrive lower-cased
+	lower := map[string]string{"x-acme-trace-id": "t-1"}
+	rc, ok := From(FromHeaders(context.Background(), lower, WithHeaderPrefix("X-Acme-")))
+	if !ok || rc.TraceID != "t-1" {
+		t.Fatalf("expected trace from lower-cased header, got %+v", rc)
+	}
+	if _, ok := From(FromHeaders(context.Background(), lower)); ok {
+		t.Fatalf("default prefix should not match X-Acme- headers")
+	}
+}
+
+func TestFromHeaders_Malformed(t *testing.T) {
+	headers := map[string]string{
+		HeaderRequestID: strings.Repeat("r", maxIDLen+1),
+		HeaderTenantID:  "acme",
+		HeaderLabels:    "ok=1&%zz=2&novalue&=empty&bad=%g0&" + strings.Repeat("k", maxLabelKeyLen+1) + "=v",
+	}
+	rc, ok := From(FromHeaders(context.Background(), headers))
+	if !ok {
+		t.Fatalf("expected RequestContext")
+	}
+	if rc.RequestID != "" {
+		t.Fatalf("oversized request id should be dropped, got %d bytes", len(rc.RequestID))
+	}
+	if rc.TenantID != "acme" {
+		t.Fatalf("tenant = %q", rc.TenantID)
+	}
+	if len(rc.Labels) != 1 || rc.Labels["ok"] != "1" {
+		t.Fatalf("expected only the well-formed label, got %v", rc.Labels)
+	}
+	if err := Validate(rc); err != nil {
+		t.Fatalf("reconstructed context should validate: %v", err)
+	}
+}
+
+func TestHeaders_Empty(t *testing.T) {
+	if h := ToHeaders(context.Background()); h != nil {
+		t.Fatalf("expected nil headers, got %v", h)
+	}
+	parent := context.Background()
+	if got := FromHeaders(parent, map[string]string{"Content-Type": "text/plain"}); got != parent {
+		t.Fatalf("expected parent returned unchanged")
+	}
+}
+
+func TestFromHeaders_KeepsParentFields(t *testing.T) {
+	parent := WithUser(WithLabel(context.Background(), "region", "eu"), "u-1")
+	rc, _ := From(FromHeaders(parent, map[string]string{HeaderTraceID: "t-2", HeaderLabels: "plan=pro"}))
+	if rc.UserID != "u-1" || rc.TraceID != "t-2" {
+		t.Fatalf("unexpected ids: %+v", rc)
+	}
+	if rc.Labels["region"] != "eu" || rc.Labels["plan"] != "pro" {
+		t.Fatalf("unexpected labels: %v", rc.Labels)
+	}
+	orig, _ := From(parent)
+	if _, ok := orig.Labels["plan"]; ok {
+		t.Fatalf("parent labels were modified")
+	}
+}
+
+func TestFromHeaders_CapsMergedLabels(t *testing.T) {
+	parent := context.Background()
+	for i := 0; i < maxLabels; i++ {
+		parent = WithLabel(parent, fmt.Sprintf("p%02d", i), "v")
+	}
+	var pairs []string
+	for i := 0; i < maxLabels; i++ {
+		pairs = append(pairs, fmt.Sprintf("h%02d=v", i))
+	}
+	pairs = append(pairs, "p00=override")
+	rc, _ := From(FromHeaders(parent, map[string]string{HeaderLabels: strings.Join(pairs[1:], "&")}))
+	if len(rc.Labels) != maxLabels {
+		t.Fatalf("want merged labels capped at %d, got %d", maxLabels, len(rc.Labels))
+	}
+	if rc.Labels["p00"] != "override" {
+		t.Fatalf("header should still override existing labels, got %q", rc.Labels["p00"])
+	}
+	if err := Validate(rc); err != nil {
+		t.Fatalf("merged context should validate: %v", err)
+	}
+}
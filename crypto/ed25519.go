@@ -0,0 +1,97 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// GenerateEd25519Key generates an Ed25519 private key.
+// The public key is available via key.Public().
+func GenerateEd25519Key() (ed25519.PrivateKey, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ed25519 key: %w", err)
+	}
+	return key, nil
+}
+
+// ParseEd25519PrivateKey parses a PKCS8 PEM-encoded Ed25519 private key.
+func ParseEd25519PrivateKey(keyData string) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(keyData))
+	if block == nil {
+		return nil, errors.New("failed to decode PEM block")
+	}
+
+	parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	key, ok := parsedKey.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("key is not an Ed25519 private key")
+	}
+	return key, nil
+}
+
+// ParseEd25519PublicKey parses a PKIX PEM-encoded Ed25519 public key.
+func ParseEd25519PublicKey(keyData string) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode([]byte(keyData))
+	if block == nil {
+		return nil, errors.New("failed to decode PEM block")
+	}
+
+	parsedKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	key, ok := parsedKey.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("key is not an Ed25519 public key")
+	}
+	return key, nil
+}
+
+// EncodeEd25519PrivateKeyPEM encodes an Ed25519 private key as a PKCS8 "PRIVATE KEY" PEM block.
+func EncodeEd25519PrivateKeyPEM(key ed25519.PrivateKey) (string, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return "", errors.New("invalid ed25519 private key size")
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal private key: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// EncodeEd25519PublicKeyPEM encodes an Ed25519 public key as a PKIX "PUBLIC KEY" PEM block.
+func EncodeEd25519PublicKeyPEM(pub ed25519.PublicKey) (string, error) {
+	if len(pub) != ed25519.PublicKeySize {
+		return "", errors.New("invalid ed25519 public key size")
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// SignEd25519 signs message with key.
+func SignEd25519(key ed25519.PrivateKey, message []byte) ([]byte, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, errors.New("invalid ed25519 private key size")
+	}
+	return ed25519.Sign(key, message), nil
+}
+
+// VerifyEd25519 reports whether sig is a valid signature of message by pub.
+// Malformed keys and signatures are reported as invalid.
+func VerifyEd25519(pub ed25519.PublicKey, message, sig []byte) bool {
+	if len(pub) != ed25519.PublicKeySize {
+		return false
+	}
+	return ed25519.Verify(pub, message, sig)
+}
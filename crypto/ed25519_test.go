@@ -0,0 +1,81 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"testing"
+)
+
+func TestEd25519_RoundTrip(t *testing.T) {
+	key, err := GenerateEd25519Key()
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	pub := key.Public().(ed25519.PublicKey)
+
+	privPEM, err := EncodeEd25519PrivateKeyPEM(key)
+	if err != nil {
+		t.Fatalf("encode private: %v", err)
+	}
+	pubPEM, err := EncodeEd25519PublicKeyPEM(pub)
+	if err != nil {
+		t.Fatalf("encode public: %v", err)
+	}
+
+	parsedKey, err := ParseEd25519PrivateKey(privPEM)
+	if err != nil {
+		t.Fatalf("parse private: %v", err)
+	}
+	parsedPub, err := ParseEd25519PublicKey(pubPEM)
+	if err != nil {
+		t.Fatalf("parse public: %v", err)
+	}
+	if !parsedKey.Equal(key) || !parsedPub.Equal(pub) {
+		t.Fatalf("keys did not round-trip")
+	}
+
+	msg := []byte("service token")
+	sig, err := SignEd25519(parsedKey, msg)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	if !VerifyEd25519(parsedPub, msg, sig) {
+		t.Fatal("signature did not verify")
+	}
+	if VerifyEd25519(parsedPub, []byte("tampered"), sig) {
+		t.Fatal("signature verified for a different message")
+	}
+}
+
+func TestEd25519_WrongKeyType(t *testing.T) {
+	rsaKey, err := GenerateRSAKey(2048)
+	if err != nil {
+		t.Fatalf("generate rsa: %v", err)
+	}
+	rsaPriv, _ := EncodeRSAPrivateKeyPEM(rsaKey)
+	rsaPub, _ := EncodeRSAPublicKeyPEM(&rsaKey.PublicKey)
+
+	if _, err := ParseEd25519PrivateKey(rsaPriv); err == nil {
+		t.Fatal("expected error parsing RSA private key as Ed25519")
+	}
+	if _, err := ParseEd25519PublicKey(rsaPub); err == nil {
+		t.Fatal("expected error parsing RSA public key as Ed25519")
+	}
+	if _, err := ParseEd25519PrivateKey("not pem"); err == nil {
+		t.Fatal("expected PEM decode error")
+	}
+}
+
+func TestEd25519_InvalidKeys(t *testing.T) {
+	if _, err := SignEd25519(ed25519.PrivateKey{1, 2, 3}, []byte("m")); err == nil {
+		t.Fatal("expected error signing with short key")
+	}
+	if VerifyEd25519(ed25519.PublicKey{1, 2, 3}, []byte("m"), nil) {
+		t.Fatal("short public key should not verify")
+	}
+	if _, err := EncodeEd25519PrivateKeyPEM(nil); err == nil {
+		t.Fatal("expected error encoding nil private key")
+	}
+	if _, err := EncodeEd25519PublicKeyPEM(nil); err == nil {
+		t.Fatal("expected error encoding nil public key")
+	}
+}
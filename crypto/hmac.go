@@ -0,0 +1,31 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// HMACSHA256 returns the HMAC-SHA256 of data under key.
+func HMACSHA256(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// VerifyHMAC reports whether expectedMAC is the HMAC-SHA256 of data under key.
+// The comparison is constant-time.
+func VerifyHMAC(key, data, expectedMAC []byte) bool {
+	return hmac.Equal(HMACSHA256(key, data), expectedMAC)
+}
+
+// VerifyHMACHex is VerifyHMAC for a hex-encoded MAC, as sent in webhook signature headers.
+// Scheme prefixes such as "sha256=" must be stripped by the caller. Invalid hex is reported
+// as a mismatch.
+func VerifyHMACHex(key, data []byte, expectedHex string) bool {
+	expected, err := hex.DecodeString(expectedHex)
+	if err != nil {
+		return false
+	}
+	return VerifyHMAC(key, data, expected)
+}
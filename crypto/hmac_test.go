@@ -0,0 +1,51 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestHMACSHA256_KnownVector(t *testing.T) {
+	// RFC 4231 test case 2.
+	got := hex.EncodeToString(HMACSHA256([]byte("Jefe"), []byte("what do ya want for nothing?")))
+	want := "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"
+	if got != want {
+		t.Fatalf("HMACSHA256=%s, want %s", got, want)
+	}
+}
+
+func TestVerifyHMAC(t *testing.T) {
+	key := []byte("webhook-secret")
+	body := []byte(`{"event":"push"}`)
+	mac := HMACSHA256(key, body)
+
+	if !VerifyHMAC(key, body, mac) {
+		t.Fatal("matching MAC rejected")
+	}
+	if VerifyHMAC([]byte("other-secret"), body, mac) {
+		t.Fatal("MAC accepted with wrong key")
+	}
+	if VerifyHMAC(key, []byte(`{"event":"pull"}`), mac) {
+		t.Fatal("MAC accepted for different data")
+	}
+	if VerifyHMAC(key, body, mac[:16]) {
+		t.Fatal("truncated MAC accepted")
+	}
+}
+
+func TestVerifyHMACHex(t *testing.T) {
+	key := []byte("webhook-secret")
+	body := []byte("payload")
+	sig := hex.EncodeToString(HMACSHA256(key, body))
+
+	if !VerifyHMACHex(key, body, sig) || !VerifyHMACHex(key, body, strings.ToUpper(sig)) {
+		t.Fatal("matching hex MAC rejected")
+	}
+	if VerifyHMACHex(key, []byte("tampered"), sig) {
+		t.Fatal("hex MAC accepted for different data")
+	}
+	if VerifyHMACHex(key, body, "sha256="+sig) || VerifyHMACHex(key, body, "zz") {
+		t.Fatal("invalid hex accepted")
+	}
+}
@@ -2,6 +2,7 @@
 package crypto
 
 import (
+	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
@@ -52,3 +53,43 @@ func ParseRSAPublicKey(keyData string) (*rsa.PublicKey, error) {
 
 	return rsaKey, nil
 }
+
+// MinRSAKeyBits is the smallest RSA key size GenerateRSAKey accepts.
+const MinRSAKeyBits = 2048
+
+// GenerateRSAKey generates an RSA private key of the given size.
+// Sizes below MinRSAKeyBits are rejected.
+func GenerateRSAKey(bits int) (*rsa.PrivateKey, error) {
+	if bits < MinRSAKeyBits {
+		return nil, fmt.Errorf("rsa key size %d is below the minimum of %d bits", bits, MinRSAKeyBits)
+	}
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate rsa key: %w", err)
+	}
+	return key, nil
+}
+
+// EncodeRSAPrivateKeyPEM encodes an RSA private key as a PKCS8 "PRIVATE KEY" PEM block.
+func EncodeRSAPrivateKeyPEM(key *rsa.PrivateKey) (string, error) {
+	if key == nil {
+		return "", errors.New("private key is nil")
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal private key: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// EncodeRSAPublicKeyPEM encodes an RSA public key as a PKIX "PUBLIC KEY" PEM block.
+func EncodeRSAPublicKeyPEM(pub *rsa.PublicKey) (string, error) {
+	if pub == nil {
+		return "", errors.New("public key is nil")
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
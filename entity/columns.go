@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"reflect"
+	"strings"
+)
+
+// IDColumn is the column holding an entity's identifier.
+const IDColumn = "id"
+
+// CreatedAtColumn is the column holding an entity's creation time.
+const CreatedAtColumn = "created_at"
+
+// InsertOption configures InsertColumns.
+type InsertOption func(*insertConfig)
+
+type insertConfig struct {
+	omitID bool
+}
+
+// OmitID excludes the ID column from the insert, for databases that generate identifiers.
+func OmitID() InsertOption {
+	return func(c *insertConfig) { c.omitID = true }
+}
+
+// column pairs a database column with the struct field that holds its value.
+type column struct {
+	name  string
+	value reflect.Value
+}
+
+// InsertColumns returns the columns, positional "?" placeholders, and argument values for
+// inserting e, in struct field order. Fields of embedded structs such as BaseEntity are
+// included; fields tagged `db:"-"` are skipped.
+func InsertColumns(e Entity, opts ...InsertOption) (columns []string, placeholders []string, args []any) {
+	var cfg insertConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	for _, col := range entityColumns(e) {
+		if cfg.omitID && col.name == IDColumn {
+			continue
+		}
+		columns = append(columns, col.name)
+		placeholders = append(placeholders, "?")
+		args = append(args, col.value.Interface())
+	}
+	return columns, placeholders, args
+}
+
+// UpdateAssignments returns a SET clause such as "name = ?, active = ?" and the matching
+// argument values for updating e. The ID column is excluded so callers can append it to
+// their WHERE clause, and the creation time is excluded so updates never overwrite it.
+func UpdateAssignments(e Entity) (setClause string, args []any) {
+	var assignments []string
+	for _, col := range entityColumns(e) {
+		if col.name == IDColumn || col.name == CreatedAtColumn {
+			continue
+		}
+		assignments = append(assignments, col.name+" = ?")
+		args = append(args, col.value.Interface())
+	}
+	return strings.Join(assignments, ", "), args
+}
+
+// entityColumns returns the mapped columns of e, flattening embedded structs.
+func entityColumns(e Entity) []column {
+	if e == nil {
+		return nil
+	}
+	val := reflect.ValueOf(e)
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+	return appendColumns(nil, val, false)
+}
+
+// appendColumns collects the mapped columns of val. Nil embedded struct pointers are
+// skipped, or allocated when alloc is set so scans can fill them.
+func appendColumns(columns []column, val reflect.Value, alloc bool) []column {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fieldVal := val.Field(i)
+		if field.Anonymous && field.Tag.Get("db") == "" {
+			if fieldVal.Kind() == reflect.Ptr {
+				if fieldVal.IsNil() {
+					if !alloc || field.Type.Elem().Kind() != reflect.Struct {
+						continue
+					}
+					fieldVal.Set(reflect.New(field.Type.Elem()))
+				}
+				fieldVal = fieldVal.Elem()
+			}
+			if fieldVal.Kind() == reflect.Struct {
+				columns = appendColumns(columns, fieldVal, alloc)
+				continue
+			}
+		}
+		if name, ok := columnName(field); ok {
+			columns = append(columns, column{name: name, value: fieldVal})
+		}
+	}
+	return columns
+}
@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// SkippingEntity has a field excluded from persistence.
+type SkippingEntity struct {
+	BaseEntity
+	Name    string `db:"name"`
+	Cache   string `db:"-"`
+	private string `db:"private"`
+}
+
+func (e *SkippingEntity) TableName() string  { return "skipping_entities" }
+func (e *SkippingEntity) EntityName() string { return "skipping_entity" }
+
+func TestInsertColumns(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	e := &TestEntity{
+		BaseEntity:  BaseEntity{ID: "id-1", CreatedAt: now, UpdatedAt: now},
+		Name:        "Name",
+		Description: "Desc",
+		Active:      true,
+	}
+
+	t.Run("all columns in field order", func(t *testing.T) {
+		columns, placeholders, args := InsertColumns(e)
+		assert.Equal(t, []string{"id", "created_at", "updated_at", "name", "description", "active"}, columns)
+		assert.Equal(t, []string{"?", "?", "?", "?", "?", "?"}, placeholders)
+		assert.Equal(t, []any{"id-1", now, now, "Name", "Desc", true}, args)
+	})
+
+	t.Run("omit id", func(t *testing.T) {
+		columns, placeholders, args := InsertColumns(e, OmitID())
+		assert.Equal(t, []string{"created_at", "updated_at", "name", "description", "active"}, columns)
+		assert.Len(t, placeholders, len(columns))
+		assert.Equal(t, []any{now, now, "Name", "Desc", true}, args)
+	})
+
+	t.Run("skips db dash and unexported fields", func(t *testing.T) {
+		columns, _, args := InsertColumns(&SkippingEntity{Name: "n", Cache: "c", private: "p"}, OmitID())
+		assert.Equal(t, []string{"created_at", "updated_at", "name"}, columns)
+		assert.Equal(t, "n", args[2])
+	})
+
+	t.Run("nil entity", func(t *testing.T) {
+		columns, placeholders, args := InsertColumns(nil)
+		assert.Nil(t, columns)
+		assert.Nil(t, placeholders)
+		assert.Nil(t, args)
+	})
+}
+
+func TestUpdateAssignments(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	e := &TestEntity{
+		BaseEntity:  BaseEntity{ID: "id-1", CreatedAt: now, UpdatedAt: now},
+		Name:        "Name",
+		Description: "Desc",
+		Active:      false,
+	}
+
+	setClause, args := UpdateAssignments(e)
+	assert.Equal(t, "updated_at = ?, name = ?, description = ?, active = ?", setClause)
+	assert.Equal(t, []any{now, "Name", "Desc", false}, args)
+	assert.NotContains(t, setClause, CreatedAtColumn, "updates must not overwrite the creation time")
+}
+
+func TestInsertColumns_Inference(t *testing.T) {
+	SetDefaultColumnInference(true)
+	defer SetDefaultColumnInference(false)
+
+	columns, _, args := InsertColumns(&InferredEntity{Name: "n", FirstName: "Ada"}, OmitID())
+	assert.Equal(t, []string{"created_at", "updated_at", "name", "first_name"}, columns)
+	assert.Equal(t, "Ada", args[3])
+}
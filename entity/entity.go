@@ -6,7 +6,10 @@ package entity
 import (
 	"database/sql"
 	"reflect"
+	"sync/atomic"
 	"time"
+
+	"core/utils"
 )
 
 // Entity represents a domain entity that can be stored in a database.
@@ -114,8 +117,35 @@ func ScanEntities(entity Entity, rows *sql.Rows) ([]Entity, error) {
 	return entities, rows.Err()
 }
 
+// inferColumns enables snake_case column inference for fields without a db tag.
+var inferColumns atomic.Bool
+
+// SetDefaultColumnInference controls how fields without a db tag are mapped.
+// When enabled, an exported, non-embedded field without a db tag maps to the
+// snake_case form of its name (FirstName becomes first_name); `db:"-"` still skips
+// the field. It is disabled by default, so untagged fields are not scanned.
+func SetDefaultColumnInference(enabled bool) {
+	inferColumns.Store(enabled)
+}
+
+// columnName returns the database column for a struct field and whether the field maps to one.
+func columnName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("db")
+	switch {
+	case tag == "-":
+		return "", false
+	case tag != "":
+		return tag, true
+	case inferColumns.Load() && field.IsExported() && !field.Anonymous:
+		return utils.ToSnakeCase(field.Name), true
+	}
+	return "", false
+}
+
 // scanIntoEntity uses reflection to scan database values into an entity struct.
-// It automatically maps database columns to struct fields based on db tags.
+// It automatically maps database columns to struct fields based on db tags,
+// falling back to inferred column names when SetDefaultColumnInference is enabled.
+// Fields of embedded structs such as BaseEntity are scanned in place, in field order.
 func scanIntoEntity(entity, scanner interface{}) error {
 	val := reflect.ValueOf(entity)
 	if val.Kind() != reflect.Ptr || val.IsNil() {
@@ -129,12 +159,8 @@ func scanIntoEntity(entity, scanner interface{}) error {
 
 	var fields []interface{}
 
-	for i := 0; i < elem.NumField(); i++ {
-		field := elem.Type().Field(i)
-		dbTag := field.Tag.Get("db")
-		if dbTag != "" && dbTag != "-" {
-			fields = append(fields, elem.Field(i).Addr().Interface())
-		}
+	for _, col := range appendColumns(nil, elem, true) {
+		fields = append(fields, col.value.Addr().Interface())
 	}
 
 	scanMethod := reflect.ValueOf(scanner).MethodByName("Scan")
@@ -272,3 +272,114 @@ func TestEntity_createNewEntity(t *testing.T) {
 	assert.Empty(t, newEntity.GetID())
 	assert.Empty(t, newEntity.(*TestEntity).Name)
 }
+
+// fakeScanner assigns values to scan destinations in order.
+type fakeScanner struct {
+	values []any
+	dests  int
+}
+
+func (s *fakeScanner) Scan(dest ...any) error {
+	s.dests = len(dest)
+	for i, d := range dest {
+		if i >= len(s.values) {
+			break
+		}
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(s.values[i]))
+	}
+	return nil
+}
+
+// InferredEntity mixes tagged, untagged, and skipped fields.
+type InferredEntity struct {
+	BaseEntity
+	Name      string `db:"name"`
+	FirstName string
+	Secret    string `db:"-"`
+	internal  string
+}
+
+func (e *InferredEntity) TableName() string  { return "inferred_entities" }
+func (e *InferredEntity) EntityName() string { return "inferred_entity" }
+
+func TestEntity_ColumnInference(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		e := &InferredEntity{}
+		scanner := &fakeScanner{values: []any{"id", time.Time{}, time.Time{}, "tagged"}}
+		assert.NoError(t, scanIntoEntity(e, scanner))
+		assert.Equal(t, 4, scanner.dests)
+		assert.Equal(t, "tagged", e.Name)
+		assert.Empty(t, e.FirstName)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		SetDefaultColumnInference(true)
+		defer SetDefaultColumnInference(false)
+
+		e := &InferredEntity{}
+		scanner := &fakeScanner{values: []any{"id", time.Time{}, time.Time{}, "tagged", "Ada"}}
+		assert.NoError(t, scanIntoEntity(e, scanner))
+		assert.Equal(t, 5, scanner.dests)
+		assert.Equal(t, "tagged", e.Name)
+		assert.Equal(t, "Ada", e.FirstName)
+		assert.Empty(t, e.Secret)
+		assert.Empty(t, e.internal)
+	})
+
+	t.Run("column names", func(t *testing.T) {
+		SetDefaultColumnInference(true)
+		defer SetDefaultColumnInference(false)
+
+		typ := reflect.TypeOf(InferredEntity{})
+		cases := map[string]struct {
+			column string
+			ok     bool
+		}{
+			"BaseEntity": {"", false},
+			"Name":       {"name", true},
+			"FirstName":  {"first_name", true},
+			"Secret":     {"", false},
+			"internal":   {"", false},
+		}
+		for fieldName, want := range cases {
+			field, _ := typ.FieldByName(fieldName)
+			column, ok := columnName(field)
+			assert.Equal(t, want.ok, ok, fieldName)
+			assert.Equal(t, want.column, column, fieldName)
+		}
+	})
+}
+
+func TestEntity_ScanEmbeddedColumns(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	updated := created.Add(time.Hour)
+	scanner := &fakeScanner{values: []any{"id-1", created, updated, "Name", "Desc", true}}
+
+	e := &TestEntity{}
+	assert.NoError(t, scanIntoEntity(e, scanner))
+	assert.Equal(t, 6, scanner.dests)
+	assert.Equal(t, "id-1", e.ID)
+	assert.Equal(t, created, e.CreatedAt)
+	assert.Equal(t, updated, e.UpdatedAt)
+	assert.Equal(t, "Name", e.Name)
+	assert.Equal(t, "Desc", e.Description)
+	assert.True(t, e.Active)
+}
+
+// PointerEmbedEntity embeds BaseEntity by pointer.
+type PointerEmbedEntity struct {
+	*BaseEntity
+	Name string `db:"name"`
+}
+
+func TestEntity_ScanEmbeddedPointer(t *testing.T) {
+	scanner := &fakeScanner{values: []any{"id-2", time.Time{}, time.Time{}, "Name"}}
+
+	e := &PointerEmbedEntity{}
+	assert.NoError(t, scanIntoEntity(e, scanner))
+	assert.Equal(t, 4, scanner.dests)
+	if assert.NotNil(t, e.BaseEntity) {
+		assert.Equal(t, "id-2", e.ID)
+	}
+	assert.Equal(t, "Name", e.Name)
+}
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"context"
+	"time"
+
+	"core/ids"
+)
+
+// BeforeCreator is implemented by entities that need to run logic before they are inserted.
+type BeforeCreator interface {
+	// BeforeCreate is called before the entity is inserted.
+	BeforeCreate(ctx context.Context) error
+}
+
+// BeforeUpdater is implemented by entities that need to run logic before they are updated.
+type BeforeUpdater interface {
+	// BeforeUpdate is called before the entity is updated.
+	BeforeUpdate(ctx context.Context) error
+}
+
+// Touch prepares e for persistence at time now. It generates a ULID if the ID is empty,
+// sets CreatedAt if it is zero, and always sets UpdatedAt. Existing IDs and creation
+// timestamps are left intact.
+func Touch(e Entity, now time.Time) {
+	if e == nil {
+		return
+	}
+	if e.GetID() == "" {
+		if id, err := ids.NewULID(now); err == nil {
+			e.SetID(id)
+		}
+	}
+	if e.GetCreatedAt().IsZero() {
+		e.SetCreatedAt(now)
+	}
+	e.SetUpdatedAt(now)
+}
+
+// PrepareCreate touches e and then runs its BeforeCreate hook, if any.
+func PrepareCreate(ctx context.Context, e Entity, now time.Time) error {
+	Touch(e, now)
+	if hook, ok := e.(BeforeCreator); ok {
+		return hook.BeforeCreate(ctx)
+	}
+	return nil
+}
+
+// PrepareUpdate sets UpdatedAt to now and then runs e's BeforeUpdate hook, if any.
+func PrepareUpdate(ctx context.Context, e Entity, now time.Time) error {
+	if e == nil {
+		return nil
+	}
+	e.SetUpdatedAt(now)
+	if hook, ok := e.(BeforeUpdater); ok {
+		return hook.BeforeUpdate(ctx)
+	}
+	return nil
+}
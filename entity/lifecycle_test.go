@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"core/ids"
+)
+
+// HookedEntity records lifecycle hook calls.
+type HookedEntity struct {
+	BaseEntity
+	created int
+	updated int
+	err     error
+}
+
+func (e *HookedEntity) TableName() string  { return "hooked_entities" }
+func (e *HookedEntity) EntityName() string { return "hooked_entity" }
+
+func (e *HookedEntity) BeforeCreate(ctx context.Context) error {
+	e.created++
+	return e.err
+}
+
+func (e *HookedEntity) BeforeUpdate(ctx context.Context) error {
+	e.updated++
+	return e.err
+}
+
+func TestTouch(t *testing.T) {
+	now := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+
+	t.Run("populates empty fields", func(t *testing.T) {
+		e := &TestEntity{}
+		Touch(e, now)
+		assert.True(t, ids.IsULID(e.GetID()), "generated id %q", e.GetID())
+		assert.Equal(t, now, e.GetCreatedAt())
+		assert.Equal(t, now, e.GetUpdatedAt())
+	})
+
+	t.Run("keeps existing id and created at", func(t *testing.T) {
+		created := now.Add(-time.Hour)
+		e := &TestEntity{BaseEntity: BaseEntity{ID: "existing", CreatedAt: created, UpdatedAt: created}}
+		Touch(e, now)
+		assert.Equal(t, "existing", e.GetID())
+		assert.Equal(t, created, e.GetCreatedAt())
+		assert.Equal(t, now, e.GetUpdatedAt())
+	})
+
+	t.Run("nil entity", func(t *testing.T) {
+		assert.NotPanics(t, func() { Touch(nil, now) })
+	})
+}
+
+func TestPrepareCreate(t *testing.T) {
+	now := time.Now()
+	e := &HookedEntity{}
+	assert.NoError(t, PrepareCreate(context.Background(), e, now))
+	assert.Equal(t, 1, e.created)
+	assert.Equal(t, 0, e.updated)
+	assert.NotEmpty(t, e.GetID())
+	assert.Equal(t, now, e.GetCreatedAt())
+
+	wantErr := errors.New("invalid")
+	e = &HookedEntity{err: wantErr}
+	assert.ErrorIs(t, PrepareCreate(context.Background(), e, now), wantErr)
+
+	// Entities without hooks are only touched.
+	assert.NoError(t, PrepareCreate(context.Background(), &TestEntity{}, now))
+}
+
+func TestPrepareUpdate(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := created.Add(24 * time.Hour)
+	e := &HookedEntity{BaseEntity: BaseEntity{ID: "id-1", CreatedAt: created, UpdatedAt: created}}
+
+	assert.NoError(t, PrepareUpdate(context.Background(), e, now))
+	assert.Equal(t, 1, e.updated)
+	assert.Equal(t, 0, e.created)
+	assert.Equal(t, "id-1", e.GetID())
+	assert.Equal(t, created, e.GetCreatedAt())
+	assert.Equal(t, now, e.GetUpdatedAt())
+}
@@ -0,0 +1,62 @@
+package entity
+
+import "time"
+
+// DeletedAtColumn is the column holding an entity's soft-delete timestamp.
+const DeletedAtColumn = "deleted_at"
+
+// SoftDeleter is implemented by entities that are marked deleted instead of removed.
+type SoftDeleter interface {
+	// IsDeleted reports whether the entity has been soft-deleted.
+	IsDeleted() bool
+
+	// SoftDelete marks the entity deleted at t.
+	SoftDelete(t time.Time)
+
+	// Restore clears the soft-delete marker.
+	Restore()
+}
+
+// SoftDeleteEntity provides soft-delete support and can be embedded in domain entities
+// alongside BaseEntity. A nil DeletedAt means the entity is live.
+type SoftDeleteEntity struct {
+	DeletedAt *time.Time `db:"deleted_at"`
+}
+
+// IsDeleted reports whether the entity has been soft-deleted.
+func (e *SoftDeleteEntity) IsDeleted() bool {
+	return e.DeletedAt != nil
+}
+
+// SoftDelete marks the entity deleted at t.
+func (e *SoftDeleteEntity) SoftDelete(t time.Time) {
+	e.DeletedAt = &t
+}
+
+// Restore clears the soft-delete marker.
+func (e *SoftDeleteEntity) Restore() {
+	e.DeletedAt = nil
+}
+
+// HasSoftDelete reports whether e supports soft deletes, either by implementing
+// SoftDeleter or by mapping a deleted_at column.
+func HasSoftDelete(e Entity) bool {
+	if _, ok := e.(SoftDeleter); ok {
+		return true
+	}
+	for _, col := range entityColumns(e) {
+		if col.name == DeletedAtColumn {
+			return true
+		}
+	}
+	return false
+}
+
+// SoftDeleteFilter returns the condition that excludes soft-deleted rows of e's table,
+// or an empty string if e does not support soft deletes.
+func SoftDeleteFilter(e Entity) string {
+	if !HasSoftDelete(e) {
+		return ""
+	}
+	return DeletedAtColumn + " IS NULL"
+}
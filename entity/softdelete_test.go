@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// SoftEntity embeds the soft-delete mixin.
+type SoftEntity struct {
+	BaseEntity
+	SoftDeleteEntity
+	Name string `db:"name"`
+}
+
+func (e *SoftEntity) TableName() string  { return "soft_entities" }
+func (e *SoftEntity) EntityName() string { return "soft_entity" }
+
+// TaggedSoftEntity maps deleted_at without the mixin.
+type TaggedSoftEntity struct {
+	BaseEntity
+	RemovedAt *time.Time `db:"deleted_at"`
+}
+
+func (e *TaggedSoftEntity) TableName() string  { return "tagged_soft_entities" }
+func (e *TaggedSoftEntity) EntityName() string { return "tagged_soft_entity" }
+
+func TestSoftDeleteEntity_Cycle(t *testing.T) {
+	e := &SoftEntity{}
+	assert.False(t, e.IsDeleted())
+
+	now := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	e.SoftDelete(now)
+	assert.True(t, e.IsDeleted())
+	if assert.NotNil(t, e.DeletedAt) {
+		assert.Equal(t, now, *e.DeletedAt)
+	}
+
+	e.Restore()
+	assert.False(t, e.IsDeleted())
+	assert.Nil(t, e.DeletedAt)
+}
+
+func TestHasSoftDelete(t *testing.T) {
+	assert.True(t, HasSoftDelete(&SoftEntity{}))
+	assert.True(t, HasSoftDelete(&TaggedSoftEntity{}))
+	assert.False(t, HasSoftDelete(&TestEntity{}))
+	assert.False(t, HasSoftDelete(nil))
+}
+
+func TestSoftDeleteFilter(t *testing.T) {
+	assert.Equal(t, "deleted_at IS NULL", SoftDeleteFilter(&SoftEntity{}))
+	assert.Empty(t, SoftDeleteFilter(&TestEntity{}))
+}
+
+func TestSoftDelete_Columns(t *testing.T) {
+	now := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	e := &SoftEntity{Name: "n"}
+	e.SoftDelete(now)
+
+	columns, _, args := InsertColumns(e, OmitID())
+	assert.Equal(t, []string{"created_at", "updated_at", "deleted_at", "name"}, columns)
+	assert.Equal(t, &now, args[2])
+
+	setClause, _ := UpdateAssignments(e)
+	assert.Equal(t, "updated_at = ?, deleted_at = ?, name = ?", setClause)
+}
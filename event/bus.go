@@ -8,6 +8,13 @@ import (
 var (
 	ErrClosed     = errors.New("events: bus closed")
 	ErrNilHandler = errors.New("events: nil handler")
+	// ErrBufferFull is returned when a topic buffer has no room: immediately by TryPublish,
+	// or by Publish once WithPublishTimeout elapses.
+	ErrBufferFull = errors.New("events: buffer full")
+	// ErrNoSubscribers is returned by PublishAwait when no subscriber would receive the event.
+	ErrNoSubscribers = errors.New("events: no subscribers")
+	// ErrHandlerPanic wraps a panic recovered from a handler; it is retried and reported like any handler error.
+	ErrHandlerPanic = errors.New("events: handler panic")
 )
 
 // Handler processes an event; returning an error signals failure (may be retried).
@@ -19,8 +26,48 @@ type Subscription interface {
 }
 
 // EventBus is a simple, clean pub/sub interface.
+//
+// Implementations may offer more through the optional TryPublisher, SyncPublisher,
+// AwaitPublisher, QueueInspector, and Shutdowner interfaces; callers discover them by
+// type assertion. MemoryBus implements all of them.
 type EventBus interface {
 	Subscribe(topic string, handler Handler, opts ...SubscribeOption) (Subscription, error)
 	Publish(ctx context.Context, topic string, event any, opts ...PublishOption) error
+	// Close stops accepting publishes and returns without waiting; buffered events still drain.
 	Close() error
 }
+
+// TryPublisher is implemented by buses that can publish without blocking.
+type TryPublisher interface {
+	// TryPublish enqueues event without blocking, returning ErrBufferFull if the topic buffer is full.
+	TryPublish(ctx context.Context, topic string, event any, opts ...PublishOption) error
+}
+
+// SyncPublisher is implemented by buses that can deliver an event in the caller's goroutine.
+type SyncPublisher interface {
+	// PublishSync delivers event to all current subscribers in the caller's goroutine, honoring
+	// retries, and returns the joined errors of handlers that failed after their final retry.
+	PublishSync(ctx context.Context, topic string, event any, opts ...PublishOption) error
+}
+
+// AwaitPublisher is implemented by buses that can wait for a subscriber to handle an event.
+type AwaitPublisher interface {
+	// PublishAwait enqueues event like Publish, then blocks until at least one subscriber has
+	// handled it successfully. It returns nil on the first success, the joined handler errors if
+	// every subscriber failed after its final retry, ErrNoSubscribers if there are none, or
+	// ctx.Err() if ctx ends first.
+	PublishAwait(ctx context.Context, topic string, event any, opts ...PublishOption) error
+}
+
+// QueueInspector is implemented by buses that buffer events per topic.
+type QueueInspector interface {
+	// QueueDepth reports the number of buffered events and the buffer capacity for topic.
+	QueueDepth(topic string) (depth, capacity int)
+}
+
+// Shutdowner is implemented by buses that can wait for buffered events to drain.
+type Shutdowner interface {
+	// Shutdown stops accepting publishes, waits for buffered events to drain and in-flight
+	// handlers to finish, and returns ctx.Err() if ctx ends first.
+	Shutdown(ctx context.Context) error
+}
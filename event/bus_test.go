@@ -1,12 +1,17 @@
 package events
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"reflect"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"core/logging"
 )
 
 func TestMemoryBus_PublishSubscribe(t *testing.T) {
@@ -107,6 +112,61 @@ func TestTypedHelpers(t *testing.T) {
 	}
 }
 
+func TestPublishTyped(t *testing.T) {
+	bus := NewMemoryBus()
+	defer bus.Close()
+
+	type orderCreated struct{ ID string }
+	var got orderCreated
+	_, _ = SubscribeTyped(bus, "orders", func(ctx context.Context, e orderCreated) error {
+		got = e
+		return nil
+	})
+
+	if err := PublishTyped(bus, context.Background(), "orders", orderCreated{ID: "o-1"}); err != nil {
+		t.Fatalf("publish typed: %v", err)
+	}
+	_ = bus.Shutdown(context.Background())
+	if got.ID != "o-1" {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestSubscribeTyped_ReportsMismatch(t *testing.T) {
+	bus := NewMemoryBus()
+	defer bus.Close()
+
+	calls := 0
+	var reported error
+	_, err := SubscribeTyped(bus, "ints", func(ctx context.Context, v int) error {
+		calls++
+		return nil
+	}, WithStrictTypes(), WithErrorHandler(func(ctx context.Context, event any, err error) {
+		reported = err
+	}))
+	if err != nil {
+		t.Fatalf("subscribe typed: %v", err)
+	}
+	// Lenient subscribers keep ignoring mismatches.
+	_, _ = SubscribeTyped(bus, "ints", func(ctx context.Context, v int) error { return nil })
+
+	err = bus.PublishSync(context.Background(), "ints", "not an int")
+	var mismatch *TypeMismatchError
+	if !errors.As(err, &mismatch) || !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("want TypeMismatchError, got %v", err)
+	}
+	if mismatch.Expected.Kind() != reflect.Int || mismatch.Got.Kind() != reflect.String {
+		t.Fatalf("unexpected mismatch types: %v", mismatch)
+	}
+	if !errors.Is(reported, ErrTypeMismatch) || calls != 0 {
+		t.Fatalf("mismatch not reported to error handler: err=%v calls=%d", reported, calls)
+	}
+
+	if err := bus.PublishSync(context.Background(), "ints", 7); err != nil || calls != 1 {
+		t.Fatalf("matching event: err=%v calls=%d", err, calls)
+	}
+}
+
 func TestPublishContextCancel(t *testing.T) {
 	bus := NewMemoryBus(WithBuffer(0)) // unbuffered forces blocking
 	defer bus.Close()
@@ -157,6 +217,409 @@ func TestPublishWithHeaders(t *testing.T) {
 	}
 }
 
+func TestQueueDepthAndSaturationAlert(t *testing.T) {
+	type alert struct {
+		topic string
+		ratio float64
+	}
+	alerts := make(chan alert, 8)
+	bus := NewMemoryBus(WithBuffer(4), WithWorkers(1), WithSaturationAlert(0.75, func(topic string, ratio float64) {
+		alerts <- alert{topic, ratio}
+	}))
+	defer bus.Close()
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	_, err := bus.Subscribe("slow", func(ctx context.Context, evt any) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	if depth, capacity := bus.QueueDepth("unknown"); depth != 0 || capacity != 0 {
+		t.Fatalf("unknown topic: got depth=%d capacity=%d", depth, capacity)
+	}
+
+	// The first event occupies the only worker so later events stay buffered.
+	if err := bus.Publish(context.Background(), "slow", 0); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("handler did not start")
+	}
+
+	for i := 1; i <= 4; i++ {
+		if err := bus.Publish(context.Background(), "slow", i); err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+	}
+
+	if depth, capacity := bus.QueueDepth("slow"); depth != 4 || capacity != 4 {
+		t.Fatalf("got depth=%d capacity=%d, want 4/4", depth, capacity)
+	}
+
+	select {
+	case a := <-alerts:
+		if a.topic != "slow" || a.ratio < 0.75 {
+			t.Fatalf("unexpected alert: %+v", a)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("saturation alert did not fire")
+	}
+	if len(alerts) != 0 {
+		t.Fatalf("alert should fire once per crossing, got %d extra", len(alerts))
+	}
+
+	close(release)
+}
+
+func TestSaturationAlert_HotKey(t *testing.T) {
+	alerts := make(chan float64, 8)
+	bus := NewMemoryBus(WithBuffer(4), WithWorkers(4), WithSaturationAlert(0.75, func(topic string, ratio float64) {
+		alerts <- ratio
+	}))
+	defer bus.Close()
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	defer close(release)
+	_, _ = bus.Subscribe("orders", func(ctx context.Context, evt any) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+		return nil
+	})
+
+	// The first event blocks the key's worker; the rest fill only that key's buffer.
+	_ = bus.Publish(context.Background(), "orders", 0, WithKey("hot"))
+	<-started
+	for i := 1; i <= 3; i++ {
+		if err := bus.Publish(context.Background(), "orders", i, WithKey("hot")); err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+	}
+
+	if depth, capacity := bus.QueueDepth("orders"); float64(depth)/float64(capacity) >= 0.75 {
+		t.Fatalf("topic-wide depth %d/%d should stay below the threshold", depth, capacity)
+	}
+	select {
+	case ratio := <-alerts:
+		if ratio != 0.75 {
+			t.Fatalf("ratio = %v, want 0.75 for the hot key's buffer", ratio)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("saturation alert did not fire for a hot key")
+	}
+}
+
+func TestPublishSync_RunsHandlersInline(t *testing.T) {
+	bus := NewMemoryBus(WithWorkers(1))
+	defer bus.Close()
+
+	var order []string
+	for _, name := range []string{"a", "b"} {
+		name := name
+		_, err := bus.Subscribe("sync", func(ctx context.Context, evt any) error {
+			time.Sleep(5 * time.Millisecond)
+			order = append(order, name)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+	}
+
+	if err := bus.PublishSync(context.Background(), "sync", 1); err != nil {
+		t.Fatalf("publish sync: %v", err)
+	}
+	// No synchronization needed: handlers ran before PublishSync returned.
+	if len(order) != 2 {
+		t.Fatalf("handlers did not complete before return: %v", order)
+	}
+}
+
+func TestPublishSync_JoinsErrorsAndRetries(t *testing.T) {
+	bus := NewMemoryBus()
+	defer bus.Close()
+
+	errA := errors.New("a failed")
+	errB := errors.New("b failed")
+	var triesA atomic.Int32
+	_, _ = bus.Subscribe("sync", func(ctx context.Context, evt any) error {
+		triesA.Add(1)
+		return errA
+	}, WithRetries(3))
+	_, _ = bus.Subscribe("sync", func(ctx context.Context, evt any) error { return errB })
+	_, _ = bus.Subscribe("sync", func(ctx context.Context, evt any) error { return nil })
+
+	err := bus.PublishSync(context.Background(), "sync", "evt")
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Fatalf("want joined errors, got %v", err)
+	}
+	if triesA.Load() != 3 {
+		t.Fatalf("want 3 tries, got %d", triesA.Load())
+	}
+
+	if err := bus.PublishSync(context.Background(), "empty", "evt"); err != nil {
+		t.Fatalf("no subscribers should succeed, got %v", err)
+	}
+
+	_ = bus.Close()
+	if err := bus.PublishSync(context.Background(), "sync", "evt"); !errors.Is(err, ErrClosed) {
+		t.Fatalf("want ErrClosed, got %v", err)
+	}
+}
+
+func TestWildcardSubscriptions(t *testing.T) {
+	bus := NewMemoryBus()
+	defer bus.Close()
+
+	var mu sync.Mutex
+	got := map[string][]any{}
+	record := func(name string) Handler {
+		return func(ctx context.Context, evt any) error {
+			mu.Lock()
+			got[name] = append(got[name], evt)
+			mu.Unlock()
+			return nil
+		}
+	}
+
+	_, _ = bus.Subscribe("order.*", record("single"))
+	_, _ = bus.Subscribe("order.**", record("multi"))
+	_, _ = bus.Subscribe("order.created", record("exact"))
+	patternSub, _ := bus.Subscribe("*.created", record("removed"))
+	patternSub.Unsubscribe()
+
+	ctx := context.Background()
+	_ = bus.PublishSync(ctx, "order.created", "created")
+	_ = bus.PublishSync(ctx, "order.eu.shipped", "shipped")
+	_ = bus.PublishSync(ctx, "invoice.created", "invoice")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(got["single"]) != 1 || got["single"][0] != "created" {
+		t.Fatalf("single-segment wildcard got %v", got["single"])
+	}
+	if len(got["multi"]) != 2 {
+		t.Fatalf("multi-segment wildcard got %v", got["multi"])
+	}
+	if len(got["exact"]) != 1 || got["exact"][0] != "created" {
+		t.Fatalf("exact subscriber got %v", got["exact"])
+	}
+	if len(got["removed"]) != 0 {
+		t.Fatalf("unsubscribed pattern still received %v", got["removed"])
+	}
+}
+
+func TestWildcardSubscriptionAsync(t *testing.T) {
+	bus := NewMemoryBus()
+	defer bus.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	_, err := bus.Subscribe("order.*", func(ctx context.Context, evt any) error {
+		wg.Done()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	_ = bus.Publish(context.Background(), "order.created", 1)
+	_ = bus.Publish(context.Background(), "order.shipped", 2)
+	waitDone(t, &wg)
+}
+
+// blockedTopic subscribes a handler that parks the topic's only worker until release is closed.
+func blockedTopic(t *testing.T, bus EventBus, topic string) (release chan struct{}) {
+	t.Helper()
+	started := make(chan struct{})
+	release = make(chan struct{})
+	var once sync.Once
+	if _, err := bus.Subscribe(topic, func(ctx context.Context, evt any) error {
+		once.Do(func() { close(started) })
+		<-release
+		return nil
+	}); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if err := bus.Publish(context.Background(), topic, "occupy-worker"); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("handler did not start")
+	}
+	return release
+}
+
+func TestTryPublish_BufferFull(t *testing.T) {
+	bus := NewMemoryBus(WithBuffer(1), WithWorkers(1))
+	defer bus.Close()
+	release := blockedTopic(t, bus, "busy")
+	defer close(release)
+
+	if err := bus.TryPublish(context.Background(), "busy", 1); err != nil {
+		t.Fatalf("first try should fit in buffer: %v", err)
+	}
+	start := time.Now()
+	if err := bus.TryPublish(context.Background(), "busy", 2); !errors.Is(err, ErrBufferFull) {
+		t.Fatalf("want ErrBufferFull, got %v", err)
+	}
+	if time.Since(start) > 50*time.Millisecond {
+		t.Fatalf("TryPublish should not block")
+	}
+}
+
+func TestPublishTimeout_BufferFull(t *testing.T) {
+	bus := NewMemoryBus(WithBuffer(1), WithWorkers(1))
+	defer bus.Close()
+	release := blockedTopic(t, bus, "busy")
+	defer close(release)
+
+	if err := bus.Publish(context.Background(), "busy", 1); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	err := bus.Publish(context.Background(), "busy", 2, WithPublishTimeout(10*time.Millisecond))
+	if !errors.Is(err, ErrBufferFull) {
+		t.Fatalf("want ErrBufferFull, got %v", err)
+	}
+}
+
+func TestShutdown_DrainsBufferedEvents(t *testing.T) {
+	const n = 50
+	bus := NewMemoryBus(WithBuffer(n), WithWorkers(2))
+
+	var handled atomic.Int32
+	_, err := bus.Subscribe("jobs", func(ctx context.Context, evt any) error {
+		time.Sleep(time.Millisecond)
+		handled.Add(1)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	for i := 0; i < n; i++ {
+		if err := bus.Publish(context.Background(), "jobs", i); err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := bus.Shutdown(ctx); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if got := handled.Load(); got != n {
+		t.Fatalf("handled %d events, want %d", got, n)
+	}
+	if err := bus.Publish(context.Background(), "jobs", "late"); !errors.Is(err, ErrClosed) {
+		t.Fatalf("publish after shutdown: want ErrClosed, got %v", err)
+	}
+	if err := bus.Shutdown(context.Background()); err != nil {
+		t.Fatalf("second shutdown: %v", err)
+	}
+}
+
+func TestShutdown_RespectsDeadline(t *testing.T) {
+	bus := NewMemoryBus(WithBuffer(1), WithWorkers(1))
+	release := blockedTopic(t, bus, "stuck")
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := bus.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestShutdown_UnblocksPendingPublish(t *testing.T) {
+	bus := NewMemoryBus(WithBuffer(1), WithWorkers(1))
+	release := blockedTopic(t, bus, "busy")
+	defer close(release)
+
+	if err := bus.Publish(context.Background(), "busy", 1); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	errc := make(chan error, 1)
+	go func() { errc <- bus.Publish(context.Background(), "busy", 2) }()
+	time.Sleep(10 * time.Millisecond)
+	_ = bus.Close()
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, ErrClosed) {
+			t.Fatalf("want ErrClosed, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("blocked publish was not released by Close")
+	}
+}
+
+func TestKeyedPublishPreservesPerKeyOrder(t *testing.T) {
+	const keys, perKey = 5, 40
+	bus := NewMemoryBus(WithBuffer(16), WithWorkers(4))
+	defer bus.Close()
+
+	type keyed struct {
+		key string
+		seq int
+	}
+	var mu sync.Mutex
+	seen := map[string][]int{}
+	var wg sync.WaitGroup
+	wg.Add(keys * perKey)
+	_, err := bus.Subscribe("orders", func(ctx context.Context, evt any) error {
+		defer wg.Done()
+		e := evt.(keyed)
+		if e.seq%3 == 0 {
+			time.Sleep(100 * time.Microsecond) // jitter to expose reordering
+		}
+		mu.Lock()
+		seen[e.key] = append(seen[e.key], e.seq)
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	for seq := 0; seq < perKey; seq++ {
+		for k := 0; k < keys; k++ {
+			key := string(rune('a' + k))
+			if err := bus.Publish(context.Background(), "orders", keyed{key, seq}, WithKey(key)); err != nil {
+				t.Fatalf("publish: %v", err)
+			}
+		}
+	}
+
+	waitDone(t, &wg)
+	mu.Lock()
+	defer mu.Unlock()
+	for key, seqs := range seen {
+		if len(seqs) != perKey {
+			t.Fatalf("key %s: got %d events, want %d", key, len(seqs), perKey)
+		}
+		for i := 1; i < len(seqs); i++ {
+			if seqs[i] <= seqs[i-1] {
+				t.Fatalf("key %s out of order: %v", key, seqs)
+			}
+		}
+	}
+}
+
 func waitDone(t *testing.T, wg *sync.WaitGroup) {
 	t.Helper()
 	done := make(chan struct{})
@@ -168,3 +631,389 @@ func waitDone(t *testing.T, wg *sync.WaitGroup) {
 		t.Fatal("timeout waiting for handlers")
 	}
 }
+
+func TestHandlerPanicIsRecoveredAndLogged(t *testing.T) {
+	var buf safeBuffer
+	var reported atomic.Value
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	bus := NewMemoryBus(
+		WithLogger(logging.NewJSON(&buf, logging.DefaultConfig())),
+		WithOnError(func(ctx context.Context, topic string, event any, err error) {
+			reported.Store(err)
+			wg.Done()
+		}),
+	)
+	defer bus.Close()
+
+	_, err := bus.Subscribe("orders", func(ctx context.Context, evt any) error {
+		panic("handler exploded")
+	})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if err := bus.Publish(context.Background(), "orders", "order-1"); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	waitDone(t, &wg)
+	if err, _ := reported.Load().(error); !errors.Is(err, ErrHandlerPanic) {
+		t.Fatalf("want ErrHandlerPanic, got %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{`"msg":"panic recovered"`, `"panic":"handler exploded"`, `"topic":"orders"`, `"event":"order-1"`, `"stack":`} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("log record missing %s: %s", want, out)
+		}
+	}
+}
+
+// safeBuffer is a bytes.Buffer safe for concurrent writes and reads.
+type safeBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *safeBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *safeBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestSubscriptionErrorHandler(t *testing.T) {
+	var global atomic.Int32
+	bus := NewMemoryBus(WithOnError(func(context.Context, string, any, error) { global.Add(1) }))
+	defer bus.Close()
+
+	wantErr := errors.New("handler failed")
+	var gotEvent any
+	var gotErr error
+	_, err := bus.Subscribe("orders", func(ctx context.Context, evt any) error {
+		return wantErr
+	}, WithRetries(2), WithErrorHandler(func(ctx context.Context, event any, err error) {
+		gotEvent, gotErr = event, err
+	}))
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	_, _ = bus.Subscribe("orders", func(ctx context.Context, evt any) error { return nil })
+
+	_ = bus.PublishSync(context.Background(), "orders", "order-1")
+	if gotEvent != "order-1" || !errors.Is(gotErr, wantErr) {
+		t.Fatalf("per-subscription handler got event=%v err=%v", gotEvent, gotErr)
+	}
+	if global.Load() != 1 {
+		t.Fatalf("bus OnError should still fire once, got %d", global.Load())
+	}
+}
+
+func TestSubscriptionDeadLetter(t *testing.T) {
+	bus := NewMemoryBus()
+	defer bus.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	var dlHeaders map[string]string
+	var dlEvent any
+	var dlCalls atomic.Int32
+	_, _ = bus.Subscribe("orders.dlq", func(ctx context.Context, evt any) error {
+		defer wg.Done()
+		dlCalls.Add(1)
+		dlEvent = evt
+		dlHeaders, _ = HeadersFrom(ctx)
+		return errors.New("dead-letter consumer also fails")
+	}, WithDeadLetter("orders.dlq"))
+
+	_, err := bus.Subscribe("orders", func(ctx context.Context, evt any) error {
+		return errors.New("boom")
+	}, WithDeadLetter("orders.dlq"))
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	if err := bus.Publish(context.Background(), "orders", "order-1", WithHeaders(map[string]string{"trace": "t1"})); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	waitDone(t, &wg)
+	if dlEvent != "order-1" {
+		t.Fatalf("dead letter event = %v", dlEvent)
+	}
+	if dlHeaders[HeaderDeadLetterOrigin] != "orders" || dlHeaders[HeaderDeadLetterError] != "boom" || dlHeaders["trace"] != "t1" {
+		t.Fatalf("unexpected dead letter headers: %v", dlHeaders)
+	}
+
+	// A failing dead-letter consumer must not re-route the event again.
+	time.Sleep(20 * time.Millisecond)
+	if dlCalls.Load() != 1 {
+		t.Fatalf("dead letter delivered %d times, want 1", dlCalls.Load())
+	}
+}
+
+func TestRetainLast_DeliversToLateSubscriber(t *testing.T) {
+	bus := NewMemoryBus(WithRetainLast())
+	defer bus.Close()
+
+	if err := bus.PublishSync(context.Background(), "config", "v1"); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	if err := bus.PublishSync(context.Background(), "config", "v2"); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	var mu sync.Mutex
+	var got []any
+	var wg sync.WaitGroup
+	wg.Add(2)
+	var attempts atomic.Int32
+	_, err := bus.Subscribe("config", func(ctx context.Context, evt any) error {
+		if evt == "v2" && attempts.Add(1) == 1 {
+			return errors.New("transient")
+		}
+		defer wg.Done()
+		mu.Lock()
+		got = append(got, evt)
+		mu.Unlock()
+		return nil
+	}, WithRetries(2))
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if err := bus.Publish(context.Background(), "config", "v3"); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	waitDone(t, &wg)
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(got, []any{"v2", "v3"}) {
+		t.Fatalf("got %v, want retained v2 before v3", got)
+	}
+	if attempts.Load() != 2 {
+		t.Fatalf("retained event attempts = %d, want 2", attempts.Load())
+	}
+}
+
+func TestRetainLast_DisabledByDefault(t *testing.T) {
+	bus := NewMemoryBus()
+	defer bus.Close()
+
+	_ = bus.PublishSync(context.Background(), "config", "v1")
+	var calls atomic.Int32
+	_, _ = bus.Subscribe("config", func(ctx context.Context, evt any) error {
+		calls.Add(1)
+		return nil
+	})
+	time.Sleep(20 * time.Millisecond)
+	if calls.Load() != 0 {
+		t.Fatalf("handler called %d times without WithRetainLast", calls.Load())
+	}
+}
+
+func TestWithConcurrency_SlowHandlerDoesNotStarveOthers(t *testing.T) {
+	bus := NewMemoryBus()
+	defer bus.Close()
+
+	release := make(chan struct{})
+	defer close(release)
+	_, err := bus.Subscribe("orders", func(ctx context.Context, evt any) error {
+		<-release
+		return nil
+	}, WithConcurrency(1))
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	_, _ = bus.Subscribe("orders", func(ctx context.Context, evt any) error {
+		wg.Done()
+		return nil
+	})
+
+	for i := 0; i < 3; i++ {
+		if err := bus.Publish(context.Background(), "orders", i); err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+	}
+	waitDone(t, &wg)
+}
+
+func TestWithConcurrency_BoundedParallelismAndRetries(t *testing.T) {
+	bus := NewMemoryBus()
+
+	var running, peak atomic.Int32
+	var mu sync.Mutex
+	attempts := make(map[any]int)
+	var wg sync.WaitGroup
+	wg.Add(4)
+	_, err := bus.Subscribe("jobs", func(ctx context.Context, evt any) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		attempts[evt]++
+		first := attempts[evt] == 1
+		mu.Unlock()
+		if first {
+			return errors.New("transient")
+		}
+		wg.Done()
+		return nil
+	}, WithConcurrency(2), WithRetries(2))
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	for i := 0; i < 4; i++ {
+		_ = bus.Publish(context.Background(), "jobs", i)
+	}
+	waitDone(t, &wg)
+	if got := peak.Load(); got != 2 {
+		t.Fatalf("peak concurrency = %d, want 2", got)
+	}
+	if err := bus.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+}
+
+func TestWithConcurrency_ShutdownDrainsPool(t *testing.T) {
+	bus := NewMemoryBus()
+
+	var handled atomic.Int32
+	_, _ = bus.Subscribe("jobs", func(ctx context.Context, evt any) error {
+		time.Sleep(5 * time.Millisecond)
+		handled.Add(1)
+		return nil
+	}, WithConcurrency(2))
+	for i := 0; i < 10; i++ {
+		_ = bus.Publish(context.Background(), "jobs", i)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := bus.Shutdown(ctx); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if got := handled.Load(); got != 10 {
+		t.Fatalf("handled %d events before shutdown returned, want 10", got)
+	}
+}
+
+func TestPublishAwait_ReturnsAfterFirstAck(t *testing.T) {
+	bus := NewMemoryBus()
+	defer bus.Close()
+
+	release := make(chan struct{})
+	defer close(release)
+	_, _ = bus.Subscribe("jobs", func(ctx context.Context, evt any) error {
+		<-release
+		return nil
+	}, WithConcurrency(1))
+	_, _ = bus.Subscribe("jobs", func(ctx context.Context, evt any) error { return nil })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := bus.PublishAwait(ctx, "jobs", 1); err != nil {
+		t.Fatalf("PublishAwait: %v", err)
+	}
+}
+
+func TestPublishAwait_AllHandlersFail(t *testing.T) {
+	bus := NewMemoryBus()
+	defer bus.Close()
+
+	boom := errors.New("boom")
+	_, _ = bus.Subscribe("jobs", func(ctx context.Context, evt any) error { return boom })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := bus.PublishAwait(ctx, "jobs", 1); !errors.Is(err, boom) {
+		t.Fatalf("PublishAwait err = %v, want %v", err, boom)
+	}
+}
+
+func TestPublishAwait_NoSubscribers(t *testing.T) {
+	bus := NewMemoryBus()
+	defer bus.Close()
+
+	if err := bus.PublishAwait(context.Background(), "nobody", 1); !errors.Is(err, ErrNoSubscribers) {
+		t.Fatalf("PublishAwait err = %v, want ErrNoSubscribers", err)
+	}
+
+	_, _ = bus.Subscribe("orders.*", func(ctx context.Context, evt any) error { return nil })
+	if err := bus.PublishAwait(context.Background(), "orders.created", 1); err != nil {
+		t.Fatalf("pattern subscriber: PublishAwait err = %v", err)
+	}
+}
+
+func TestPublishAwait_Timeout(t *testing.T) {
+	bus := NewMemoryBus()
+	defer bus.Close()
+
+	release := make(chan struct{})
+	defer close(release)
+	_, _ = bus.Subscribe("jobs", func(ctx context.Context, evt any) error {
+		<-release
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := bus.PublishAwait(ctx, "jobs", 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("PublishAwait err = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestPublishAwait_UnsubscribeReleasesQueuedItems(t *testing.T) {
+	bus := NewMemoryBus()
+	defer bus.Close()
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	defer close(release)
+	sub, _ := bus.Subscribe("jobs", func(ctx context.Context, evt any) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}, WithConcurrency(1))
+
+	// Occupy the only pool goroutine so the awaited item stays queued.
+	_ = bus.Publish(context.Background(), "jobs", 0)
+	<-started
+
+	result := make(chan error, 1)
+	go func() { result <- bus.PublishAwait(context.Background(), "jobs", 1) }()
+	pool := sub.(*memorySub).pool
+	deadline := time.Now().Add(time.Second)
+	for len(pool.queue) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("awaited item never reached the pool queue")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	sub.Unsubscribe()
+	select {
+	case err := <-result:
+		if !errors.Is(err, ErrClosed) {
+			t.Fatalf("PublishAwait err = %v, want ErrClosed", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("PublishAwait still blocked after Unsubscribe")
+	}
+}
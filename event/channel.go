@@ -0,0 +1,59 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// Channel subscribes to topic and forwards events onto the returned channel.
+// bufferSize bounds how many events may queue for a slow consumer; once the buffer is full,
+// delivery blocks the topic worker until the consumer catches up or the subscription is cancelled.
+// The returned func unsubscribes and closes the channel; it is safe to call multiple times.
+// If the subscription cannot be created (e.g., the bus is closed), the channel is returned already closed.
+func Channel(bus EventBus, topic string, bufferSize int) (<-chan any, func()) {
+	if bufferSize < 0 {
+		bufferSize = 0
+	}
+	out := make(chan any, bufferSize)
+	done := make(chan struct{})
+
+	// mu guards out: handlers hold the read lock while sending, cancel takes the write lock to close.
+	var mu sync.RWMutex
+
+	sub, err := bus.Subscribe(topic, func(ctx context.Context, event any) error {
+		mu.RLock()
+		defer mu.RUnlock()
+
+		select {
+		case <-done:
+			return nil
+		default:
+		}
+
+		select {
+		case out <- event:
+			return nil
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	if err != nil {
+		close(out)
+		return out, func() {}
+	}
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			sub.Unsubscribe()
+			// Release any handler blocked on a full buffer before closing the channel
+			close(done)
+			mu.Lock()
+			close(out)
+			mu.Unlock()
+		})
+	}
+	return out, cancel
+}
@@ -0,0 +1,90 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestChannel_ReceivesEvents(t *testing.T) {
+	bus := NewMemoryBus(WithBuffer(8), WithWorkers(1))
+	defer bus.Close()
+
+	ch, cancel := Channel(bus, "topic", 4)
+	defer cancel()
+
+	for i := 1; i <= 3; i++ {
+		if err := bus.Publish(context.Background(), "topic", i); err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+	}
+
+	for want := 1; want <= 3; want++ {
+		select {
+		case got := <-ch:
+			if got != want {
+				t.Fatalf("got %v, want %d", got, want)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("timeout waiting for event")
+		}
+	}
+}
+
+func TestChannel_CancelStopsDeliveryAndCloses(t *testing.T) {
+	bus := NewMemoryBus(WithBuffer(8), WithWorkers(1))
+	defer bus.Close()
+
+	ch, cancel := Channel(bus, "topic", 1)
+	cancel()
+	cancel() // idempotent
+
+	if err := bus.Publish(context.Background(), "topic", 1); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	select {
+	case v, ok := <-ch:
+		if ok {
+			t.Fatalf("received %v after cancel, want closed channel", v)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+}
+
+func TestChannel_CancelReleasesBlockedWorker(t *testing.T) {
+	bus := NewMemoryBus(WithBuffer(8), WithWorkers(1))
+	defer bus.Close()
+
+	// Unbuffered and never read: the worker blocks on the first event
+	_, cancel := Channel(bus, "topic", 0)
+	if err := bus.Publish(context.Background(), "topic", 1); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		cancel()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("cancel blocked on a slow consumer")
+	}
+}
+
+func TestChannel_ClosedBus(t *testing.T) {
+	bus := NewMemoryBus()
+	bus.Close()
+
+	ch, cancel := Channel(bus, "topic", 1)
+	defer cancel()
+
+	if _, ok := <-ch; ok {
+		t.Fatal("expected closed channel for closed bus")
+	}
+}
@@ -2,54 +2,220 @@ package events
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"core/recovery"
 )
 
-type memoryBus struct {
+// MemoryBus is the in-memory EventBus returned by NewMemoryBus. Each topic has a buffered
+// queue drained by its own workers. Besides EventBus it implements TryPublisher,
+// SyncPublisher, AwaitPublisher, QueueInspector, and Shutdowner.
+type MemoryBus struct {
 	cfg    BusConfig
 	mu     sync.RWMutex
 	topics map[string]*topic
 	closed bool
+
+	// Shutdown coordination: closing is closed when the bus stops accepting publishes,
+	// stopped once buffered items are drained and all workers have exited.
+	closing    chan struct{}
+	stopped    chan struct{}
+	publishers sync.WaitGroup
+	workers    sync.WaitGroup
+
+	// Wildcard subscriptions, matched against each delivered topic
+	patternMu     sync.RWMutex
+	patterns      map[int64]patternSubscription
+	nextPatternID int64
+
+	// Handler pools of WithConcurrency subscriptions, guarded by mu
+	pools       map[*handlerPool]struct{}
+	poolWorkers sync.WaitGroup
 }
 
 type topic struct {
 	ch      chan item
 	workers int
+	// keyed holds one buffer per worker when workers > 1; items with a partition key are
+	// hashed to a fixed worker so events sharing a key are handled in publish order.
+	keyed []chan item
 
 	mu     sync.RWMutex
 	subs   map[int64]subscription
 	nextID int64
+	// last is the most recently delivered item, kept when RetainLast is set.
+	last *item
+
+	saturated atomic.Bool
 }
 
 type subscription struct {
 	handler Handler
 	config  SubscribeConfig
+	// ready, if set, is closed once the retained event has been handed to this subscription;
+	// deliveries of newer events wait on it so the retained event is always seen first.
+	ready chan struct{}
+	// pool, if set, runs the handler off the topic worker (WithConcurrency).
+	pool *handlerPool
 }
 
-type memorySub struct {
-	bus   *memoryBus
+// handlerPool feeds one subscription's items to its own goroutines.
+type handlerPool struct {
+	queue    chan pooledItem
+	done     chan struct{}
+	stopOnce sync.Once
+
+	// mu guards stopped so no send can land in queue after stop has drained it.
+	mu      sync.RWMutex
+	stopped bool
+}
+
+// pooledItem is an item queued for a handler pool with the topic it was published on,
+// which differs between deliveries for wildcard subscriptions.
+type pooledItem struct {
 	topic string
-	id    int64
+	item  item
+}
+
+// send queues queued for the pool's goroutines, reporting ErrClosed to its ack if the
+// pool has been stopped.
+func (p *handlerPool) send(queued pooledItem) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.stopped {
+		queued.item.ack.report(ErrClosed)
+		return
+	}
+	select {
+	case p.queue <- queued:
+	case <-p.done:
+		queued.item.ack.report(ErrClosed)
+	}
+}
+
+// stop makes the pool's goroutines exit. Items still queued are dropped, reporting
+// ErrClosed so awaiting publishers are released.
+func (p *handlerPool) stop() {
+	p.stopOnce.Do(func() {
+		close(p.done)
+		p.mu.Lock()
+		p.stopped = true
+		p.mu.Unlock()
+		for {
+			select {
+			case queued, ok := <-p.queue:
+				if !ok {
+					return
+				}
+				queued.item.ack.report(ErrClosed)
+			default:
+				return
+			}
+		}
+	})
+}
+
+type patternSubscription struct {
+	segments []string
+	subscription
+}
+
+type memorySub struct {
+	bus     *MemoryBus
+	topic   string
+	id      int64
+	pattern bool
+	pool    *handlerPool
 }
 
 type item struct {
 	ctx   context.Context
 	event any
+	// ack, if set, is signaled as subscribers finish handling the item (PublishAwait).
+	ack *ack
+}
+
+// ack tracks the outcome of one awaited publish across its subscribers.
+type ack struct {
+	mu      sync.Mutex
+	pending int
+	errs    []error
+	settled bool
+	// done is closed on the first success or once every expected subscriber has failed.
+	done chan struct{}
+	err  error
+}
+
+func newAck() *ack {
+	return &ack{done: make(chan struct{})}
+}
+
+// expect records how many subscribers will report for the item; zero settles it
+// with ErrNoSubscribers.
+func (a *ack) expect(n int) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pending += n
+	if a.pending == 0 {
+		a.settle(ErrNoSubscribers)
+	}
+}
+
+// report records one subscriber's final result.
+func (a *ack) report(err error) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pending--
+	if err == nil {
+		a.settle(nil)
+		return
+	}
+	a.errs = append(a.errs, err)
+	if a.pending <= 0 {
+		a.settle(errors.Join(a.errs...))
+	}
+}
+
+// settle publishes the outcome once; callers must hold a.mu.
+func (a *ack) settle(err error) {
+	if a.settled {
+		return
+	}
+	a.settled = true
+	a.err = err
+	close(a.done)
 }
 
 // NewMemoryBus creates an in-memory EventBus.
-func NewMemoryBus(opts ...BusOption) EventBus {
+func NewMemoryBus(opts ...BusOption) *MemoryBus {
 	cfg := BusConfig{BufferSize: 64, WorkersPerTopic: 1}
 	for _, opt := range opts {
 		opt(&cfg)
 	}
-	return &memoryBus{
-		cfg:    cfg,
-		topics: make(map[string]*topic),
+	return &MemoryBus{
+		cfg:      cfg,
+		topics:   make(map[string]*topic),
+		patterns: make(map[int64]patternSubscription),
+		pools:    make(map[*handlerPool]struct{}),
+		closing:  make(chan struct{}),
+		stopped:  make(chan struct{}),
 	}
 }
 
-func (b *memoryBus) ensureTopic(name string) *topic {
+func (b *MemoryBus) ensureTopic(name string) *topic {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
@@ -64,52 +230,218 @@ func (b *memoryBus) ensureTopic(name string) *topic {
 			workers: b.cfg.WorkersPerTopic,
 			subs:    make(map[int64]subscription),
 		}
+		if t.workers > 1 {
+			t.keyed = make([]chan item, t.workers)
+			for i := range t.keyed {
+				t.keyed[i] = make(chan item, b.cfg.BufferSize)
+			}
+		}
 		b.topics[name] = t
 
 		// Start worker goroutines for this topic
+		b.workers.Add(t.workers)
 		for i := 0; i < t.workers; i++ {
-			go b.worker(name, t)
+			var keyed chan item
+			if t.keyed != nil {
+				keyed = t.keyed[i]
+			}
+			go b.worker(name, t, keyed)
 		}
 	}
 	return t
 }
 
-func (b *memoryBus) worker(topicName string, t *topic) {
-	for item := range t.ch {
-		// Snapshot current subscriptions to avoid holding locks during handler execution
-		t.mu.RLock()
-		subs := make([]subscription, 0, len(t.subs))
-		for _, sub := range t.subs {
-			subs = append(subs, sub)
+// worker processes items from the shared topic buffer and, if set, its keyed buffer
+// until both are closed and drained.
+func (b *MemoryBus) worker(topicName string, t *topic, keyed chan item) {
+	defer b.workers.Done()
+	shared := t.ch
+	for shared != nil || keyed != nil {
+		var (
+			item item
+			ok   bool
+		)
+		select {
+		case item, ok = <-shared:
+			if !ok {
+				shared = nil
+				continue
+			}
+		case item, ok = <-keyed:
+			if !ok {
+				keyed = nil
+				continue
+			}
+		}
+		b.rearmSaturation(t)
+		_ = b.deliver(topicName, t, item, false)
+	}
+}
+
+// queue returns the buffer for an item: the worker-specific buffer chosen by hashing key,
+// or the shared buffer for unkeyed items and single-worker topics.
+func (t *topic) queue(key string) chan item {
+	if key == "" || t.keyed == nil {
+		return t.ch
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return t.keyed[h.Sum32()%uint32(len(t.keyed))]
+}
+
+// depth returns the buffered item count and total capacity across the topic's buffers.
+// maxFill returns the highest depth/capacity ratio among the topic's buffers.
+func (t *topic) maxFill() float64 {
+	fill := func(ch chan item) float64 {
+		if cap(ch) == 0 {
+			return 0
 		}
+		return float64(len(ch)) / float64(cap(ch))
+	}
+	highest := fill(t.ch)
+	for _, ch := range t.keyed {
+		highest = max(highest, fill(ch))
+	}
+	return highest
+}
+
+func (t *topic) depth() (depth, capacity int) {
+	depth, capacity = len(t.ch), cap(t.ch)
+	for _, ch := range t.keyed {
+		depth += len(ch)
+		capacity += cap(ch)
+	}
+	return depth, capacity
+}
+
+// deliver runs every current subscriber of t for item, honoring per-subscription retries.
+// Subscriptions with a handler pool are handed the item instead, unless inline is set.
+// It returns the joined errors of subscribers that ran and failed after their final retry.
+func (b *MemoryBus) deliver(topicName string, t *topic, item item, inline bool) error {
+	// Snapshot current subscriptions to avoid holding locks during handler execution.
+	// Retaining under the same lock means a concurrent Subscribe sees item either as the
+	// retained event or as a regular delivery, never both.
+	if b.cfg.RetainLast {
+		t.mu.Lock()
+	} else {
+		t.mu.RLock()
+	}
+	subs := make([]subscription, 0, len(t.subs))
+	for _, sub := range t.subs {
+		subs = append(subs, sub)
+	}
+	if b.cfg.RetainLast {
+		retained := item
+		retained.ack = nil
+		t.last = &retained
+		t.mu.Unlock()
+	} else {
 		t.mu.RUnlock()
+	}
+	subs = b.appendPatternSubs(subs, topicName)
+	item.ack.expect(len(subs))
+
+	// Process each subscription
+	var errs []error
+	for _, sub := range subs {
+		if sub.ready != nil {
+			<-sub.ready
+		}
+		if sub.pool != nil && !inline {
+			sub.pool.send(pooledItem{topic: topicName, item: item})
+			continue
+		}
+		err := b.deliverTo(topicName, sub, item)
+		item.ack.report(err)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
 
-		// Process each subscription
-		for _, sub := range subs {
-			retries := sub.config.Retries
-			if retries <= 0 {
-				retries = 1
-			}
+// deliverTo runs sub for item, retrying up to its configured attempts. If every attempt fails
+// it invokes the error hooks, dead-letters the item, and returns the last error.
+func (b *MemoryBus) deliverTo(topicName string, sub subscription, item item) error {
+	retries := sub.config.Retries
+	if retries <= 0 {
+		retries = 1
+	}
 
-			var lastErr error
-			for attempt := 1; attempt <= retries; attempt++ {
-				if err := sub.handler(item.ctx, item.event); err != nil {
-					lastErr = err
-					continue
-				}
-				lastErr = nil
-				break
-			}
+	var lastErr error
+	for attempt := 1; attempt <= retries; attempt++ {
+		if err := b.invoke(topicName, sub.handler, item); err != nil {
+			lastErr = err
+			continue
+		}
+		return nil
+	}
 
-			// Call error handler if all retries failed
-			if lastErr != nil && b.cfg.OnError != nil {
-				b.cfg.OnError(item.ctx, topicName, item.event, lastErr)
-			}
+	// Call error handler if all retries failed
+	if sub.config.OnError != nil {
+		sub.config.OnError(item.ctx, item.event, lastErr)
+	}
+	if b.cfg.OnError != nil {
+		b.cfg.OnError(item.ctx, topicName, item.event, lastErr)
+	}
+	b.deadLetter(topicName, sub.config.DeadLetter, item, lastErr)
+	return lastErr
+}
+
+// deadLetter re-publishes a failed item onto the dead-letter topic, tagging it with its origin.
+// Items that are already dead letters are dropped to avoid loops.
+func (b *MemoryBus) deadLetter(topicName, deadLetterTopic string, item item, err error) {
+	if deadLetterTopic == "" || deadLetterTopic == topicName {
+		return
+	}
+	headers, _ := HeadersFrom(item.ctx)
+	if _, isDeadLetter := headers[HeaderDeadLetterOrigin]; isDeadLetter {
+		return
+	}
+
+	tagged := make(map[string]string, len(headers)+2)
+	for k, v := range headers {
+		tagged[k] = v
+	}
+	tagged[HeaderDeadLetterOrigin] = topicName
+	tagged[HeaderDeadLetterError] = err.Error()
+
+	// Detach from the original publisher's cancellation so the dead letter is not lost.
+	_ = b.Publish(context.WithoutCancel(item.ctx), deadLetterTopic, item.event, WithHeaders(tagged))
+}
+
+// appendPatternSubs appends wildcard subscriptions matching topicName to subs.
+func (b *MemoryBus) appendPatternSubs(subs []subscription, topicName string) []subscription {
+	b.patternMu.RLock()
+	defer b.patternMu.RUnlock()
+
+	if len(b.patterns) == 0 {
+		return subs
+	}
+	segments := strings.Split(topicName, ".")
+	for _, p := range b.patterns {
+		if matchSegments(p.segments, segments) {
+			subs = append(subs, p.subscription)
 		}
 	}
+	return subs
 }
 
-func (b *memoryBus) Subscribe(topicName string, handler Handler, opts ...SubscribeOption) (Subscription, error) {
+// invoke runs handler for item, converting a panic into an error after logging it.
+func (b *MemoryBus) invoke(topicName string, handler Handler, item item) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			recovery.LogPanic(item.ctx, b.cfg.Logger, r,
+				slog.String("topic", topicName),
+				slog.Any("event", item.event),
+			)
+			err = fmt.Errorf("%w: %v", ErrHandlerPanic, r)
+		}
+	}()
+	return handler(item.ctx, item.event)
+}
+
+func (b *MemoryBus) Subscribe(topicName string, handler Handler, opts ...SubscribeOption) (Subscription, error) {
 	if handler == nil {
 		return nil, ErrNilHandler
 	}
@@ -121,31 +453,135 @@ func (b *memoryBus) Subscribe(topicName string, handler Handler, opts ...Subscri
 	}
 	b.mu.RUnlock()
 
-	topic := b.ensureTopic(topicName)
-	if topic == nil {
-		return nil, ErrClosed
-	}
-
 	// Build subscription config
 	cfg := SubscribeConfig{Retries: 1}
 	for _, opt := range opts {
 		opt(&cfg)
 	}
 
+	sub := subscription{
+		handler: handler,
+		config:  cfg,
+	}
+	if cfg.Concurrency > 0 {
+		pool, err := b.startPool(sub)
+		if err != nil {
+			return nil, err
+		}
+		sub.pool = pool
+	}
+
+	if isPattern(topicName) {
+		b.patternMu.Lock()
+		id := b.nextPatternID + 1
+		b.nextPatternID = id
+		b.patterns[id] = patternSubscription{
+			segments:     strings.Split(topicName, "."),
+			subscription: sub,
+		}
+		b.patternMu.Unlock()
+		return &memorySub{bus: b, topic: topicName, id: id, pattern: true, pool: sub.pool}, nil
+	}
+
+	topic := b.ensureTopic(topicName)
+	if topic == nil {
+		b.stopPool(sub.pool)
+		return nil, ErrClosed
+	}
+
 	// Register subscription
 	topic.mu.Lock()
+	retained := topic.last
+	if retained != nil {
+		sub.ready = make(chan struct{})
+	}
 	id := topic.nextID + 1
 	topic.nextID = id
-	topic.subs[id] = subscription{
-		handler: handler,
-		config:  cfg,
-	}
+	topic.subs[id] = sub
 	topic.mu.Unlock()
 
-	return &memorySub{bus: b, topic: topicName, id: id}, nil
+	// Hand over the retained event before releasing newer deliveries to this subscription.
+	// The replay counts as an in-flight publish so Shutdown waits for it.
+	if retained != nil {
+		b.mu.RLock()
+		if b.closed {
+			b.mu.RUnlock()
+			close(sub.ready)
+		} else {
+			b.publishers.Add(1)
+			b.mu.RUnlock()
+			go b.replay(topicName, sub, *retained)
+		}
+	}
+
+	return &memorySub{bus: b, topic: topicName, id: id, pool: sub.pool}, nil
+}
+
+// startPool starts sub.config.Concurrency goroutines that run sub's handler for queued items.
+func (b *MemoryBus) startPool(sub subscription) (*handlerPool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, ErrClosed
+	}
+
+	pool := &handlerPool{
+		queue: make(chan pooledItem, b.cfg.BufferSize),
+		done:  make(chan struct{}),
+	}
+	b.pools[pool] = struct{}{}
+	b.poolWorkers.Add(sub.config.Concurrency)
+	for i := 0; i < sub.config.Concurrency; i++ {
+		go b.poolWorker(sub, pool)
+	}
+	return pool, nil
+}
+
+// poolWorker handles items from pool until it is stopped or its queue is closed and drained.
+func (b *MemoryBus) poolWorker(sub subscription, pool *handlerPool) {
+	defer b.poolWorkers.Done()
+	for {
+		select {
+		case queued, ok := <-pool.queue:
+			if !ok {
+				return
+			}
+			queued.item.ack.report(b.deliverTo(queued.topic, sub, queued.item))
+		case <-pool.done:
+			return
+		}
+	}
+}
+
+// stopPool stops pool, if any, and forgets it.
+func (b *MemoryBus) stopPool(pool *handlerPool) {
+	if pool == nil {
+		return
+	}
+	pool.stop()
+	b.mu.Lock()
+	delete(b.pools, pool)
+	b.mu.Unlock()
+}
+
+// replay delivers a retained item to a new subscription, then releases its pending deliveries.
+func (b *MemoryBus) replay(topicName string, sub subscription, retained item) {
+	defer b.publishers.Done()
+	defer close(sub.ready)
+	// Detach from the original publisher's cancellation; it has long since returned.
+	retained.ctx = context.WithoutCancel(retained.ctx)
+	_ = b.deliverTo(topicName, sub, retained)
 }
 
 func (s *memorySub) Unsubscribe() {
+	defer s.bus.stopPool(s.pool)
+	if s.pattern {
+		s.bus.patternMu.Lock()
+		delete(s.bus.patterns, s.id)
+		s.bus.patternMu.Unlock()
+		return
+	}
+
 	s.bus.mu.RLock()
 	topic := s.bus.topics[s.topic]
 	s.bus.mu.RUnlock()
@@ -159,7 +595,105 @@ func (s *memorySub) Unsubscribe() {
 	topic.mu.Unlock()
 }
 
-func (b *memoryBus) Publish(ctx context.Context, topicName string, event any, opts ...PublishOption) error {
+func (b *MemoryBus) Publish(ctx context.Context, topicName string, event any, opts ...PublishOption) error {
+	topic, item, cfg, err := b.prepare(ctx, topicName, event, opts)
+	if err != nil {
+		return err
+	}
+	defer b.publishers.Done()
+	return b.enqueue(topicName, topic, item, cfg)
+}
+
+func (b *MemoryBus) PublishAwait(ctx context.Context, topicName string, event any, opts ...PublishOption) error {
+	topic, item, cfg, err := b.prepare(ctx, topicName, event, opts)
+	if err != nil {
+		return err
+	}
+	if !b.hasSubscribers(topicName, topic) {
+		b.publishers.Done()
+		return ErrNoSubscribers
+	}
+	item.ack = newAck()
+	err = b.enqueue(topicName, topic, item, cfg)
+	b.publishers.Done()
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-item.ack.done:
+		return item.ack.err
+	case <-item.ctx.Done():
+		return item.ctx.Err()
+	}
+}
+
+// enqueue sends item to the topic buffer, respecting context cancellation and the publish timeout.
+func (b *MemoryBus) enqueue(topicName string, topic *topic, item item, cfg PublishConfig) error {
+	var timeout <-chan time.Time
+	if cfg.Timeout > 0 {
+		timer := time.NewTimer(cfg.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	queue := topic.queue(cfg.Key)
+	select {
+	case queue <- item:
+		b.checkSaturation(topicName, topic, queue)
+		return nil
+	case <-timeout:
+		return ErrBufferFull
+	case <-b.closing:
+		return ErrClosed
+	case <-item.ctx.Done():
+		return item.ctx.Err()
+	}
+}
+
+// hasSubscribers reports whether t or any wildcard subscription would receive an event on topicName.
+func (b *MemoryBus) hasSubscribers(topicName string, t *topic) bool {
+	t.mu.RLock()
+	n := len(t.subs)
+	t.mu.RUnlock()
+	if n > 0 {
+		return true
+	}
+	return len(b.appendPatternSubs(nil, topicName)) > 0
+}
+
+func (b *MemoryBus) TryPublish(ctx context.Context, topicName string, event any, opts ...PublishOption) error {
+	topic, item, cfg, err := b.prepare(ctx, topicName, event, opts)
+	if err != nil {
+		return err
+	}
+	defer b.publishers.Done()
+
+	queue := topic.queue(cfg.Key)
+	select {
+	case queue <- item:
+		b.checkSaturation(topicName, topic, queue)
+		return nil
+	default:
+		return ErrBufferFull
+	}
+}
+
+func (b *MemoryBus) PublishSync(ctx context.Context, topicName string, event any, opts ...PublishOption) error {
+	topic, item, _, err := b.prepare(ctx, topicName, event, opts)
+	if err != nil {
+		return err
+	}
+	defer b.publishers.Done()
+	if err := item.ctx.Err(); err != nil {
+		return err
+	}
+	return b.deliver(topicName, topic, item, true)
+}
+
+// prepare applies publish options and resolves the topic for a publish operation.
+// On success the publish is tracked as in flight; callers must call b.publishers.Done.
+func (b *MemoryBus) prepare(ctx context.Context, topicName string, event any, opts []PublishOption) (*topic, item, PublishConfig, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -167,8 +701,9 @@ func (b *memoryBus) Publish(ctx context.Context, topicName string, event any, op
 	b.mu.RLock()
 	if b.closed {
 		b.mu.RUnlock()
-		return ErrClosed
+		return nil, item{}, PublishConfig{}, ErrClosed
 	}
+	b.publishers.Add(1)
 	b.mu.RUnlock()
 
 	// Process publish options
@@ -184,32 +719,103 @@ func (b *memoryBus) Publish(ctx context.Context, topicName string, event any, op
 
 	topic := b.ensureTopic(topicName)
 	if topic == nil {
-		return ErrClosed
+		b.publishers.Done()
+		return nil, item{}, PublishConfig{}, ErrClosed
+	}
+	return topic, item{ctx: ctx, event: event}, cfg, nil
+}
+
+func (b *MemoryBus) QueueDepth(topicName string) (depth, capacity int) {
+	b.mu.RLock()
+	t := b.topics[topicName]
+	b.mu.RUnlock()
+
+	if t == nil {
+		return 0, 0
+	}
+	return t.depth()
+}
+
+// checkSaturation fires the saturation hook when queue, the topic buffer just written to,
+// crosses the configured threshold. Keyed buffers are judged on their own, so one hot key
+// filling its buffer raises the alert even while the others are empty.
+func (b *MemoryBus) checkSaturation(topicName string, t *topic, queue chan item) {
+	if b.cfg.OnSaturation == nil || cap(queue) == 0 {
+		return
+	}
+	ratio := float64(len(queue)) / float64(cap(queue))
+	if ratio >= b.cfg.SaturationThreshold && t.saturated.CompareAndSwap(false, true) {
+		b.cfg.OnSaturation(topicName, ratio)
 	}
+}
 
-	item := item{ctx: ctx, event: event}
+// rearmSaturation resets the saturation state once workers drain every buffer of the topic
+// below the threshold.
+func (b *MemoryBus) rearmSaturation(t *topic) {
+	if b.cfg.OnSaturation == nil || !t.saturated.Load() {
+		return
+	}
+	if t.maxFill() < b.cfg.SaturationThreshold {
+		t.saturated.Store(false)
+	}
+}
 
-	// Send to topic channel, respecting context cancellation
+func (b *MemoryBus) Close() error {
+	b.stop()
+	return nil
+}
+
+func (b *MemoryBus) Shutdown(ctx context.Context) error {
 	select {
-	case topic.ch <- item:
+	case <-b.stop():
 		return nil
 	case <-ctx.Done():
 		return ctx.Err()
 	}
 }
 
-func (b *memoryBus) Close() error {
+// stop rejects new publishes and subscriptions, then, once in-flight publishes have returned,
+// closes topic channels so workers drain buffered items and exit. The returned channel is
+// closed after every worker has finished.
+func (b *MemoryBus) stop() <-chan struct{} {
 	b.mu.Lock()
-	defer b.mu.Unlock()
-
 	if b.closed {
-		return nil
+		b.mu.Unlock()
+		return b.stopped
 	}
-
 	b.closed = true
-	for _, topic := range b.topics {
-		close(topic.ch)
-	}
+	close(b.closing)
+	b.mu.Unlock()
+
+	go func() {
+		b.publishers.Wait()
+		b.mu.RLock()
+		for _, topic := range b.topics {
+			close(topic.ch)
+			for _, ch := range topic.keyed {
+				close(ch)
+			}
+		}
+		b.mu.RUnlock()
+		b.workers.Wait()
 
-	return nil
+		// Topic workers no longer feed handler pools; let them drain and exit.
+		b.mu.Lock()
+		for pool := range b.pools {
+			close(pool.queue)
+		}
+		b.mu.Unlock()
+		b.poolWorkers.Wait()
+		close(b.stopped)
+	}()
+	return b.stopped
 }
+
+var (
+	_ EventBus       = (*MemoryBus)(nil)
+	_ TryPublisher   = (*MemoryBus)(nil)
+	_ SyncPublisher  = (*MemoryBus)(nil)
+	_ AwaitPublisher = (*MemoryBus)(nil)
+	_ QueueInspector = (*MemoryBus)(nil)
+	_ Shutdowner     = (*MemoryBus)(nil)
+)
@@ -2,6 +2,14 @@ package events
 
 import "context"
 
+// Headers attached to dead-lettered events.
+const (
+	// HeaderDeadLetterOrigin holds the topic the event originally failed on.
+	HeaderDeadLetterOrigin = "x-dead-letter-origin"
+	// HeaderDeadLetterError holds the final handler error message.
+	HeaderDeadLetterError = "x-dead-letter-error"
+)
+
 // metadataKey is the context key for headers.
 type metadataKey struct{}
 
@@ -1,13 +1,24 @@
 package events
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"core/logging"
+)
 
 // SubscribeOption configures a subscription.
 type SubscribeOption func(*SubscribeConfig)
 
 // SubscribeConfig holds subscription configuration.
 type SubscribeConfig struct {
-	Retries int
+	Retries    int
+	OnError    func(ctx context.Context, event any, err error)
+	DeadLetter string
+	// StrictTypes makes SubscribeTyped report mismatched event types as errors.
+	StrictTypes bool
+	// Concurrency, when positive, runs the handler in its own pool of that many goroutines.
+	Concurrency int
 }
 
 // WithRetries sets number of attempts per event for this handler (default 1, i.e., no retry).
@@ -19,6 +30,43 @@ func WithRetries(n int) SubscribeOption {
 	}
 }
 
+// WithErrorHandler sets a hook invoked when this subscription's handler fails after its final retry.
+// It runs in addition to the bus-level OnError hook.
+func WithErrorHandler(f func(ctx context.Context, event any, err error)) SubscribeOption {
+	return func(c *SubscribeConfig) {
+		c.OnError = f
+	}
+}
+
+// WithDeadLetter re-publishes events that fail after the final retry onto topic.
+// Dead-lettered events carry HeaderDeadLetterOrigin and HeaderDeadLetterError and are never
+// dead-lettered again, so a failing dead-letter consumer cannot cause a loop.
+func WithDeadLetter(topic string) SubscribeOption {
+	return func(c *SubscribeConfig) {
+		c.DeadLetter = topic
+	}
+}
+
+// WithStrictTypes makes SubscribeTyped return a *TypeMismatchError for events of the wrong type
+// instead of ignoring them, so they are retried and reported like other handler failures.
+func WithStrictTypes() SubscribeOption {
+	return func(c *SubscribeConfig) {
+		c.StrictTypes = true
+	}
+}
+
+// WithConcurrency runs this subscription's handler in a dedicated pool of n goroutines fed by a
+// queue of the bus buffer size, so a slow handler does not hold up other subscribers of the topic.
+// Events may then be handled concurrently and out of order for this subscriber; each still gets
+// its retries and error hooks. PublishSync bypasses the pool and runs the handler inline.
+func WithConcurrency(n int) SubscribeOption {
+	return func(c *SubscribeConfig) {
+		if n > 0 {
+			c.Concurrency = n
+		}
+	}
+}
+
 // PublishOption configures a publish operation.
 type PublishOption func(*PublishConfig)
 
@@ -26,6 +74,7 @@ type PublishOption func(*PublishConfig)
 type PublishConfig struct {
 	Headers map[string]string
 	Key     string
+	Timeout time.Duration
 }
 
 // WithHeaders attaches metadata headers to the event.
@@ -43,13 +92,21 @@ func WithHeaders(headers map[string]string) PublishOption {
 	}
 }
 
-// WithKey sets a partition key for the event (useful for distributed systems).
+// WithKey sets a partition key for the event. The memory bus routes events with the same key
+// to the same worker, preserving their publish order when a topic has multiple workers.
 func WithKey(key string) PublishOption {
 	return func(c *PublishConfig) {
 		c.Key = key
 	}
 }
 
+// WithPublishTimeout bounds how long Publish waits for buffer space; on expiry it returns ErrBufferFull.
+func WithPublishTimeout(d time.Duration) PublishOption {
+	return func(c *PublishConfig) {
+		c.Timeout = d
+	}
+}
+
 // BusOption configures an EventBus implementation.
 type BusOption func(*BusConfig)
 
@@ -58,6 +115,14 @@ type BusConfig struct {
 	BufferSize      int
 	WorkersPerTopic int
 	OnError         func(ctx context.Context, topic string, event any, err error)
+
+	SaturationThreshold float64
+	OnSaturation        func(topic string, ratio float64)
+
+	// RetainLast keeps the most recently delivered event per topic for new subscribers.
+	RetainLast bool
+
+	Logger *logging.Logger
 }
 
 // WithBuffer sets the per-topic buffer size (default 64).
@@ -84,3 +149,31 @@ func WithOnError(f func(ctx context.Context, topic string, event any, err error)
 		c.OnError = f
 	}
 }
+
+// WithLogger sets the logger used to report recovered handler panics (default logging.Default()).
+func WithLogger(l *logging.Logger) BusOption {
+	return func(c *BusConfig) {
+		c.Logger = l
+	}
+}
+
+// WithRetainLast makes the bus keep the most recently delivered event on each topic and hand it
+// to every new exact-topic subscriber, honoring its retries and error hooks, before any newer event.
+func WithRetainLast() BusOption {
+	return func(c *BusConfig) {
+		c.RetainLast = true
+	}
+}
+
+// WithSaturationAlert sets a hook invoked when a topic buffer's depth/capacity ratio reaches threshold (0, 1].
+// It fires once per crossing and re-arms after workers drain the buffer below the threshold.
+// With several workers, each keyed buffer is judged on its own (see WithKey).
+// The hook runs in the publishing goroutine and should return quickly.
+func WithSaturationAlert(threshold float64, f func(topic string, ratio float64)) BusOption {
+	return func(c *BusConfig) {
+		if threshold > 0 && threshold <= 1 {
+			c.SaturationThreshold = threshold
+			c.OnSaturation = f
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package events
+
+import "strings"
+
+// Topic pattern wildcards. Topics are dot-separated segments (e.g., "order.created").
+const (
+	// WildcardSegment matches exactly one topic segment ("order.*" matches "order.created").
+	WildcardSegment = "*"
+	// WildcardMulti matches one or more topic segments ("order.**" matches "order.eu.created").
+	WildcardMulti = "**"
+)
+
+// isPattern reports whether topic contains wildcard segments.
+func isPattern(topic string) bool {
+	for _, segment := range strings.Split(topic, ".") {
+		if segment == WildcardSegment || segment == WildcardMulti {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments reports whether the topic segments match the pattern segments.
+func matchSegments(pattern, topic []string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case WildcardMulti:
+			// "**" consumes one or more segments; try every split point.
+			for i := 1; i <= len(topic); i++ {
+				if matchSegments(pattern[1:], topic[i:]) {
+					return true
+				}
+			}
+			return false
+		case WildcardSegment:
+			if len(topic) == 0 {
+				return false
+			}
+		default:
+			if len(topic) == 0 || pattern[0] != topic[0] {
+				return false
+			}
+		}
+		pattern, topic = pattern[1:], topic[1:]
+	}
+	return len(topic) == 0
+}
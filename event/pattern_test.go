@@ -0,0 +1,45 @@
+package events
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatchSegments(t *testing.T) {
+	cases := []struct {
+		pattern string
+		topic   string
+		want    bool
+	}{
+		{"order.*", "order.created", true},
+		{"order.*", "order.eu.created", false},
+		{"order.*", "order", false},
+		{"order.**", "order.created", true},
+		{"order.**", "order.eu.created", true},
+		{"order.**", "order", false},
+		{"*.created", "order.created", true},
+		{"**.created", "eu.order.created", true},
+		{"order.**.shipped", "order.eu.de.shipped", true},
+		{"order.**.shipped", "order.shipped", false},
+		{"order.*", "invoice.created", false},
+	}
+	for _, c := range cases {
+		got := matchSegments(strings.Split(c.pattern, "."), strings.Split(c.topic, "."))
+		if got != c.want {
+			t.Fatalf("match(%q, %q)=%v, want %v", c.pattern, c.topic, got, c.want)
+		}
+	}
+}
+
+func TestIsPattern(t *testing.T) {
+	for topic, want := range map[string]bool{
+		"order.*":       true,
+		"order.**":      true,
+		"order.created": false,
+		"order*":        false,
+	} {
+		if got := isPattern(topic); got != want {
+			t.Fatalf("isPattern(%q)=%v, want %v", topic, got, want)
+		}
+	}
+}
@@ -1,6 +1,27 @@
 package events
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrTypeMismatch is matched (via errors.Is) by TypeMismatchError.
+var ErrTypeMismatch = errors.New("events: event type mismatch")
+
+// TypeMismatchError reports an event whose dynamic type does not match a typed handler.
+type TypeMismatchError struct {
+	Expected reflect.Type
+	Got      reflect.Type
+}
+
+func (e *TypeMismatchError) Error() string {
+	return fmt.Sprintf("events: expected event of type %v, got %v", e.Expected, e.Got)
+}
+
+// Is reports whether target is ErrTypeMismatch.
+func (e *TypeMismatchError) Is(target error) bool { return target == ErrTypeMismatch }
 
 // TypedHandler is a generic handler that accepts a specific event type T.
 type TypedHandler[T any] func(ctx context.Context, event T) error
@@ -17,7 +38,32 @@ func AsHandler[T any](h TypedHandler[T]) Handler {
 	}
 }
 
+// AsStrictHandler is like AsHandler but returns a *TypeMismatchError when the assertion fails,
+// so mismatches reach retries and error hooks instead of being silently ignored.
+func AsStrictHandler[T any](h TypedHandler[T]) Handler {
+	return func(ctx context.Context, event any) error {
+		v, ok := event.(T)
+		if !ok {
+			return &TypeMismatchError{Expected: reflect.TypeFor[T](), Got: reflect.TypeOf(event)}
+		}
+		return h(ctx, v)
+	}
+}
+
 // SubscribeTyped is a helper that subscribes a typed handler to an EventBus.
+// Events of another type are ignored unless WithStrictTypes is set.
 func SubscribeTyped[T any](bus EventBus, topic string, handler TypedHandler[T], opts ...SubscribeOption) (Subscription, error) {
+	var cfg SubscribeConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.StrictTypes {
+		return bus.Subscribe(topic, AsStrictHandler(handler), opts...)
+	}
 	return bus.Subscribe(topic, AsHandler(handler), opts...)
 }
+
+// PublishTyped is a helper that publishes a typed event, mirroring SubscribeTyped.
+func PublishTyped[T any](bus EventBus, ctx context.Context, topic string, event T, opts ...PublishOption) error {
+	return bus.Publish(ctx, topic, event, opts...)
+}
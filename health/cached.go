@@ -0,0 +1,96 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Cached wraps inner so it runs at most once per ttl. Within the TTL, callers receive the
+// last Result and error; the first call, and the first call after expiry, run inner
+// synchronously while concurrent callers wait for that run or for their own context,
+// whichever comes first. Runs that fail because the caller's context was cancelled or
+// timed out are not cached, and callers waiting on such a run retry it themselves; an
+// inner check that hits its own timeout is cached like any other failure.
+func Cached(inner Checker, ttl time.Duration) Checker {
+	return &cachedChecker{inner: inner, ttl: ttl}
+}
+
+type cachedChecker struct {
+	inner Checker
+	ttl   time.Duration
+
+	mu      sync.Mutex
+	res     *Result
+	err     error
+	expires time.Time
+	flight  *checkFlight
+}
+
+// checkFlight is one in-progress run of the inner checker; done is closed when it ends.
+type checkFlight struct {
+	done     chan struct{}
+	res      *Result
+	err      error
+	canceled bool
+}
+
+func (c *cachedChecker) Check(ctx context.Context) (*Result, error) {
+	for {
+		c.mu.Lock()
+		if time.Now().Before(c.expires) {
+			res, err := c.res, c.err
+			c.mu.Unlock()
+			return res, err
+		}
+		if f := c.flight; f != nil {
+			c.mu.Unlock()
+			select {
+			case <-f.done:
+				if f.canceled && ctx.Err() == nil {
+					continue
+				}
+				return f.res, f.err
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		f := &checkFlight{done: make(chan struct{})}
+		c.flight = f
+		c.mu.Unlock()
+		return c.lead(ctx, f)
+	}
+}
+
+// lead runs inner on behalf of every caller waiting on f and publishes its outcome. A
+// panicking inner is reported to the waiters as an error, left uncached and re-raised.
+func (c *cachedChecker) lead(ctx context.Context, f *checkFlight) (*Result, error) {
+	completed := false
+	defer func() {
+		if !completed {
+			f.err = fmt.Errorf("health: cached check panicked: %v", recover())
+			c.finish(f, false)
+			panic(f.err)
+		}
+	}()
+	f.res, f.err = c.inner.Check(ctx)
+	completed = true
+	// Only the leader's own cancellation is discarded; an inner check that times out on
+	// its own is a real result, or a hung dependency would be re-run by every waiter.
+	f.canceled = ctx.Err() != nil
+	c.finish(f, !f.canceled)
+	return f.res, f.err
+}
+
+// finish releases the waiters on f, storing its outcome for ttl when store is set.
+func (c *cachedChecker) finish(f *checkFlight, store bool) {
+	c.mu.Lock()
+	if store {
+		c.res, c.err = f.res, f.err
+		c.expires = time.Now().Add(c.ttl)
+	}
+	c.flight = nil
+	c.mu.Unlock()
+	close(f.done)
+}
@@ -0,0 +1,201 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCached_RunsOnceWithinTTL(t *testing.T) {
+	var calls atomic.Int32
+	c := Cached(FuncChecker(func(ctx context.Context) (*Result, error) {
+		n := calls.Add(1)
+		return OK("ok", map[string]any{"run": n}), nil
+	}), time.Hour)
+
+	first, err := c.Check(context.Background())
+	if err != nil || first.Details["run"] != int32(1) {
+		t.Fatalf("first call: %+v %v", first, err)
+	}
+	for i := 0; i < 10; i++ {
+		res, _ := c.Check(context.Background())
+		if res != first {
+			t.Fatalf("want cached result, got %+v", res)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("want inner to run once, ran %d times", calls.Load())
+	}
+}
+
+func TestCached_Concurrent(t *testing.T) {
+	var calls atomic.Int32
+	c := Cached(FuncChecker(func(ctx context.Context) (*Result, error) {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return OK("ok", nil), nil
+	}), time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if res, err := c.Check(context.Background()); err != nil || res.Status != StatusHealthy {
+				t.Errorf("unexpected result %+v %v", res, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Fatalf("want inner to run once, ran %d times", calls.Load())
+	}
+}
+
+func TestCached_Expiry(t *testing.T) {
+	var calls atomic.Int32
+	c := Cached(FuncChecker(func(ctx context.Context) (*Result, error) {
+		calls.Add(1)
+		return nil, errors.New("db down")
+	}), 10*time.Millisecond)
+
+	_, err1 := c.Check(context.Background())
+	_, err2 := c.Check(context.Background())
+	if err1 == nil || err1 != err2 || calls.Load() != 1 {
+		t.Fatalf("want cached error, got %v / %v after %d runs", err1, err2, calls.Load())
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	c.Check(context.Background())
+	if calls.Load() != 2 {
+		t.Fatalf("want rerun after ttl, ran %d times", calls.Load())
+	}
+}
+
+func TestCached_DoesNotCacheCancellation(t *testing.T) {
+	var calls atomic.Int32
+	c := Cached(FuncChecker(func(ctx context.Context) (*Result, error) {
+		calls.Add(1)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return OK("ok", nil), nil
+	}), time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Check(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("want cancellation, got %v", err)
+	}
+	if res, err := c.Check(context.Background()); err != nil || res.Status != StatusHealthy {
+		t.Fatalf("want fresh run after cancelled one, got %+v %v", res, err)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("want 2 runs, got %d", calls.Load())
+	}
+}
+
+func TestCached_WaitersHonourTheirContext(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	c := Cached(FuncChecker(func(ctx context.Context) (*Result, error) {
+		close(started)
+		<-release
+		return OK("ok", nil), nil
+	}), time.Hour)
+
+	leader := make(chan error, 1)
+	go func() {
+		_, err := c.Check(context.Background())
+		leader <- err
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	begin := time.Now()
+	if _, err := c.Check(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want waiter to give up on its own deadline, got %v", err)
+	}
+	if waited := time.Since(begin); waited > time.Second {
+		t.Fatalf("waiter blocked for %v behind the slow check", waited)
+	}
+
+	close(release)
+	if err := <-leader; err != nil {
+		t.Fatalf("leader: %v", err)
+	}
+	if res, err := c.Check(context.Background()); err != nil || res.Status != StatusHealthy {
+		t.Fatalf("want cached result, got %+v %v", res, err)
+	}
+}
+
+func TestCached_WaiterRetriesAfterLeaderCancelled(t *testing.T) {
+	var calls atomic.Int32
+	started := make(chan struct{})
+	c := Cached(FuncChecker(func(ctx context.Context) (*Result, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return OK("ok", nil), nil
+	}), time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error, 1)
+	go func() {
+		_, err := c.Check(ctx)
+		leader <- err
+	}()
+	<-started
+
+	waiter := make(chan error, 1)
+	go func() {
+		res, err := c.Check(context.Background())
+		if err == nil && res.Status != StatusHealthy {
+			err = fmt.Errorf("unexpected status %v", res.Status)
+		}
+		waiter <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	if err := <-leader; !errors.Is(err, context.Canceled) {
+		t.Fatalf("want leader cancelled, got %v", err)
+	}
+	if err := <-waiter; err != nil {
+		t.Fatalf("want waiter to rerun the check, got %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("want 2 runs, got %d", calls.Load())
+	}
+}
+
+func TestCached_CachesInnerTimeout(t *testing.T) {
+	var calls atomic.Int32
+	c := Cached(FuncChecker(func(ctx context.Context) (*Result, error) {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return nil, fmt.Errorf("db ping: %w", context.DeadlineExceeded)
+	}), time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Check(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("want inner timeout, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Fatalf("want the timed-out check cached after one run, ran %d times", calls.Load())
+	}
+}
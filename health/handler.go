@@ -0,0 +1,97 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// HandlerOption configures Handler.
+type HandlerOption func(*handlerConfig)
+
+type handlerConfig struct {
+	tag      string
+	perCheck time.Duration
+	codes    map[Status]int
+}
+
+// WithTag restricts the handler to checkers carrying tag, e.g. TagReadiness for /readyz.
+func WithTag(tag string) HandlerOption {
+	return func(c *handlerConfig) { c.tag = tag }
+}
+
+// WithCheckTimeout bounds each checker run by the handler; see RunAllTimeout.
+func WithCheckTimeout(d time.Duration) HandlerOption {
+	return func(c *handlerConfig) { c.perCheck = d }
+}
+
+// WithStatusCode overrides the HTTP status code written for an overall status.
+func WithStatusCode(status Status, code int) HandlerOption {
+	return func(c *handlerConfig) { c.codes[status] = code }
+}
+
+// Handler returns an http.Handler that runs the registry's checkers concurrently and
+// writes the Summary as JSON. By default healthy and degraded map to 200, unhealthy and
+// unknown to 503.
+//
+//	mux.Handle("/healthz", health.Handler(reg, health.WithTag(health.TagLiveness)))
+//	mux.Handle("/readyz", health.Handler(reg, health.WithTag(health.TagReadiness)))
+func Handler(r *Registry, opts ...HandlerOption) http.Handler {
+	cfg := handlerConfig{codes: map[Status]int{
+		StatusHealthy:   http.StatusOK,
+		StatusDegraded:  http.StatusOK,
+		StatusUnhealthy: http.StatusServiceUnavailable,
+		StatusUnknown:   http.StatusServiceUnavailable,
+	}}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		sum := r.runConcurrent(req.Context(), cfg.tag, cfg.perCheck)
+		code, ok := cfg.codes[sum.Overall]
+		if !ok {
+			code = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(newSummaryJSON(sum))
+	})
+}
+
+// summaryJSON is the wire form of a Summary.
+type summaryJSON struct {
+	Status Status      `json:"status"`
+	Checks []entryJSON `json:"checks"`
+}
+
+type entryJSON struct {
+	Name       string         `json:"name"`
+	Tags       []string       `json:"tags,omitempty"`
+	Status     Status         `json:"status"`
+	Message    string         `json:"message,omitempty"`
+	Details    map[string]any `json:"details,omitempty"`
+	Error      string         `json:"error,omitempty"`
+	DurationMS float64        `json:"duration_ms"`
+}
+
+func newSummaryJSON(sum Summary) summaryJSON {
+	out := summaryJSON{Status: sum.Overall, Checks: make([]entryJSON, 0, len(sum.Entries))}
+	for _, e := range sum.Entries {
+		ej := entryJSON{
+			Name:       e.Name,
+			Tags:       e.Tags,
+			Status:     statusFrom(e.Result, e.Error),
+			DurationMS: float64(e.Duration) / float64(time.Millisecond),
+		}
+		if e.Result != nil {
+			ej.Message = e.Result.Message
+			ej.Details = e.Result.Details
+		}
+		if e.Error != nil {
+			ej.Error = e.Error.Error()
+		}
+		out.Checks = append(out.Checks, ej)
+	}
+	return out
+}
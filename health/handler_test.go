@@ -0,0 +1,88 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func serve(t *testing.T, h http.Handler) (int, summaryJSON) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("want JSON content type, got %q", ct)
+	}
+	var body summaryJSON
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode body %q: %v", rec.Body.String(), err)
+	}
+	return rec.Code, body
+}
+
+func static(res *Result, err error) Checker {
+	return FuncChecker(func(ctx context.Context) (*Result, error) { return res, err })
+}
+
+func TestHandler_Healthy(t *testing.T) {
+	reg := New()
+	reg.Register("db", static(OK("db ok", map[string]any{"pool": float64(4)}), nil))
+	reg.Register("cache", static(Degraded("cache slow", nil), nil))
+
+	code, body := serve(t, Handler(reg))
+	if code != http.StatusOK {
+		t.Fatalf("want 200, got %d", code)
+	}
+	if body.Status != StatusDegraded || len(body.Checks) != 2 {
+		t.Fatalf("unexpected body: %+v", body)
+	}
+	if c := body.Checks[1]; c.Name != "db" || c.Status != StatusHealthy || c.Message != "db ok" || c.Details["pool"] != float64(4) {
+		t.Fatalf("unexpected db entry: %+v", c)
+	}
+}
+
+func TestHandler_Unhealthy(t *testing.T) {
+	reg := New()
+	reg.Register("db", static(Unhealthy("db down", nil), nil))
+	reg.Register("probe", static(nil, errors.New("misconfigured")))
+
+	code, body := serve(t, Handler(reg))
+	if code != http.StatusServiceUnavailable {
+		t.Fatalf("want 503, got %d", code)
+	}
+	if body.Status != StatusUnhealthy {
+		t.Fatalf("want unhealthy, got %v", body.Status)
+	}
+	if c := body.Checks[1]; c.Name != "probe" || c.Status != StatusUnknown || c.Error != "misconfigured" {
+		t.Fatalf("unexpected probe entry: %+v", c)
+	}
+}
+
+func TestHandler_StatusCodeOverride(t *testing.T) {
+	reg := New()
+	reg.Register("cache", static(Degraded("cache slow", nil), nil))
+
+	code, _ := serve(t, Handler(reg, WithStatusCode(StatusDegraded, http.StatusServiceUnavailable)))
+	if code != http.StatusServiceUnavailable {
+		t.Fatalf("want overridden 503, got %d", code)
+	}
+}
+
+func TestHandler_LivenessAndReadiness(t *testing.T) {
+	reg := New()
+	reg.RegisterWith("process", static(OK("alive", nil), nil), WithTags(TagLiveness))
+	reg.RegisterWith("db", static(Unhealthy("db down", nil), nil), WithTags(TagReadiness))
+
+	code, body := serve(t, Handler(reg, WithTag(TagLiveness)))
+	if code != http.StatusOK || len(body.Checks) != 1 || body.Checks[0].Name != "process" {
+		t.Fatalf("liveness: code=%d body=%+v", code, body)
+	}
+
+	code, body = serve(t, Handler(reg, WithTag(TagReadiness)))
+	if code != http.StatusServiceUnavailable || len(body.Checks) != 1 || body.Checks[0].Name != "db" {
+		t.Fatalf("readiness: code=%d body=%+v", code, body)
+	}
+}
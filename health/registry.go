@@ -2,12 +2,24 @@ package health
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
 	"time"
 )
 
+// ErrCheckTimeout is wrapped by the error recorded for a checker that exceeds its timeout.
+var ErrCheckTimeout = errors.New("health: check timed out")
+
+// ErrCheckCanceled is wrapped by the error recorded for a checker abandoned because the
+// caller's context was cancelled.
+var ErrCheckCanceled = errors.New("health: check canceled")
+
 // Entry represents a registered checker and timing info.
 type Entry struct {
 	Name     string
+	Tags     []string
 	Result   *Result
 	Error    error
 	Duration time.Duration
@@ -19,28 +31,121 @@ type Summary struct {
 	Entries []Entry
 }
 
+// Standard tags distinguishing probe kinds.
+const (
+	// TagLiveness marks checks that report whether the process is alive.
+	TagLiveness = "liveness"
+	// TagReadiness marks checks that report whether dependencies are reachable.
+	TagReadiness = "readiness"
+)
+
 // Registry holds named checkers.
 type Registry struct {
 	checks map[string]Checker
+	tags   map[string][]string
 }
 
 // New creates a registry.
-func New() *Registry { return &Registry{checks: map[string]Checker{}} }
+func New() *Registry { return &Registry{checks: map[string]Checker{}, tags: map[string][]string{}} }
 
 // Register adds or replaces a checker.
-func (r *Registry) Register(name string, c Checker) { r.checks[name] = c }
+func (r *Registry) Register(name string, c Checker) { r.RegisterWith(name, c) }
+
+// RegisterOption configures a checker registration.
+type RegisterOption func(*registration)
+
+type registration struct {
+	tags []string
+}
+
+// WithTags tags a checker, e.g. with TagLiveness or TagReadiness.
+func WithTags(tags ...string) RegisterOption {
+	return func(reg *registration) { reg.tags = append(reg.tags, tags...) }
+}
+
+// RegisterWith adds or replaces a checker with options.
+func (r *Registry) RegisterWith(name string, c Checker, opts ...RegisterOption) {
+	var reg registration
+	for _, opt := range opts {
+		opt(&reg)
+	}
+	r.checks[name] = c
+	r.tags[name] = reg.tags
+}
+
+// hasTag reports whether the named checker carries tag.
+func (r *Registry) hasTag(name, tag string) bool {
+	for _, t := range r.tags[name] {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
 
 // RunAll executes all registered checks with the given context.
 // Overall status is the worst among results (Unhealthy > Degraded > Unknown > Healthy).
 func (r *Registry) RunAll(ctx context.Context) Summary {
+	return r.RunAllTimeout(ctx, 0)
+}
+
+// RunAllTimeout executes all registered checks, giving each at most perCheck to finish.
+// A checker that exceeds its deadline has its context cancelled and is recorded with
+// StatusUnknown and an error wrapping ErrCheckTimeout. A perCheck <= 0 disables the timeout.
+func (r *Registry) RunAllTimeout(ctx context.Context, perCheck time.Duration) Summary {
 	entries := make([]Entry, 0, len(r.checks))
-	overall := StatusHealthy
 	for name, c := range r.checks {
-		start := time.Now()
-		res, err := c.Check(ctx)
-		dur := time.Since(start)
-		entries = append(entries, Entry{Name: name, Result: res, Error: err, Duration: dur})
-		st := statusFrom(res, err)
+		e := runCheck(ctx, name, c, perCheck)
+		e.Tags = r.tags[name]
+		entries = append(entries, e)
+	}
+	return summarize(entries)
+}
+
+// RunAllConcurrent executes all registered checks in parallel, so total latency is that of
+// the slowest checker. Entries are sorted by name; the overall status is computed as in RunAll.
+func (r *Registry) RunAllConcurrent(ctx context.Context) Summary {
+	return r.runConcurrent(ctx, "", 0)
+}
+
+// RunTagged executes, in parallel, only the checkers registered with tag.
+// Entries are sorted by name; the overall status is computed as in RunAll.
+func (r *Registry) RunTagged(ctx context.Context, tag string) Summary {
+	if tag == "" {
+		return Summary{Overall: StatusHealthy, Entries: []Entry{}}
+	}
+	return r.runConcurrent(ctx, tag, 0)
+}
+
+// runConcurrent runs the checkers carrying tag (all checkers if tag is empty) in parallel.
+func (r *Registry) runConcurrent(ctx context.Context, tag string, perCheck time.Duration) Summary {
+	names := make([]string, 0, len(r.checks))
+	for name := range r.checks {
+		if tag == "" || r.hasTag(name, tag) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	entries := make([]Entry, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string, c Checker) {
+			defer wg.Done()
+			entries[i] = runCheck(ctx, name, c, perCheck)
+			entries[i].Tags = r.tags[name]
+		}(i, name, r.checks[name])
+	}
+	wg.Wait()
+	return summarize(entries)
+}
+
+// summarize computes the overall status of entries.
+func summarize(entries []Entry) Summary {
+	overall := StatusHealthy
+	for _, e := range entries {
+		st := statusFrom(e.Result, e.Error)
 		if worse(st, overall) {
 			overall = st
 		}
@@ -48,6 +153,49 @@ func (r *Registry) RunAll(ctx context.Context) Summary {
 	return Summary{Overall: overall, Entries: entries}
 }
 
+// runCheck runs a single checker, enforcing timeout when it is positive.
+func runCheck(ctx context.Context, name string, c Checker, timeout time.Duration) Entry {
+	start := time.Now()
+	if timeout <= 0 {
+		res, err := c.Check(ctx)
+		return Entry{Name: name, Result: res, Error: err, Duration: time.Since(start)}
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type outcome struct {
+		res *Result
+		err error
+	}
+	// Buffered so the checker goroutine can exit once it observes cancellation.
+	done := make(chan outcome, 1)
+	go func() {
+		res, err := c.Check(checkCtx)
+		done <- outcome{res, err}
+	}()
+
+	select {
+	case o := <-done:
+		return Entry{Name: name, Result: o.res, Error: o.err, Duration: time.Since(start)}
+	case <-checkCtx.Done():
+		err := checkCtx.Err()
+		message := "check timed out"
+		if errors.Is(err, context.Canceled) {
+			message = "check canceled"
+			err = fmt.Errorf("%w: %w", ErrCheckCanceled, err)
+		} else {
+			err = fmt.Errorf("%w after %v: %w", ErrCheckTimeout, timeout, err)
+		}
+		return Entry{
+			Name:     name,
+			Result:   Unknown(message, nil),
+			Error:    err,
+			Duration: time.Since(start),
+		}
+	}
+}
+
 func statusFrom(res *Result, err error) Status {
 	if err != nil {
 		return StatusUnknown
@@ -0,0 +1,189 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunAll(t *testing.T) {
+	reg := New()
+	reg.Register("db", FuncChecker(func(ctx context.Context) (*Result, error) {
+		return OK("db ok", nil), nil
+	}))
+	reg.Register("cache", FuncChecker(func(ctx context.Context) (*Result, error) {
+		return Degraded("cache slow", nil), nil
+	}))
+
+	sum := reg.RunAll(context.Background())
+	if sum.Overall != StatusDegraded {
+		t.Fatalf("want degraded overall, got %v", sum.Overall)
+	}
+	if len(sum.Entries) != 2 {
+		t.Fatalf("want 2 entries, got %d", len(sum.Entries))
+	}
+}
+
+func TestRunAllTimeout_SlowChecker(t *testing.T) {
+	var cancelled atomic.Bool
+	reg := New()
+	reg.Register("slow", FuncChecker(func(ctx context.Context) (*Result, error) {
+		select {
+		case <-ctx.Done():
+			cancelled.Store(true)
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+			return OK("too late", nil), nil
+		}
+	}))
+	reg.Register("fast", FuncChecker(func(ctx context.Context) (*Result, error) {
+		return OK("fast", nil), nil
+	}))
+
+	start := time.Now()
+	sum := reg.RunAllTimeout(context.Background(), 20*time.Millisecond)
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("slow checker blocked the summary for %v", elapsed)
+	}
+	if sum.Overall != StatusUnknown {
+		t.Fatalf("want unknown overall, got %v", sum.Overall)
+	}
+
+	var slow Entry
+	for _, e := range sum.Entries {
+		if e.Name == "slow" {
+			slow = e
+		} else if e.Error != nil || e.Result.Status != StatusHealthy {
+			t.Fatalf("fast checker affected: %+v", e)
+		}
+	}
+	if !errors.Is(slow.Error, ErrCheckTimeout) || !errors.Is(slow.Error, context.DeadlineExceeded) {
+		t.Fatalf("want timeout error, got %v", slow.Error)
+	}
+	if slow.Result == nil || slow.Result.Status != StatusUnknown {
+		t.Fatalf("want unknown result, got %+v", slow.Result)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for !cancelled.Load() {
+		if time.Now().After(deadline) {
+			t.Fatal("slow checker context was not cancelled")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRunAllTimeout_ParentCancelled(t *testing.T) {
+	reg := New()
+	reg.Register("blocked", FuncChecker(func(ctx context.Context) (*Result, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	sum := reg.RunAllTimeout(ctx, time.Second)
+	if e := sum.Entries[0]; !errors.Is(e.Error, context.Canceled) || errors.Is(e.Error, ErrCheckTimeout) {
+		t.Fatalf("want cancellation error, got %v", e.Error)
+	}
+}
+
+func TestRunAllTimeout_CancelledWhileRunning(t *testing.T) {
+	reg := New()
+	started := make(chan struct{})
+	reg.Register("stuck", FuncChecker(func(ctx context.Context) (*Result, error) {
+		close(started)
+		select {} // ignores ctx, so runCheck must give up on its own
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+	e := reg.RunAllTimeout(ctx, time.Minute).Entries[0]
+	if !errors.Is(e.Error, ErrCheckCanceled) || !errors.Is(e.Error, context.Canceled) || errors.Is(e.Error, ErrCheckTimeout) {
+		t.Fatalf("want cancellation error, got %v", e.Error)
+	}
+	if e.Result == nil || e.Result.Message != "check canceled" {
+		t.Fatalf("want canceled result, got %+v", e.Result)
+	}
+}
+
+func TestRunAllConcurrent(t *testing.T) {
+	reg := New()
+	sleep := func(d time.Duration, res *Result) Checker {
+		return FuncChecker(func(ctx context.Context) (*Result, error) {
+			time.Sleep(d)
+			return res, nil
+		})
+	}
+	reg.Register("db", sleep(50*time.Millisecond, OK("db", nil)))
+	reg.Register("cache", sleep(50*time.Millisecond, Degraded("cache", nil)))
+	reg.Register("queue", sleep(50*time.Millisecond, OK("queue", nil)))
+	reg.Register("api", sleep(50*time.Millisecond, Unhealthy("api", nil)))
+
+	start := time.Now()
+	sum := reg.RunAllConcurrent(context.Background())
+	elapsed := time.Since(start)
+
+	if elapsed >= 150*time.Millisecond {
+		t.Fatalf("checks did not run concurrently: took %v", elapsed)
+	}
+	if sum.Overall != StatusUnhealthy {
+		t.Fatalf("want unhealthy overall, got %v", sum.Overall)
+	}
+	want := []string{"api", "cache", "db", "queue"}
+	if len(sum.Entries) != len(want) {
+		t.Fatalf("want %d entries, got %d", len(want), len(sum.Entries))
+	}
+	for i, name := range want {
+		if sum.Entries[i].Name != name {
+			t.Fatalf("entry %d: want %q, got %q", i, name, sum.Entries[i].Name)
+		}
+		if sum.Entries[i].Duration < 50*time.Millisecond {
+			t.Fatalf("entry %q: duration %v not recorded", name, sum.Entries[i].Duration)
+		}
+	}
+}
+
+func TestRunTagged(t *testing.T) {
+	var ran sync.Map
+	checker := func(name string) Checker {
+		return FuncChecker(func(ctx context.Context) (*Result, error) {
+			ran.Store(name, true)
+			return OK(name, nil), nil
+		})
+	}
+
+	reg := New()
+	reg.Register("untagged", checker("untagged"))
+	reg.RegisterWith("process", checker("process"), WithTags(TagLiveness))
+	reg.RegisterWith("db", checker("db"), WithTags(TagReadiness))
+	reg.RegisterWith("queue", checker("queue"), WithTags(TagReadiness, "critical"))
+
+	sum := reg.RunTagged(context.Background(), TagReadiness)
+	if len(sum.Entries) != 2 || sum.Entries[0].Name != "db" || sum.Entries[1].Name != "queue" {
+		t.Fatalf("unexpected entries: %+v", sum.Entries)
+	}
+	if got := sum.Entries[1].Tags; len(got) != 2 || got[1] != "critical" {
+		t.Fatalf("want tags on entry, got %v", got)
+	}
+	for _, name := range []string{"untagged", "process"} {
+		if _, ok := ran.Load(name); ok {
+			t.Fatalf("checker %q ran but is not tagged readiness", name)
+		}
+	}
+
+	if sum := reg.RunTagged(context.Background(), "missing"); len(sum.Entries) != 0 || sum.Overall != StatusHealthy {
+		t.Fatalf("want empty healthy summary, got %+v", sum)
+	}
+
+	// Register keeps working and leaves checks untagged.
+	if all := reg.RunAll(context.Background()); len(all.Entries) != 4 {
+		t.Fatalf("want all 4 checks from RunAll, got %d", len(all.Entries))
+	}
+}
@@ -0,0 +1,62 @@
+package ids
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Base32Encode encodes src with the Crockford base32 alphabet used by ULIDs, without
+// padding. Bits are taken most significant first; the final character is zero-padded.
+func Base32Encode(src []byte) string {
+	var sb strings.Builder
+	sb.Grow((len(src)*8 + 4) / 5)
+	var acc uint32
+	var bits uint8
+	for _, b := range src {
+		acc = (acc << 8) | uint32(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			sb.WriteByte(crockford[(acc>>bits)&31])
+		}
+	}
+	if bits > 0 {
+		sb.WriteByte(crockford[(acc<<(5-bits))&31])
+	}
+	return sb.String()
+}
+
+// Base32Decode decodes a Crockford base32 string produced by Base32Encode.
+// Decoding is case-insensitive; invalid characters, impossible lengths and
+// non-zero padding bits are rejected.
+func Base32Decode(s string) ([]byte, error) {
+	out := make([]byte, 0, len(s)*5/8)
+	var acc uint32
+	var bits uint8
+	for i := 0; i < len(s); i++ {
+		idx := indexCrock(upper(s[i]))
+		if idx < 0 {
+			return nil, fmt.Errorf("ids: invalid base32 character %q at %d", s[i], i)
+		}
+		acc = (acc << 5) | uint32(idx)
+		bits += 5
+		if bits >= 8 {
+			bits -= 8
+			out = append(out, byte(acc>>bits))
+		}
+	}
+	if bits >= 5 {
+		return nil, fmt.Errorf("ids: invalid base32 length %d", len(s))
+	}
+	if acc&(1<<bits-1) != 0 {
+		return nil, fmt.Errorf("ids: invalid base32 padding")
+	}
+	return out, nil
+}
+
+func upper(b byte) byte {
+	if b >= 'a' && b <= 'z' {
+		return b - 'a' + 'A'
+	}
+	return b
+}
@@ -0,0 +1,73 @@
+package ids
+
+import (
+	"bytes"
+	"crypto/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBase32_RoundTrip(t *testing.T) {
+	for n := 0; n <= 32; n++ {
+		src := make([]byte, n)
+		if _, err := rand.Read(src); err != nil {
+			t.Fatalf("rand: %v", err)
+		}
+		enc := Base32Encode(src)
+		if want := (n*8 + 4) / 5; len(enc) != want {
+			t.Fatalf("len(Base32Encode(%d bytes))=%d, want %d", n, len(enc), want)
+		}
+		for _, s := range []string{enc, strings.ToLower(enc)} {
+			got, err := Base32Decode(s)
+			if err != nil {
+				t.Fatalf("decode %q: %v", s, err)
+			}
+			if !bytes.Equal(got, src) {
+				t.Fatalf("round trip mismatch for %x: got %x", src, got)
+			}
+		}
+	}
+}
+
+func TestBase32_KnownValues(t *testing.T) {
+	cases := map[string][]byte{
+		"":   {},
+		"00": {0x00},
+		"ZW": {0xFF},
+		"Z0": {0xF8},
+	}
+	for want, src := range cases {
+		if got := Base32Encode(src); got != want {
+			t.Fatalf("Base32Encode(%x)=%q, want %q", src, got, want)
+		}
+	}
+}
+
+func TestBase32_MatchesULIDEntropy(t *testing.T) {
+	s, err := NewULID(time.UnixMilli(1700000000000))
+	if err != nil {
+		t.Fatalf("new ulid: %v", err)
+	}
+	entropy, err := Base32Decode(s[10:])
+	if err != nil || len(entropy) != 10 {
+		t.Fatalf("decode ulid entropy: %x %v", entropy, err)
+	}
+	if Base32Encode(entropy) != s[10:] {
+		t.Fatalf("entropy re-encoding mismatch")
+	}
+}
+
+func TestBase32Decode_Invalid(t *testing.T) {
+	for _, s := range []string{
+		"U0",  // U is not in the alphabet
+		"IL",  // ambiguous letters are rejected
+		"0-0", // separators are not skipped
+		"0",   // 5 bits cannot encode a byte
+		"01",  // non-zero padding bits
+	} {
+		if _, err := Base32Decode(s); err == nil {
+			t.Fatalf("expected error for %q", s)
+		}
+	}
+}
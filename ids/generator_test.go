@@ -0,0 +1,70 @@
+package ids
+
+import "testing"
+
+func sequence(g Generator, n int) []string {
+	out := make([]string, 0, 2*n)
+	for i := 0; i < n; i++ {
+		u, _ := g.UUID()
+		l, _ := g.ULID()
+		out = append(out, u, l)
+	}
+	return out
+}
+
+func TestDeterministic_Repeatable(t *testing.T) {
+	a := sequence(NewDeterministic(42), 50)
+	b := sequence(NewDeterministic(42), 50)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("sequence diverged at %d: %q != %q", i, a[i], b[i])
+		}
+	}
+
+	c := sequence(NewDeterministic(7), 50)
+	if a[0] == c[0] {
+		t.Fatalf("different seeds produced the same first id %q", a[0])
+	}
+}
+
+func TestDeterministic_ValidIDs(t *testing.T) {
+	g := NewDeterministic(1)
+	prev := ""
+	for i := 0; i < 100; i++ {
+		u, err := g.UUID()
+		if err != nil || !IsUUIDVersion(u, 4) {
+			t.Fatalf("invalid uuid %q: %v", u, err)
+		}
+		l, err := g.ULID()
+		if err != nil || !IsULID(l) {
+			t.Fatalf("invalid ulid %q: %v", l, err)
+		}
+		if l <= prev {
+			t.Fatalf("ulids not ordered: %q then %q", prev, l)
+		}
+		prev = l
+	}
+}
+
+func TestDefaultGenerator(t *testing.T) {
+	if _, ok := Default().(cryptoGenerator); !ok {
+		t.Fatalf("want crypto generator by default, got %T", Default())
+	}
+
+	g := NewDeterministic(3)
+	SetDefault(g)
+	defer SetDefault(nil)
+	if Default() != g {
+		t.Fatalf("SetDefault did not replace the generator")
+	}
+
+	SetDefault(nil)
+	u, err := Default().UUID()
+	if err != nil || !IsUUID(u) {
+		t.Fatalf("crypto default invalid: %q %v", u, err)
+	}
+	l, err := NewCrypto().ULID()
+	if err != nil || !IsULID(l) {
+		t.Fatalf("crypto ulid invalid: %q %v", l, err)
+	}
+}
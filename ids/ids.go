@@ -14,11 +14,15 @@ func NewUUID() (string, error) {
 	if _, err := rand.Read(b[:]); err != nil {
 		return "", fmt.Errorf("uuid: rand: %w", err)
 	}
-	// Set version (4) and variant (10xx)
+	return formatUUIDv4(b), nil
+}
+
+// formatUUIDv4 sets the version (4) and variant (10xx) bits and formats b.
+func formatUUIDv4(b [16]byte) string {
 	b[6] = (b[6] & 0x0f) | 0x40
 	b[8] = (b[8] & 0x3f) | 0x80
 	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x",
-		b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+		b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
 }
 
 // MustUUID generates a UUID v4 or panics.
@@ -30,9 +34,42 @@ func MustUUID() string {
 	return s
 }
 
-// IsUUID reports whether s looks like a valid UUID v4 string.
+// NewUUIDv7 generates a time-ordered UUID v7 (RFC 9562): a 48-bit Unix millisecond
+// timestamp followed by random bits, as a lowercase string with hyphens.
+func NewUUIDv7() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[6:]); err != nil {
+		return "", fmt.Errorf("uuid: rand: %w", err)
+	}
+	ms := uint64(time.Now().UnixMilli())
+	for i := 5; i >= 0; i-- {
+		b[i] = byte(ms)
+		ms >>= 8
+	}
+	// Set version (7) and variant (10xx)
+	b[6] = (b[6] & 0x0f) | 0x70
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x",
+		b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// MustUUIDv7 generates a UUID v7 or panics.
+func MustUUIDv7() string {
+	s, err := NewUUIDv7()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// IsUUID reports whether s looks like a valid UUID v4 or v7 string.
 func IsUUID(s string) bool {
-	if len(s) != 36 {
+	return IsUUIDVersion(s, 4) || IsUUIDVersion(s, 7)
+}
+
+// IsUUIDVersion reports whether s looks like a valid RFC 9562 UUID of the given version.
+func IsUUIDVersion(s string, version int) bool {
+	if len(s) != 36 || version < 1 || version > 15 {
 		return false
 	}
 	for i, c := range s {
@@ -47,8 +84,8 @@ func IsUUID(s string) bool {
 			}
 		}
 	}
-	// version 4 at pos 14 (0-based)
-	if s[14] != '4' {
+	// version at pos 14 (0-based)
+	if !strings.EqualFold(s[14:15], fmt.Sprintf("%x", version)) {
 		return false
 	}
 	// variant at pos 19 must be one of 8,9,a,b
@@ -72,10 +109,15 @@ func NewULID(t time.Time) (string, error) {
 	if _, err := rand.Read(entropy[:]); err != nil {
 		return "", fmt.Errorf("ulid: rand: %w", err)
 	}
+	return formatULID(ts, entropy), nil
+}
+
+// formatULID encodes a millisecond timestamp and 80 bits of entropy as a ULID.
+func formatULID(ms uint64, entropy [10]byte) string {
 	var buf [26]byte
-	encodeTime(ts, buf[0:10])
+	encodeTime(ms, buf[0:10])
 	encodeEntropy(entropy[:], buf[10:26])
-	return string(buf[:]), nil
+	return string(buf[:])
 }
 
 // MustULID generates a ULID for now or panics.
@@ -101,6 +143,23 @@ func IsULID(s string) bool {
 	return true
 }
 
+// ULIDTime returns the millisecond timestamp embedded in a ULID.
+func ULIDTime(s string) (time.Time, error) {
+	if !IsULID(s) {
+		return time.Time{}, fmt.Errorf("ids: invalid ulid: %s", s)
+	}
+	s = strings.ToUpper(s)
+	// The first char carries only 3 of the 48 timestamp bits.
+	if indexCrock(s[0]) > 7 {
+		return time.Time{}, fmt.Errorf("ids: ulid timestamp overflow: %s", s)
+	}
+	var ms uint64
+	for i := 0; i < 10; i++ {
+		ms = ms<<5 | uint64(indexCrock(s[i]))
+	}
+	return time.UnixMilli(int64(ms)), nil
+}
+
 func indexCrock(b byte) int {
 	switch b {
 	case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
@@ -111,8 +170,10 @@ func indexCrock(b byte) int {
 		return int(b-'J') + 18
 	case 'M', 'N':
 		return int(b-'M') + 20
-	case 'P', 'Q', 'R', 'S', 'T', 'V', 'W', 'X', 'Y', 'Z':
+	case 'P', 'Q', 'R', 'S', 'T':
 		return int(b-'P') + 22
+	case 'V', 'W', 'X', 'Y', 'Z':
+		return int(b-'V') + 27
 	default:
 		return -1
 	}
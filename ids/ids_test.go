@@ -1,6 +1,8 @@
 package ids
 
 import (
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -15,6 +17,51 @@ func TestUUID(t *testing.T) {
 	}
 }
 
+func TestUUIDv7(t *testing.T) {
+	a, err := NewUUIDv7()
+	if err != nil || !IsUUIDVersion(a, 7) || !IsUUID(a) {
+		t.Fatalf("invalid uuid v7: %v %v", a, err)
+	}
+	if IsUUIDVersion(a, 4) {
+		t.Fatalf("v7 reported as v4: %v", a)
+	}
+	if IsUUIDVersion(MustUUID(), 7) {
+		t.Fatalf("v4 reported as v7")
+	}
+
+	time.Sleep(2 * time.Millisecond)
+	b := MustUUIDv7()
+	if b <= a {
+		t.Fatalf("successive v7s not ordered: %q then %q", a, b)
+	}
+
+	// Timestamp prefix encodes the current Unix milliseconds.
+	if ms, err := strconv.ParseUint(strings.ReplaceAll(b[:13], "-", ""), 16, 64); err != nil || time.Since(time.UnixMilli(int64(ms))) > time.Minute {
+		t.Fatalf("unexpected v7 timestamp in %q: %v", b, err)
+	}
+}
+
+func TestIsUUIDVersion(t *testing.T) {
+	cases := []struct {
+		s       string
+		version int
+		want    bool
+	}{
+		{"3f2b8c1e-9d4a-4b7e-8f10-2a6c5d9e0b1f", 4, true},
+		{"3f2b8c1e-9d4a-4b7e-8f10-2a6c5d9e0b1f", 7, false},
+		{"0190b5a4-8c2e-7d3f-9a1b-2c3d4e5f6a7b", 7, true},
+		{"0190b5a4-8c2e-1d3f-9a1b-2c3d4e5f6a7b", 1, true},
+		{"0190b5a4-8c2e-7d3f-ca1b-2c3d4e5f6a7b", 7, false}, // wrong variant
+		{"0190b5a4-8c2e-7d3f-9a1b-2c3d4e5f6a7b", 0, false},
+		{"not-a-uuid", 7, false},
+	}
+	for _, c := range cases {
+		if got := IsUUIDVersion(c.s, c.version); got != c.want {
+			t.Fatalf("IsUUIDVersion(%q, %d)=%v, want %v", c.s, c.version, got, c.want)
+		}
+	}
+}
+
 func TestULID(t *testing.T) {
 	s, err := NewULID(time.Unix(0, 0))
 	if err != nil || !IsULID(s) {
@@ -25,6 +72,45 @@ func TestULID(t *testing.T) {
 	}
 }
 
+func TestIndexCrock(t *testing.T) {
+	for i := 0; i < len(crockford); i++ {
+		if got := indexCrock(crockford[i]); got != i {
+			t.Fatalf("indexCrock(%q)=%d, want %d", crockford[i], got, i)
+		}
+	}
+}
+
+func TestULIDTime(t *testing.T) {
+	for _, ts := range []time.Time{
+		time.Unix(0, 0),
+		time.Date(2024, 6, 1, 12, 30, 45, 123456789, time.UTC),
+		time.Now(),
+	} {
+		s, err := NewULID(ts)
+		if err != nil {
+			t.Fatalf("new ulid: %v", err)
+		}
+		got, err := ULIDTime(s)
+		if err != nil {
+			t.Fatalf("ULIDTime(%q): %v", s, err)
+		}
+		if want := ts.Truncate(time.Millisecond); !got.Equal(want) {
+			t.Fatalf("ULIDTime(%q)=%v, want %v", s, got, want)
+		}
+	}
+
+	s, _ := NewULID(time.UnixMilli(1700000000000))
+	if got, err := ULIDTime(strings.ToLower(s)); err != nil || got.UnixMilli() != 1700000000000 {
+		t.Fatalf("lowercase ulid: got %v, %v", got, err)
+	}
+
+	for _, bad := range []string{"", "not-a-ulid", "8ZZZZZZZZZZZZZZZZZZZZZZZZZ"} {
+		if _, err := ULIDTime(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
+
 func TestPrefixed(t *testing.T) {
 	s, err := Prefixed("user")
 	if err != nil {
@@ -0,0 +1,88 @@
+package ids
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MonotonicFactory generates strictly increasing ULIDs. Within the same millisecond it
+// increments the previous entropy instead of drawing new random bytes; if the entropy
+// would overflow, it waits for the next millisecond. If the clock moves backwards, the
+// last timestamp is reused so ordering is preserved.
+//
+// A MonotonicFactory is safe for concurrent use. The zero value is ready to use.
+type MonotonicFactory struct {
+	mu      sync.Mutex
+	lastMs  uint64
+	entropy [10]byte
+	now     func() time.Time // overridable for tests
+}
+
+// NewMonotonicFactory returns a new MonotonicFactory.
+func NewMonotonicFactory() *MonotonicFactory {
+	return &MonotonicFactory{}
+}
+
+// New returns the next ULID for the current time.
+func (f *MonotonicFactory) New() (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ms := f.nowMs()
+	if ms <= f.lastMs && f.lastMs != 0 {
+		if incrementEntropy(&f.entropy) {
+			ms = f.lastMs
+		} else {
+			// Entropy exhausted for this millisecond; wait for the clock to move on.
+			for ms <= f.lastMs {
+				time.Sleep(100 * time.Microsecond)
+				ms = f.nowMs()
+			}
+			if err := f.reseed(); err != nil {
+				return "", err
+			}
+		}
+	} else if err := f.reseed(); err != nil {
+		return "", err
+	}
+	f.lastMs = ms
+
+	return formatULID(ms, f.entropy), nil
+}
+
+// Must returns the next ULID or panics.
+func (f *MonotonicFactory) Must() string {
+	s, err := f.New()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func (f *MonotonicFactory) nowMs() uint64 {
+	now := time.Now
+	if f.now != nil {
+		now = f.now
+	}
+	return uint64(now().UnixNano() / 1e6)
+}
+
+func (f *MonotonicFactory) reseed() error {
+	if _, err := rand.Read(f.entropy[:]); err != nil {
+		return fmt.Errorf("ulid: rand: %w", err)
+	}
+	return nil
+}
+
+// incrementEntropy adds one to the 80-bit big-endian entropy, reporting false on overflow.
+func incrementEntropy(entropy *[10]byte) bool {
+	for i := len(entropy) - 1; i >= 0; i-- {
+		entropy[i]++
+		if entropy[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package ids
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMonotonicFactory_TightLoop(t *testing.T) {
+	f := NewMonotonicFactory()
+	prev := f.Must()
+	for i := 0; i < 100000; i++ {
+		next := f.Must()
+		if !IsULID(next) {
+			t.Fatalf("invalid ulid %q", next)
+		}
+		if next <= prev {
+			t.Fatalf("not strictly increasing at %d: %q <= %q", i, next, prev)
+		}
+		prev = next
+	}
+}
+
+func TestMonotonicFactory_SameMillisecond(t *testing.T) {
+	fixed := time.UnixMilli(1700000000000)
+	f := &MonotonicFactory{now: func() time.Time { return fixed }}
+
+	a, b := f.Must(), f.Must()
+	if a[:10] != b[:10] {
+		t.Fatalf("want same timestamp prefix, got %q and %q", a, b)
+	}
+	if b <= a {
+		t.Fatalf("want increasing ulids within a millisecond, got %q then %q", a, b)
+	}
+}
+
+func TestMonotonicFactory_ClockBackwards(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	f := &MonotonicFactory{now: func() time.Time { return now }}
+
+	a := f.Must()
+	now = now.Add(-time.Second)
+	if b := f.Must(); b <= a {
+		t.Fatalf("want increasing ulids after clock moved back, got %q then %q", a, b)
+	}
+}
+
+func TestMonotonicFactory_Overflow(t *testing.T) {
+	var mu sync.Mutex
+	now := time.UnixMilli(1700000000000)
+	calls := 0
+	f := &MonotonicFactory{now: func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		// Advance the clock once the factory starts waiting.
+		if calls > 2 {
+			return now.Add(time.Millisecond)
+		}
+		return now
+	}}
+
+	a := f.Must()
+	for i := range f.entropy {
+		f.entropy[i] = 0xFF
+	}
+	b := f.Must()
+	if a[:10] == b[:10] {
+		t.Fatalf("want next millisecond after overflow, got %q then %q", a, b)
+	}
+	if b <= a {
+		t.Fatalf("want increasing ulids after overflow, got %q then %q", a, b)
+	}
+}
+
+func TestMonotonicFactory_Concurrent(t *testing.T) {
+	f := NewMonotonicFactory()
+	const workers, perWorker = 8, 2000
+
+	results := make([][]string, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				results[w] = append(results[w], f.Must())
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	seen := make(map[string]struct{}, workers*perWorker)
+	for _, ids := range results {
+		if !sort.StringsAreSorted(ids) {
+			t.Fatalf("per-goroutine ulids not ordered")
+		}
+		for _, id := range ids {
+			if _, dup := seen[id]; dup {
+				t.Fatalf("duplicate ulid %q", id)
+			}
+			seen[id] = struct{}{}
+		}
+	}
+}
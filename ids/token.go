@@ -0,0 +1,49 @@
+package ids
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+const (
+	// MinTokenBytes is the minimum entropy accepted by NewToken (128 bits).
+	MinTokenBytes = 16
+	// MinTokenCrockfordChars is the minimum length accepted by NewTokenCrockford (40 bits).
+	MinTokenCrockfordChars = 8
+)
+
+// ErrWeakToken is returned when a token is requested with less than the minimum entropy.
+var ErrWeakToken = errors.New("ids: token entropy below minimum")
+
+// NewToken returns nBytes of crypto-random data encoded as unpadded URL-safe base64,
+// suitable for API keys and reset links. nBytes must be at least MinTokenBytes.
+func NewToken(nBytes int) (string, error) {
+	if nBytes < MinTokenBytes {
+		return "", fmt.Errorf("%w: %d bytes, need at least %d", ErrWeakToken, nBytes, MinTokenBytes)
+	}
+	b := make([]byte, nBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("token: rand: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// NewTokenCrockford returns a crypto-random code of nChars Crockford base32 characters
+// (5 bits each), which avoids ambiguous letters and suits codes people type in.
+// nChars must be at least MinTokenCrockfordChars.
+func NewTokenCrockford(nChars int) (string, error) {
+	if nChars < MinTokenCrockfordChars {
+		return "", fmt.Errorf("%w: %d characters, need at least %d", ErrWeakToken, nChars, MinTokenCrockfordChars)
+	}
+	b := make([]byte, nChars)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("token: rand: %w", err)
+	}
+	// 256 is a multiple of 32, so masking each byte picks characters uniformly.
+	for i := range b {
+		b[i] = crockford[b[i]&31]
+	}
+	return string(b), nil
+}
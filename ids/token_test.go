@@ -0,0 +1,76 @@
+package ids
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewToken(t *testing.T) {
+	tok, err := NewToken(32)
+	if err != nil {
+		t.Fatalf("NewToken: %v", err)
+	}
+	if len(tok) != base64.RawURLEncoding.EncodedLen(32) {
+		t.Fatalf("want %d chars, got %d (%s)", base64.RawURLEncoding.EncodedLen(32), len(tok), tok)
+	}
+	for _, c := range tok {
+		isAlnum := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+		if !isAlnum && c != '-' && c != '_' {
+			t.Fatalf("token has non URL-safe character %q: %s", c, tok)
+		}
+	}
+	b, err := base64.RawURLEncoding.DecodeString(tok)
+	if err != nil || len(b) != 32 {
+		t.Fatalf("token should decode to 32 bytes: %v %d", err, len(b))
+	}
+}
+
+func TestNewTokenCrockford(t *testing.T) {
+	code, err := NewTokenCrockford(10)
+	if err != nil {
+		t.Fatalf("NewTokenCrockford: %v", err)
+	}
+	if len(code) != 10 {
+		t.Fatalf("want 10 chars, got %q", code)
+	}
+	for i := 0; i < len(code); i++ {
+		if !strings.ContainsRune(crockford, rune(code[i])) {
+			t.Fatalf("code has non-Crockford character %q: %s", code[i], code)
+		}
+	}
+}
+
+func TestToken_MinimumEntropy(t *testing.T) {
+	if _, err := NewToken(MinTokenBytes - 1); !errors.Is(err, ErrWeakToken) {
+		t.Fatalf("want ErrWeakToken, got %v", err)
+	}
+	if _, err := NewTokenCrockford(MinTokenCrockfordChars - 1); !errors.Is(err, ErrWeakToken) {
+		t.Fatalf("want ErrWeakToken, got %v", err)
+	}
+	if _, err := NewToken(MinTokenBytes); err != nil {
+		t.Fatalf("minimum length should be accepted: %v", err)
+	}
+}
+
+func TestToken_Unique(t *testing.T) {
+	const n = 10000
+	tokens := make(map[string]struct{}, n)
+	codes := make(map[string]struct{}, n)
+	for i := 0; i < n; i++ {
+		tok, err := NewToken(MinTokenBytes)
+		if err != nil {
+			t.Fatalf("NewToken: %v", err)
+		}
+		code, err := NewTokenCrockford(16)
+		if err != nil {
+			t.Fatalf("NewTokenCrockford: %v", err)
+		}
+		tokens[tok] = struct{}{}
+		codes[code] = struct{}{}
+	}
+	if len(tokens) != n || len(codes) != n {
+		t.Fatalf("duplicates: %d unique tokens, %d unique codes of %d", len(tokens), len(codes), n)
+	}
+}
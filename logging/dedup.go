@@ -0,0 +1,259 @@
+package logging
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	chrono "core/chrono"
+)
+
+// DefaultDedupMaxKeys is the default number of distinct messages a dedup handler tracks.
+const DefaultDedupMaxKeys = 1024
+
+// DedupOption configures a handler created by NewDedupHandler.
+type DedupOption func(*dedupConfig)
+
+type dedupConfig struct {
+	maxKeys int
+	clock   chrono.Clock
+}
+
+// WithDedupMaxKeys bounds the number of distinct messages tracked at once.
+// When full, the least recently seen message is evicted and its pending summary emitted.
+func WithDedupMaxKeys(n int) DedupOption {
+	return func(c *dedupConfig) {
+		if n > 0 {
+			c.maxKeys = n
+		}
+	}
+}
+
+// WithDedupClock sets the clock used to measure windows. Defaults to chrono.Default.
+func WithDedupClock(clock chrono.Clock) DedupOption {
+	return func(c *dedupConfig) {
+		if clock != nil {
+			c.clock = clock
+		}
+	}
+}
+
+// dedupKey identifies repeated records.
+type dedupKey struct {
+	level slog.Level
+	msg   string
+}
+
+// dedupEntry tracks one message within its current window.
+type dedupEntry struct {
+	key        dedupKey
+	start      time.Time
+	suppressed int
+	handler    slog.Handler  // receives the summary, as derived when the first duplicate was seen
+	stop       chan struct{} // closed to cancel the pending summary timer
+	elem       *list.Element
+}
+
+// dedupState is shared by a dedup handler and the handlers derived from it.
+type dedupState struct {
+	mu      sync.Mutex
+	window  time.Duration
+	clock   chrono.Clock
+	maxKeys int
+	entries map[dedupKey]*dedupEntry
+	lru     *list.List // front is most recently seen
+}
+
+// dedupHandler suppresses repeated identical messages.
+type dedupHandler struct {
+	inner slog.Handler
+	state *dedupState
+}
+
+// NewDedupHandler returns a handler that rate-limits repeated messages. The first record
+// with a given message and level is passed to inner; identical records within window of
+// it are dropped. When the window expires, a summary record such as
+// "db unavailable (repeated 412 times)" with a "repeated" attribute is emitted.
+// Memory is bounded by an LRU of recently seen messages (see WithDedupMaxKeys).
+func NewDedupHandler(inner slog.Handler, window time.Duration, opts ...DedupOption) slog.Handler {
+	cfg := dedupConfig{maxKeys: DefaultDedupMaxKeys, clock: chrono.Default}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &dedupHandler{
+		inner: inner,
+		state: &dedupState{
+			window:  window,
+			clock:   cfg.clock,
+			maxKeys: cfg.maxKeys,
+			entries: make(map[dedupKey]*dedupEntry),
+			lru:     list.New(),
+		},
+	}
+}
+
+// Enabled reports whether the inner handler handles records at the given level.
+func (h *dedupHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+// Handle passes the record on unless it repeats a message seen within the window.
+func (h *dedupHandler) Handle(ctx context.Context, r slog.Record) error {
+	s := h.state
+	if s.window <= 0 {
+		return h.inner.Handle(ctx, r)
+	}
+
+	key := dedupKey{level: r.Level, msg: r.Message}
+	now := s.clock.Now()
+
+	s.mu.Lock()
+	e, ok := s.entries[key]
+	if ok && now.Sub(e.start) < s.window {
+		e.suppressed++
+		s.lru.MoveToFront(e.elem)
+		if e.suppressed == 1 {
+			e.handler = h.inner
+			e.stop = make(chan struct{})
+			go s.await(e, e.stop, s.clock.NewTimer(e.start.Add(s.window).Sub(now)))
+		}
+		s.mu.Unlock()
+		return nil
+	}
+
+	var flush []*pendingSummary
+	if ok {
+		// The window expired before the summary timer got to run
+		flush = append(flush, s.take(e))
+		e.start = now
+		s.lru.MoveToFront(e.elem)
+	} else {
+		flush = append(flush, s.insert(key, now)...)
+	}
+	s.mu.Unlock()
+
+	err := emitSummaries(flush)
+	return errors.Join(err, h.inner.Handle(ctx, r))
+}
+
+// WithAttrs returns a dedup handler sharing this handler's state.
+func (h *dedupHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &dedupHandler{inner: h.inner.WithAttrs(attrs), state: h.state}
+}
+
+// WithGroup returns a dedup handler sharing this handler's state.
+func (h *dedupHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &dedupHandler{inner: h.inner.WithGroup(name), state: h.state}
+}
+
+// Sync emits all pending summaries immediately, then syncs the inner handler.
+func (h *dedupHandler) Sync() error {
+	s := h.state
+	s.mu.Lock()
+	var flush []*pendingSummary
+	for _, e := range s.entries {
+		flush = append(flush, s.take(e))
+	}
+	s.mu.Unlock()
+
+	err := emitSummaries(flush)
+	if syncer, ok := h.inner.(Syncer); ok {
+		err = errors.Join(err, syncer.Sync())
+	}
+	return err
+}
+
+// pendingSummary is a summary taken from an entry, emitted after releasing the lock.
+type pendingSummary struct {
+	handler slog.Handler
+	key     dedupKey
+	count   int
+	at      time.Time
+}
+
+// insert tracks a new key, evicting the least recently seen one if full; callers must hold s.mu.
+func (s *dedupState) insert(key dedupKey, now time.Time) []*pendingSummary {
+	var flush []*pendingSummary
+	for s.lru.Len() >= s.maxKeys {
+		oldest := s.lru.Back().Value.(*dedupEntry)
+		flush = append(flush, s.take(oldest))
+		s.remove(oldest)
+	}
+	e := &dedupEntry{key: key, start: now}
+	e.elem = s.lru.PushFront(e)
+	s.entries[key] = e
+	return flush
+}
+
+// remove stops tracking e; callers must hold s.mu.
+func (s *dedupState) remove(e *dedupEntry) {
+	s.lru.Remove(e.elem)
+	delete(s.entries, e.key)
+}
+
+// take resets e's suppressed count and cancels its timer, returning the summary to emit
+// or nil if nothing was suppressed; callers must hold s.mu.
+func (s *dedupState) take(e *dedupEntry) *pendingSummary {
+	if e.suppressed == 0 {
+		return nil
+	}
+	p := &pendingSummary{handler: e.handler, key: e.key, count: e.suppressed, at: s.clock.Now()}
+	e.suppressed = 0
+	e.handler = nil
+	close(e.stop)
+	e.stop = nil
+	return p
+}
+
+// await emits e's summary when timer fires, unless stop is closed first.
+// The entry is then forgotten so the next occurrence starts a fresh window.
+func (s *dedupState) await(e *dedupEntry, stop chan struct{}, timer chrono.Timer) {
+	select {
+	case <-timer.C():
+	case <-stop:
+		timer.Stop()
+		return
+	}
+
+	s.mu.Lock()
+	if e.stop != stop {
+		s.mu.Unlock()
+		return
+	}
+	p := s.take(e)
+	if s.entries[e.key] == e {
+		s.remove(e)
+	}
+	s.mu.Unlock()
+
+	_ = emitSummaries([]*pendingSummary{p})
+}
+
+// emitSummaries writes one summary record per pending summary, skipping nils.
+func emitSummaries(summaries []*pendingSummary) error {
+	var errs []error
+	for _, p := range summaries {
+		if p == nil {
+			continue
+		}
+		times := "times"
+		if p.count == 1 {
+			times = "time"
+		}
+		r := slog.NewRecord(p.at, p.key.level, fmt.Sprintf("%s (repeated %d %s)", p.key.msg, p.count, times), 0)
+		r.AddAttrs(slog.Int("repeated", p.count))
+		if err := p.handler.Handle(context.Background(), r); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+var _ Syncer = (*dedupHandler)(nil)
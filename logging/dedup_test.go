@@ -0,0 +1,147 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	chrono "core/chrono"
+)
+
+// waitForRecords polls h until it has handled n records or the deadline passes
+func waitForRecords(t *testing.T, h *recordingHandler, n int) []slog.Record {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		records := h.handled()
+		if len(records) >= n || time.Now().After(deadline) {
+			return records
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func repeatedAttr(r slog.Record) (int64, bool) {
+	var count int64
+	var found bool
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == "repeated" {
+			count, found = a.Value.Int64(), true
+		}
+		return true
+	})
+	return count, found
+}
+
+func TestDedupHandler_SuppressesAndSummarizes(t *testing.T) {
+	clock := chrono.NewMockClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	rec := newRecordingHandler(slog.LevelDebug)
+	logger := New(NewDedupHandler(rec, time.Second, WithDedupClock(clock)))
+
+	for i := 0; i < 413; i++ {
+		logger.Error("db unavailable")
+	}
+	logger.Error("other failure")
+
+	records := rec.handled()
+	if len(records) != 2 || records[0].Message != "db unavailable" || records[1].Message != "other failure" {
+		t.Fatalf("expected only first occurrences, got %d records", len(records))
+	}
+
+	clock.Advance(time.Second)
+	records = waitForRecords(t, rec, 3)
+	if len(records) != 3 {
+		t.Fatalf("expected a summary after the window, got %d records", len(records))
+	}
+	summary := records[2]
+	if summary.Message != "db unavailable (repeated 412 times)" || summary.Level != slog.LevelError {
+		t.Fatalf("unexpected summary %q at %v", summary.Message, summary.Level)
+	}
+	if count, ok := repeatedAttr(summary); !ok || count != 412 {
+		t.Fatalf("expected repeated=412, got %d (%v)", count, ok)
+	}
+
+	// A new window starts with the next occurrence
+	logger.Error("db unavailable")
+	if records = rec.handled(); len(records) != 4 || records[3].Message != "db unavailable" {
+		t.Fatalf("expected the message to be emitted again, got %d records", len(records))
+	}
+}
+
+func TestDedupHandler_KeyedByLevel(t *testing.T) {
+	clock := chrono.NewMockClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	rec := newRecordingHandler(slog.LevelDebug)
+	logger := New(NewDedupHandler(rec, time.Minute, WithDedupClock(clock)))
+
+	logger.Warn("retrying")
+	logger.Error("retrying")
+	logger.Warn("retrying")
+
+	if records := rec.handled(); len(records) != 2 {
+		t.Fatalf("expected one record per level, got %d", len(records))
+	}
+}
+
+func TestDedupHandler_NoSummaryWithoutDuplicates(t *testing.T) {
+	clock := chrono.NewMockClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	rec := newRecordingHandler(slog.LevelDebug)
+	logger := New(NewDedupHandler(rec, time.Second, WithDedupClock(clock)))
+
+	logger.Info("tick")
+	clock.Advance(2 * time.Second)
+	logger.Info("tick")
+
+	records := rec.handled()
+	if len(records) != 2 || records[0].Message != "tick" || records[1].Message != "tick" {
+		t.Fatalf("expected both ticks without a summary, got %d records", len(records))
+	}
+}
+
+func TestDedupHandler_EvictionFlushesSummary(t *testing.T) {
+	clock := chrono.NewMockClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	rec := newRecordingHandler(slog.LevelDebug)
+	logger := New(NewDedupHandler(rec, time.Hour, WithDedupClock(clock), WithDedupMaxKeys(1)))
+
+	logger.Info("a")
+	logger.Info("a")
+	logger.Info("b")
+
+	records := rec.handled()
+	if len(records) != 3 || records[1].Message != "a (repeated 1 time)" || records[2].Message != "b" {
+		t.Fatalf("expected eviction to flush the summary, got %d records", len(records))
+	}
+}
+
+func TestDedupHandler_SyncFlushes(t *testing.T) {
+	clock := chrono.NewMockClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	rec := newRecordingHandler(slog.LevelDebug)
+	h := NewDedupHandler(rec, time.Hour, WithDedupClock(clock))
+	logger := New(h).With("component", "worker")
+
+	logger.Info("busy")
+	logger.Info("busy")
+	logger.Info("busy")
+	if err := logger.Sync(); err != nil {
+		t.Fatalf("unexpected sync error: %v", err)
+	}
+
+	records := rec.handled()
+	if len(records) != 2 || records[1].Message != "busy (repeated 2 times)" {
+		t.Fatalf("expected sync to flush the summary, got %d records", len(records))
+	}
+
+	// The cancelled timer must not emit a second summary
+	clock.Advance(time.Hour)
+	time.Sleep(10 * time.Millisecond)
+	if records = rec.handled(); len(records) != 2 {
+		t.Fatalf("expected no further records, got %d", len(records))
+	}
+}
+
+func TestDedupHandler_Enabled(t *testing.T) {
+	h := NewDedupHandler(newRecordingHandler(slog.LevelWarn), time.Second)
+	if h.Enabled(context.Background(), slog.LevelInfo) || !h.Enabled(context.Background(), slog.LevelWarn) {
+		t.Fatalf("expected Enabled to follow the inner handler")
+	}
+}
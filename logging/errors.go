@@ -0,0 +1,52 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strconv"
+)
+
+// ErrorKey is the attribute key used by Err.
+const ErrorKey = "error"
+
+// StackTracer is implemented by errors that record where they were created.
+// StackTrace returns program counters as captured by runtime.Callers.
+type StackTracer interface {
+	StackTrace() []uintptr
+}
+
+// Err returns an "error" attribute for err. If err or any error it wraps implements
+// StackTracer, the attribute is a group holding the message and the frames as a "stack"
+// group keyed by position; otherwise it is the error message. A nil err yields an empty
+// attribute, which handlers omit.
+func Err(err error) slog.Attr {
+	if err == nil {
+		return slog.Attr{}
+	}
+	var st StackTracer
+	if !errors.As(err, &st) {
+		return slog.String(ErrorKey, err.Error())
+	}
+	return slog.Group(ErrorKey,
+		slog.String("message", err.Error()),
+		slog.Attr{Key: "stack", Value: slog.GroupValue(stackFrames(st.StackTrace())...)},
+	)
+}
+
+// stackFrames formats program counters as "function file:line" attributes keyed "0", "1", ...
+func stackFrames(pcs []uintptr) []slog.Attr {
+	if len(pcs) == 0 {
+		return nil
+	}
+	attrs := make([]slog.Attr, 0, len(pcs))
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		attrs = append(attrs, slog.String(strconv.Itoa(len(attrs)), fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line)))
+		if !more {
+			return attrs
+		}
+	}
+}
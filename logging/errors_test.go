@@ -0,0 +1,87 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// tracedError records the stack of its creator
+type tracedError struct {
+	msg string
+	pcs []uintptr
+}
+
+func newTracedError(msg string) *tracedError {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(2, pcs)
+	return &tracedError{msg: msg, pcs: pcs[:n]}
+}
+
+func (e *tracedError) Error() string         { return e.msg }
+func (e *tracedError) StackTrace() []uintptr { return e.pcs }
+
+func TestErr_PlainError(t *testing.T) {
+	attr := Err(errors.New("boom"))
+	if attr.Key != ErrorKey || attr.Value.Kind() != slog.KindString || attr.Value.String() != "boom" {
+		t.Fatalf("unexpected attr: %v", attr)
+	}
+}
+
+func TestErr_Nil(t *testing.T) {
+	if attr := Err(nil); !attr.Equal(slog.Attr{}) {
+		t.Fatalf("want empty attr for nil error, got %v", attr)
+	}
+
+	var buf bytes.Buffer
+	NewJSON(&buf, nil).LogAttrs(context.Background(), slog.LevelError, "failed", Err(nil))
+	if strings.Contains(buf.String(), `"error"`) {
+		t.Fatalf("nil error should be omitted: %s", buf.String())
+	}
+}
+
+func TestErr_WrappedStackTrace(t *testing.T) {
+	err := fmt.Errorf("charge card: %w", newTracedError("declined"))
+	attr := Err(err)
+	if attr.Key != ErrorKey || attr.Value.Kind() != slog.KindGroup {
+		t.Fatalf("want error group, got %v", attr)
+	}
+
+	group := attr.Value.Group()
+	if len(group) != 2 || group[0].Key != "message" || group[0].Value.String() != "charge card: declined" {
+		t.Fatalf("unexpected group: %v", group)
+	}
+	if group[1].Key != "stack" || group[1].Value.Kind() != slog.KindGroup {
+		t.Fatalf("want stack group, got %v", group[1])
+	}
+	frames := group[1].Value.Group()
+	if len(frames) == 0 || frames[0].Key != "0" || !strings.Contains(frames[0].Value.String(), "TestErr_WrappedStackTrace") {
+		t.Fatalf("first frame should be the error's creator, got %v", frames)
+	}
+}
+
+func TestLoggerError_DetectsErrorArg(t *testing.T) {
+	var buf bytes.Buffer
+	NewJSON(&buf, nil).Error("payment failed", newTracedError("declined"), "order_id", "o-1")
+
+	var out map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out["order_id"] != "o-1" {
+		t.Fatalf("key/value args after the error should be kept: %v", out)
+	}
+	errGroup, ok := out["error"].(map[string]any)
+	if !ok || errGroup["message"] != "declined" {
+		t.Fatalf("want structured error, got %v", out["error"])
+	}
+	if _, ok := errGroup["stack"].(map[string]any); !ok {
+		t.Fatalf("want stack frames, got %v", errGroup["stack"])
+	}
+}
@@ -19,22 +19,32 @@ type Handler struct {
 	conn  net.Conn
 	host  string
 	level slog.Leveler
-	attrs []slog.Attr
-	group string
+	attrs []groupedAttr
+	group string // open groups joined with "_", applied to later attrs
 
 	// Configuration
 	timeout    time.Duration
 	async      bool
 	bufferSize int
 
-	// Async processing
+	// Async processing. msgChan is never closed, so late senders cannot panic; done
+	// asks the processor to drain and exit, and stopped is closed once it has.
 	msgChan chan *gelfMessage
 	done    chan struct{}
+	stopped chan struct{}
 	wg      sync.WaitGroup
 }
 
+// groupedAttr is an attribute added via WithAttrs, with the groups open at that time.
+type groupedAttr struct {
+	prefix string
+	attr   slog.Attr
+}
+
 type gelfMessage struct {
 	data map[string]any
+	// flushed, when set, marks a Sync request: it is closed once every earlier message is sent
+	flushed chan struct{}
 }
 
 // Config holds GELF handler configuration.
@@ -84,6 +94,7 @@ func New(udpAddr string, config *Config) (*Handler, error) {
 		async:      config.Async,
 		bufferSize: config.BufferSize,
 		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
 	}
 
 	if h.async {
@@ -128,19 +139,13 @@ func (h *Handler) buildGelfMessage(r slog.Record) map[string]any {
 	}
 
 	// Add handler attributes
-	for _, attr := range h.attrs {
-		key, value := h.formatAttr(attr)
-		if key != "" {
-			data[key] = value
-		}
+	for _, ga := range h.attrs {
+		addAttr(data, ga.prefix, ga.attr)
 	}
 
 	// Add record attributes
 	r.Attrs(func(attr slog.Attr) bool {
-		key, value := h.formatAttr(attr)
-		if key != "" {
-			data[key] = value
-		}
+		addAttr(data, h.group, attr)
 		return true
 	})
 
@@ -150,6 +155,12 @@ func (h *Handler) buildGelfMessage(r slog.Record) map[string]any {
 func (h *Handler) handleAsync(data map[string]any) error {
 	msg := &gelfMessage{data: data}
 
+	select {
+	case <-h.done:
+		return fmt.Errorf("GELF handler is closed")
+	default:
+	}
+
 	select {
 	case h.msgChan <- msg:
 		return nil
@@ -189,6 +200,10 @@ func (h *Handler) asyncProcessor() {
 	for {
 		select {
 		case msg := <-h.msgChan:
+			if msg.flushed != nil {
+				close(msg.flushed)
+				continue
+			}
 			if err := h.handleSync(msg.data); err != nil {
 				// In production, you might want to log this error or increment an error counter
 				// For now, we silently drop failed messages to prevent infinite loops
@@ -198,6 +213,10 @@ func (h *Handler) asyncProcessor() {
 			for {
 				select {
 				case msg := <-h.msgChan:
+					if msg.flushed != nil {
+						close(msg.flushed)
+						continue
+					}
 					h.handleSync(msg.data)
 				default:
 					return
@@ -207,15 +226,59 @@ func (h *Handler) asyncProcessor() {
 	}
 }
 
+// Sync blocks until all messages queued before the call have been sent, or the
+// handler's timeout elapses. It is a no-op for synchronous or closed handlers.
+func (h *Handler) Sync() error {
+	if h == nil || !h.async {
+		return nil
+	}
+
+	timeout := h.timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	select {
+	case <-h.done:
+		return nil
+	default:
+	}
+
+	flushed := make(chan struct{})
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case h.msgChan <- &gelfMessage{flushed: flushed}:
+	case <-h.done:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("GELF handler sync timed out")
+	}
+
+	// A marker queued while Close runs may land after the processor's final drain;
+	// stopped releases it then, as nothing is left to flush.
+	select {
+	case <-flushed:
+		return nil
+	case <-h.stopped:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("GELF handler sync timed out")
+	}
+}
+
 // WithAttrs returns a new Handler with the given attributes.
 func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
 	if h == nil {
 		return nil
 	}
 
-	newAttrs := make([]slog.Attr, len(h.attrs)+len(attrs))
+	newAttrs := make([]groupedAttr, len(h.attrs), len(h.attrs)+len(attrs))
 	copy(newAttrs, h.attrs)
-	copy(newAttrs[len(h.attrs):], attrs)
+	for _, attr := range attrs {
+		newAttrs = append(newAttrs, groupedAttr{prefix: h.group, attr: attr})
+	}
 
 	return &Handler{
 		conn:       h.conn,
@@ -228,6 +291,7 @@ func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
 		bufferSize: h.bufferSize,
 		msgChan:    h.msgChan,
 		done:       h.done,
+		stopped:    h.stopped,
 	}
 }
 
@@ -237,10 +301,7 @@ func (h *Handler) WithGroup(name string) slog.Handler {
 		return h
 	}
 
-	newGroup := name
-	if h.group != "" {
-		newGroup = h.group + "." + name
-	}
+	newGroup := joinKey(h.group, name)
 
 	return &Handler{
 		conn:       h.conn,
@@ -253,6 +314,7 @@ func (h *Handler) WithGroup(name string) slog.Handler {
 		bufferSize: h.bufferSize,
 		msgChan:    h.msgChan,
 		done:       h.done,
+		stopped:    h.stopped,
 	}
 }
 
@@ -265,9 +327,7 @@ func (h *Handler) Close() error {
 	if h.async {
 		close(h.done)
 		h.wg.Wait()
-		if h.msgChan != nil {
-			close(h.msgChan)
-		}
+		close(h.stopped)
 	}
 
 	h.mu.Lock()
@@ -282,24 +342,42 @@ func (h *Handler) Close() error {
 	return nil
 }
 
-func (h *Handler) formatAttr(attr slog.Attr) (string, any) {
-	key := attr.Key
-	if key == "" {
-		return "", nil
+// addAttr stores attr in data as a GELF additional field. Groups, whether open on the
+// handler or group-valued attrs, are flattened into the key with underscores, so
+// WithGroup("a").WithGroup("b") and slog.Group("b", ...) under "a" both yield "_a_b_key".
+func addAttr(data map[string]any, prefix string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Value.Kind() == slog.KindGroup {
+		// An empty group key inlines the group's attrs, as in slog
+		prefix = joinKey(prefix, attr.Key)
+		for _, a := range attr.Value.Group() {
+			addAttr(data, prefix, a)
+		}
+		return
 	}
-
-	// Apply group prefix
-	if h.group != "" {
-		key = h.group + "." + key
+	if attr.Key == "" {
+		return
 	}
 
+	key := joinKey(prefix, attr.Key)
 	// GELF additional fields must be prefixed with underscore
 	// but reserve standard GELF fields
 	if !isStandardGelfField(key) {
-		key = "_" + sanitizeKey(key)
+		key = "_" + key
 	}
+	data[key] = attr.Value.Any()
+}
 
-	return key, attr.Value.Any()
+// joinKey appends a sanitized key segment to prefix with an underscore.
+func joinKey(prefix, key string) string {
+	key = sanitizeKey(key)
+	if key == "" {
+		return prefix
+	}
+	if prefix == "" {
+		return key
+	}
+	return prefix + "_" + key
 }
 
 func mapLevel(level slog.Level) int {
@@ -311,8 +389,10 @@ func mapLevel(level slog.Level) int {
 		return 6 // Informational
 	case level <= slog.LevelWarn:
 		return 4 // Warning
-	default:
+	case level <= slog.LevelError:
 		return 3 // Error
+	default:
+		return 2 // Critical, e.g. logging.LevelFatal
 	}
 }
 
@@ -325,9 +405,12 @@ func isStandardGelfField(key string) bool {
 	}
 }
 
+// sanitizeKey replaces characters Graylog rejects or mangles in field names, including
+// the dots of dotted keys, with underscores.
 func sanitizeKey(s string) string {
 	s = strings.TrimSpace(s)
 	s = strings.ReplaceAll(s, " ", "_")
 	s = strings.ReplaceAll(s, "-", "_")
+	s = strings.ReplaceAll(s, ".", "_")
 	return s
 }
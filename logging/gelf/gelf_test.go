@@ -0,0 +1,152 @@
+package gelf
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestHandler returns a synchronous handler sending to a local UDP listener
+func newTestHandler(t *testing.T) (*Handler, *net.UDPConn) {
+	t.Helper()
+	pc, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = pc.Close() })
+
+	h, err := New(pc.LocalAddr().String(), &Config{Level: slog.LevelDebug, Timeout: time.Second})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { _ = h.Close() })
+	return h, pc
+}
+
+// receive reads one GELF message from pc
+func receive(t *testing.T, pc *net.UDPConn) map[string]any {
+	t.Helper()
+	buf := make([]byte, 64<<10)
+	_ = pc.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, err := pc.Read(buf)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	var msg map[string]any
+	if err := json.Unmarshal(buf[:n], &msg); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	return msg
+}
+
+func TestHandler_FlattensNestedGroups(t *testing.T) {
+	h, pc := newTestHandler(t)
+
+	logger := slog.New(h).With("service", "api").WithGroup("a").With("pre", 1).WithGroup("b")
+	logger.Info("hello", "key", "v", "dotted.key", "d")
+
+	msg := receive(t, pc)
+	want := map[string]any{
+		"_service":        "api",
+		"_a_pre":          float64(1),
+		"_a_b_key":        "v",
+		"_a_b_dotted_key": "d",
+	}
+	for k, v := range want {
+		if msg[k] != v {
+			t.Fatalf("field %s = %v, want %v (message %v)", k, msg[k], v, msg)
+		}
+	}
+	if _, ok := msg["_a_b_service"]; ok {
+		t.Fatalf("attrs added before WithGroup must not get the group prefix: %v", msg)
+	}
+}
+
+func TestHandler_FlattensGroupValuedAttrs(t *testing.T) {
+	h, pc := newTestHandler(t)
+
+	slog.New(h).WithGroup("a").Info("request",
+		slog.Group("b", slog.String("key", "v"), slog.Group("c", slog.Int("depth", 3))),
+		slog.Group("", slog.String("inline", "yes")),
+		slog.Group("empty"),
+	)
+
+	msg := receive(t, pc)
+	if msg["_a_b_key"] != "v" || msg["_a_b_c_depth"] != float64(3) {
+		t.Fatalf("group-valued attrs should be flattened: %v", msg)
+	}
+	if msg["_a_inline"] != "yes" {
+		t.Fatalf("empty-key groups should be inlined: %v", msg)
+	}
+	for k := range msg {
+		if k == "_a_b" || k == "_a_empty" {
+			t.Fatalf("groups must not be stored as opaque values: %v", msg)
+		}
+	}
+}
+
+func TestHandler_ResolvesLogValuer(t *testing.T) {
+	h, pc := newTestHandler(t)
+
+	_ = h.Handle(context.Background(), func() slog.Record {
+		r := slog.NewRecord(time.Now(), slog.LevelInfo, "user", 0)
+		r.AddAttrs(slog.Any("user", userValue{id: "u-1", name: "Ada"}))
+		return r
+	}())
+
+	msg := receive(t, pc)
+	if msg["_user_id"] != "u-1" || msg["_user_name"] != "Ada" {
+		t.Fatalf("LogValuer groups should be resolved and flattened: %v", msg)
+	}
+}
+
+type userValue struct{ id, name string }
+
+func (u userValue) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("id", u.id), slog.String("name", u.name))
+}
+
+func TestHandler_SyncAfterClose(t *testing.T) {
+	pc, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer pc.Close()
+
+	h, err := New(pc.LocalAddr().String(), &Config{Level: slog.LevelDebug, Timeout: time.Second, Async: true, BufferSize: 16})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	logger := slog.New(h)
+	logger.Info("before close")
+	if err := h.Sync(); err != nil {
+		t.Fatalf("Sync before Close: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_ = h.Sync()
+				logger.Info("racing close")
+			}
+		}()
+	}
+	if err := h.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	wg.Wait()
+
+	if err := h.Sync(); err != nil {
+		t.Fatalf("Sync after Close should be a no-op, got %v", err)
+	}
+	if err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "late", 0)); err == nil {
+		t.Fatalf("Handle after Close should report the handler is closed")
+	}
+}
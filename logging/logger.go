@@ -11,6 +11,15 @@ import (
 	ctxpkg "core/context"
 )
 
+// LevelFatal is the level used by Fatal and FatalContext, above slog.LevelError.
+const LevelFatal = slog.Level(12)
+
+// Syncer is implemented by handlers that buffer records, such as async handlers.
+// Fatal calls Sync before exiting so buffered records are not lost.
+type Syncer interface {
+	Sync() error
+}
+
 // Logger is an enterprise-grade wrapper over slog.Logger with optimized context integration.
 type Logger struct {
 	handler slog.Handler
@@ -22,7 +31,39 @@ type Logger struct {
 type Config struct {
 	Level      slog.Level
 	AddSource  bool
-	TimeFormat string // Optional: custom time format for text handlers
+	TimeFormat string     // Optional: custom time format for text handlers
+	FieldNames FieldNames // Optional: renames the built-in keys in JSON output
+}
+
+// FieldNames renames the built-in time, level, and message keys of JSON records,
+// e.g. to "@timestamp", "severity", and "message". Empty fields keep slog's defaults.
+// Only top-level keys are renamed, so attributes inside groups are left untouched.
+type FieldNames struct {
+	Time    string
+	Level   string
+	Message string
+}
+
+// replaceAttr returns a ReplaceAttr func that applies replaceLevelName and then the renames.
+func (f FieldNames) replaceAttr() func(groups []string, a slog.Attr) slog.Attr {
+	if f == (FieldNames{}) {
+		return replaceLevelName
+	}
+	return func(groups []string, a slog.Attr) slog.Attr {
+		a = replaceLevelName(groups, a)
+		if len(groups) > 0 {
+			return a
+		}
+		switch {
+		case a.Key == slog.TimeKey && f.Time != "":
+			a.Key = f.Time
+		case a.Key == slog.LevelKey && f.Level != "":
+			a.Key = f.Level
+		case a.Key == slog.MessageKey && f.Message != "":
+			a.Key = f.Message
+		}
+		return a
+	}
 }
 
 // DefaultConfig returns sensible defaults for production logging.
@@ -53,8 +94,9 @@ func NewJSON(w io.Writer, config *Config) *Logger {
 	}
 
 	opts := &slog.HandlerOptions{
-		Level:     config.Level,
-		AddSource: config.AddSource,
+		Level:       config.Level,
+		AddSource:   config.AddSource,
+		ReplaceAttr: config.FieldNames.replaceAttr(),
 	}
 
 	return New(slog.NewJSONHandler(w, opts))
@@ -70,8 +112,9 @@ func NewText(w io.Writer, config *Config) *Logger {
 	}
 
 	opts := &slog.HandlerOptions{
-		Level:     config.Level,
-		AddSource: config.AddSource,
+		Level:       config.Level,
+		AddSource:   config.AddSource,
+		ReplaceAttr: replaceLevelName,
 	}
 
 	return New(slog.NewTextHandler(w, opts))
@@ -91,6 +134,16 @@ func (l *Logger) With(args ...any) *Logger {
 	}
 }
 
+// WithLazy returns a new logger with an attribute whose value is computed by fn.
+// fn runs only when a record is emitted, so it costs nothing at disabled levels,
+// and it runs again for every record that is.
+func (l *Logger) WithLazy(key string, fn func() any) *Logger {
+	if l == nil {
+		return nil
+	}
+	return l.With(key, lazyValue(fn))
+}
+
 // WithGroup returns a new logger with the given group name.
 func (l *Logger) WithGroup(name string) *Logger {
 	if l == nil || name == "" {
@@ -118,9 +171,9 @@ func (l *Logger) LogAttrs(ctx context.Context, level slog.Level, msg string, att
 	record := slog.NewRecord(time.Now(), level, msg, 0)
 	record.AddAttrs(l.attrs...)
 
-	// Add context fields efficiently
+	// Add context fields efficiently, honoring the context field allowlist
 	if ctx != nil {
-		for k, v := range ctxpkg.Fields(ctx) {
+		for k, v := range ctxpkg.FieldsFiltered(ctx) {
 			record.AddAttrs(slog.Any(k, v))
 		}
 	}
@@ -176,7 +229,9 @@ func (l *Logger) WarnContext(ctx context.Context, msg string, args ...any) {
 	l.Log(ctx, slog.LevelWarn, msg, args...)
 }
 
-// Error logs at error level.
+// Error logs at error level. A bare error among args is logged as an Err attribute:
+//
+//	logger.Error("payment failed", err, "order_id", id)
 func (l *Logger) Error(msg string, args ...any) {
 	l.Log(context.Background(), slog.LevelError, msg, args...)
 }
@@ -186,6 +241,29 @@ func (l *Logger) ErrorContext(ctx context.Context, msg string, args ...any) {
 	l.Log(ctx, slog.LevelError, msg, args...)
 }
 
+// Fatal logs at fatal level, flushes the handler and exits the process with status 1.
+func (l *Logger) Fatal(msg string, args ...any) {
+	l.FatalContext(context.Background(), msg, args...)
+}
+
+// FatalContext logs at fatal level with context, flushes the handler and exits the process with status 1.
+func (l *Logger) FatalContext(ctx context.Context, msg string, args ...any) {
+	l.Log(ctx, LevelFatal, msg, args...)
+	_ = l.Sync()
+	exit(1)
+}
+
+// Sync flushes the underlying handler if it implements Syncer.
+func (l *Logger) Sync() error {
+	if l == nil {
+		return nil
+	}
+	if s, ok := l.handler.(Syncer); ok {
+		return s.Sync()
+	}
+	return nil
+}
+
 // Enabled reports whether the logger handles records at the given level.
 func (l *Logger) Enabled(ctx context.Context, level slog.Level) bool {
 	if l == nil {
@@ -224,6 +302,7 @@ func (l *Logger) ToSlog() *slog.Logger {
 var (
 	globalMu      sync.RWMutex
 	defaultLogger *Logger
+	exitFunc      = os.Exit
 )
 
 // SetDefault sets the global default logger.
@@ -257,6 +336,24 @@ func Default() *Logger {
 	return defaultLogger
 }
 
+// SetExitFunc sets the function Fatal calls to terminate the process.
+// Passing nil restores os.Exit. Intended for tests that exercise fatal paths.
+func SetExitFunc(fn func(code int)) {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+	if fn == nil {
+		fn = os.Exit
+	}
+	exitFunc = fn
+}
+
+func exit(code int) {
+	globalMu.RLock()
+	fn := exitFunc
+	globalMu.RUnlock()
+	fn(code)
+}
+
 // Package-level convenience functions that use the default logger
 
 // Debug logs at debug level using the default logger.
@@ -299,20 +396,68 @@ func ErrorContext(ctx context.Context, msg string, args ...any) {
 	Default().ErrorContext(ctx, msg, args...)
 }
 
+// Fatal logs at fatal level using the default logger, then exits with status 1.
+func Fatal(msg string, args ...any) {
+	Default().Fatal(msg, args...)
+}
+
+// FatalContext logs at fatal level with context using the default logger, then exits with status 1.
+func FatalContext(ctx context.Context, msg string, args ...any) {
+	Default().FatalContext(ctx, msg, args...)
+}
+
 // Helper functions
 
+// Lazy returns an attribute whose value is computed by fn when the record is handled.
+// Use it for expensive per-call values: logger.Debug("state", logging.Lazy("dump", dump)).
+func Lazy(key string, fn func() any) slog.Attr {
+	return slog.Any(key, lazyValue(fn))
+}
+
+// lazyValue defers evaluation to slog, which resolves LogValuers only for handled records.
+type lazyValue func() any
+
+func (f lazyValue) LogValue() slog.Value {
+	if f == nil {
+		return slog.AnyValue(nil)
+	}
+	return slog.AnyValue(f())
+}
+
+// replaceLevelName renders LevelFatal as "FATAL" instead of slog's "ERROR+4".
+func replaceLevelName(groups []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.LevelKey && len(groups) == 0 {
+		if level, ok := a.Value.Any().(slog.Level); ok && level == LevelFatal {
+			a.Value = slog.StringValue("FATAL")
+		}
+	}
+	return a
+}
+
+// argsToAttrs converts alternating key/value args to attributes. Like slog, it also accepts
+// slog.Attr values; a bare error in key position becomes an Err attribute.
 func argsToAttrs(args []any) []slog.Attr {
 	if len(args) == 0 {
 		return nil
 	}
 
 	attrs := make([]slog.Attr, 0, len(args)/2)
-	for i := 0; i < len(args)-1; i += 2 {
-		key, ok := args[i].(string)
-		if !ok {
-			continue
+	for i := 0; i < len(args); {
+		switch key := args[i].(type) {
+		case slog.Attr:
+			attrs = append(attrs, key)
+			i++
+		case error:
+			attrs = append(attrs, Err(key))
+			i++
+		case string:
+			if i+1 < len(args) {
+				attrs = append(attrs, slog.Any(key, args[i+1]))
+			}
+			i += 2
+		default:
+			i += 2
 		}
-		attrs = append(attrs, slog.Any(key, args[i+1]))
 	}
 
 	return attrs
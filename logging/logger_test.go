@@ -0,0 +1,221 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	ctxpkg "core/context"
+)
+
+// syncHandler records Sync calls and whether they happened after the record was handled
+type syncHandler struct {
+	slog.Handler
+	handled int
+	synced  bool
+	order   []string
+}
+
+func (h *syncHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.handled++
+	h.order = append(h.order, "handle")
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *syncHandler) Sync() error {
+	h.synced = true
+	h.order = append(h.order, "sync")
+	return nil
+}
+
+// captureExit replaces the exit func for the duration of the test and returns the recorded codes
+func captureExit(t *testing.T) *[]int {
+	t.Helper()
+	var codes []int
+	SetExitFunc(func(code int) { codes = append(codes, code) })
+	t.Cleanup(func() { SetExitFunc(nil) })
+	return &codes
+}
+
+func TestFatal_LogsSyncsAndExits(t *testing.T) {
+	codes := captureExit(t)
+
+	var buf bytes.Buffer
+	h := &syncHandler{Handler: NewJSON(&buf, DefaultConfig()).Handler()}
+	New(h).Fatal("cannot start", "port", 8080)
+
+	if len(*codes) != 1 || (*codes)[0] != 1 {
+		t.Fatalf("expected a single exit with code 1, got %v", *codes)
+	}
+	if !h.synced || len(h.order) != 2 || h.order[0] != "handle" || h.order[1] != "sync" {
+		t.Fatalf("expected record to be handled before sync, got %v", h.order)
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid JSON output %q: %v", buf.String(), err)
+	}
+	if entry["level"] != "FATAL" || entry["msg"] != "cannot start" || entry["port"] != float64(8080) {
+		t.Fatalf("unexpected entry: %v", entry)
+	}
+}
+
+func TestFatal_ExitsWhenLevelDisabled(t *testing.T) {
+	codes := captureExit(t)
+
+	var buf bytes.Buffer
+	logger := NewText(&buf, &Config{Level: LevelFatal + 1})
+	logger.FatalContext(context.Background(), "hidden")
+
+	if len(*codes) != 1 || (*codes)[0] != 1 {
+		t.Fatalf("expected exit code 1, got %v", *codes)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("expected no output, got %q", buf.String())
+	}
+}
+
+func TestFatal_PackageLevel(t *testing.T) {
+	codes := captureExit(t)
+
+	var buf bytes.Buffer
+	SetDefault(NewText(&buf, DefaultConfig()))
+	t.Cleanup(func() { SetDefault(nil) })
+
+	Fatal("shutting down")
+
+	if len(*codes) != 1 || (*codes)[0] != 1 {
+		t.Fatalf("expected exit code 1, got %v", *codes)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("level=FATAL")) {
+		t.Fatalf("expected FATAL level in output, got %q", buf.String())
+	}
+}
+
+func TestLevelFatal_AboveError(t *testing.T) {
+	if LevelFatal <= slog.LevelError {
+		t.Fatalf("expected LevelFatal above LevelError, got %v", LevelFatal)
+	}
+	logger := NewJSON(&bytes.Buffer{}, &Config{Level: slog.LevelError})
+	if !logger.Enabled(context.Background(), LevelFatal) {
+		t.Fatalf("expected fatal to be enabled at error level")
+	}
+}
+
+func TestWithLazy_EvaluatesOnlyWhenEnabled(t *testing.T) {
+	var buf bytes.Buffer
+	calls := 0
+	logger := NewJSON(&buf, &Config{Level: slog.LevelInfo}).WithLazy("dump", func() any {
+		calls++
+		return map[string]int{"items": 3}
+	})
+
+	logger.Debug("skipped")
+	if calls != 0 {
+		t.Fatalf("lazy value evaluated %d times for a disabled level", calls)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("unexpected output: %s", buf.String())
+	}
+
+	logger.Info("emitted")
+	logger.Info("emitted again")
+	if calls != 2 {
+		t.Fatalf("want one evaluation per emitted record, got %d", calls)
+	}
+
+	var out map[string]any
+	line, _, _ := bytes.Cut(buf.Bytes(), []byte("\n"))
+	if err := json.Unmarshal(line, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	dump, ok := out["dump"].(map[string]any)
+	if !ok || dump["items"] != float64(3) {
+		t.Fatalf("want resolved dump attribute, got %v", out["dump"])
+	}
+}
+
+func TestLazy_PerCallAttribute(t *testing.T) {
+	var buf bytes.Buffer
+	calls := 0
+	logger := NewJSON(&buf, &Config{Level: slog.LevelWarn})
+	fn := func() any { calls++; return "expensive" }
+
+	logger.LogAttrs(context.Background(), slog.LevelInfo, "skipped", Lazy("state", fn))
+	if calls != 0 {
+		t.Fatalf("lazy attribute evaluated for a disabled level")
+	}
+	logger.LogAttrs(context.Background(), slog.LevelWarn, "emitted", Lazy("state", fn))
+	if calls != 1 || !bytes.Contains(buf.Bytes(), []byte(`"state":"expensive"`)) {
+		t.Fatalf("calls=%d output=%s", calls, buf.String())
+	}
+}
+
+func TestLogAttrs_HonorsContextFieldAllowlist(t *testing.T) {
+	ctxpkg.SetFieldAllowlist([]string{"trace_id"})
+	defer ctxpkg.SetFieldAllowlist(nil)
+
+	var buf bytes.Buffer
+	ctx, _ := ctxpkg.New(context.Background())
+	ctx = ctxpkg.WithTrace(ctx, "t-1")
+	ctx = ctxpkg.WithUser(ctx, "u-1")
+	NewJSON(&buf, nil).InfoContext(ctx, "hello")
+
+	var out map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out["trace_id"] != "t-1" {
+		t.Fatalf("want trace_id, got %v", out)
+	}
+	if _, ok := out["user_id"]; ok {
+		t.Fatalf("user_id should be filtered out: %v", out)
+	}
+}
+
+func TestNewJSON_FieldNames(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewJSON(&buf, &Config{
+		Level:      slog.LevelInfo,
+		FieldNames: FieldNames{Time: "@timestamp", Level: "severity", Message: "message"},
+	})
+	codes := captureExit(t)
+
+	logger.WithGroup("req").Info("started", "msg", "inner")
+	logger.Fatal("down")
+	if len(*codes) != 1 {
+		t.Fatalf("expected one exit, got %v", *codes)
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 records, got %d: %s", len(lines), buf.String())
+	}
+	var first, second map[string]any
+	if err := json.Unmarshal(lines[0], &first); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if err := json.Unmarshal(lines[1], &second); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	for _, key := range []string{slog.TimeKey, slog.LevelKey, slog.MessageKey} {
+		if _, ok := first[key]; ok {
+			t.Fatalf("default key %q still present: %v", key, first)
+		}
+	}
+	if _, ok := first["@timestamp"]; !ok {
+		t.Fatalf("missing @timestamp: %v", first)
+	}
+	if first["severity"] != "INFO" || first["message"] != "started" {
+		t.Fatalf("unexpected renamed fields: %v", first)
+	}
+	if first["req.msg"] != "inner" {
+		t.Fatalf("grouped attribute was renamed: %v", first)
+	}
+	if second["severity"] != "FATAL" {
+		t.Fatalf("severity = %v, want FATAL", second["severity"])
+	}
+}
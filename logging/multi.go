@@ -0,0 +1,86 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// multiHandler fans records out to several handlers.
+type multiHandler struct {
+	handlers []slog.Handler
+}
+
+// NewMultiHandler returns a handler that forwards every record to all handlers,
+// e.g. JSON to stdout and GELF to Graylog from a single Logger. A record is passed
+// only to the handlers enabled for its level, and their errors are joined.
+// Nil handlers are ignored.
+func NewMultiHandler(handlers ...slog.Handler) slog.Handler {
+	valid := make([]slog.Handler, 0, len(handlers))
+	for _, h := range handlers {
+		if h != nil {
+			valid = append(valid, h)
+		}
+	}
+	return &multiHandler{handlers: valid}
+}
+
+// Enabled reports whether any handler handles records at the given level.
+func (m *multiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range m.handlers {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle passes a copy of the record to each enabled handler.
+func (m *multiHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, h := range m.handlers {
+		if !h.Enabled(ctx, r.Level) {
+			continue
+		}
+		if err := h.Handle(ctx, r.Clone()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs returns a multi-handler whose handlers all have attrs attached.
+func (m *multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, len(m.handlers))
+	for i, h := range m.handlers {
+		handlers[i] = h.WithAttrs(attrs)
+	}
+	return &multiHandler{handlers: handlers}
+}
+
+// WithGroup returns a multi-handler whose handlers all open the group name.
+func (m *multiHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return m
+	}
+	handlers := make([]slog.Handler, len(m.handlers))
+	for i, h := range m.handlers {
+		handlers[i] = h.WithGroup(name)
+	}
+	return &multiHandler{handlers: handlers}
+}
+
+// Sync flushes every handler that implements Syncer, joining their errors.
+func (m *multiHandler) Sync() error {
+	var errs []error
+	for _, h := range m.handlers {
+		if s, ok := h.(Syncer); ok {
+			if err := s.Sync(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+var _ Syncer = (*multiHandler)(nil)
@@ -0,0 +1,135 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingHandler captures handled records along with the attrs and groups it was derived with.
+// Derived handlers share the record list.
+type recordingHandler struct {
+	level   slog.Level
+	attrs   []slog.Attr
+	groups  []string
+	err     error
+	mu      *sync.Mutex
+	records *[]slog.Record
+}
+
+func newRecordingHandler(level slog.Level) *recordingHandler {
+	return &recordingHandler{level: level, mu: &sync.Mutex{}, records: &[]slog.Record{}}
+}
+
+// handled returns a copy of the records handled so far
+func (h *recordingHandler) handled() []slog.Record {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]slog.Record(nil), *h.records...)
+}
+
+func (h *recordingHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *recordingHandler) Handle(_ context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	*h.records = append(*h.records, r)
+	return h.err
+}
+
+func (h *recordingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	cp := *h
+	cp.attrs = append(append([]slog.Attr(nil), h.attrs...), attrs...)
+	return &cp
+}
+
+func (h *recordingHandler) WithGroup(name string) slog.Handler {
+	cp := *h
+	cp.groups = append(append([]string(nil), h.groups...), name)
+	return &cp
+}
+
+func TestMultiHandler_FansOut(t *testing.T) {
+	a := newRecordingHandler(slog.LevelDebug)
+	b := newRecordingHandler(slog.LevelDebug)
+
+	New(NewMultiHandler(a, nil, b)).Info("hello", "k", "v")
+
+	for name, h := range map[string]*recordingHandler{"a": a, "b": b} {
+		if len(*h.records) != 1 {
+			t.Fatalf("%s: expected 1 record, got %d", name, len(*h.records))
+		}
+		r := (*h.records)[0]
+		if r.Message != "hello" || r.NumAttrs() != 1 {
+			t.Fatalf("%s: unexpected record %q with %d attrs", name, r.Message, r.NumAttrs())
+		}
+	}
+}
+
+func TestMultiHandler_Enabled(t *testing.T) {
+	debug := newRecordingHandler(slog.LevelDebug)
+	errOnly := newRecordingHandler(slog.LevelError)
+	h := NewMultiHandler(debug, errOnly)
+
+	if !h.Enabled(context.Background(), slog.LevelDebug) {
+		t.Fatalf("expected debug to be enabled when any handler enables it")
+	}
+	if NewMultiHandler(errOnly).Enabled(context.Background(), slog.LevelInfo) {
+		t.Fatalf("expected info to be disabled")
+	}
+
+	New(h).Info("info only")
+	if len(*debug.records) != 1 || len(*errOnly.records) != 0 {
+		t.Fatalf("expected only the debug handler to receive the record, got %d and %d",
+			len(*debug.records), len(*errOnly.records))
+	}
+}
+
+func TestMultiHandler_AttrsAndGroups(t *testing.T) {
+	a := newRecordingHandler(slog.LevelInfo)
+	b := newRecordingHandler(slog.LevelInfo)
+
+	derived := NewMultiHandler(a, b).WithAttrs([]slog.Attr{slog.String("service", "api")}).WithGroup("http")
+	children := derived.(*multiHandler).handlers
+	if len(children) != 2 {
+		t.Fatalf("expected 2 child handlers, got %d", len(children))
+	}
+	for i, child := range children {
+		rh := child.(*recordingHandler)
+		if len(rh.attrs) != 1 || rh.attrs[0].Key != "service" {
+			t.Fatalf("child %d: expected service attr, got %v", i, rh.attrs)
+		}
+		if len(rh.groups) != 1 || rh.groups[0] != "http" {
+			t.Fatalf("child %d: expected http group, got %v", i, rh.groups)
+		}
+	}
+}
+
+func TestMultiHandler_JoinsErrors(t *testing.T) {
+	errA := errors.New("a failed")
+	errB := errors.New("b failed")
+	a := newRecordingHandler(slog.LevelInfo)
+	a.err = errA
+	b := newRecordingHandler(slog.LevelInfo)
+	b.err = errB
+
+	err := NewMultiHandler(a, b).Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0))
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Fatalf("expected both errors to be joined, got %v", err)
+	}
+}
+
+func TestMultiHandler_Sync(t *testing.T) {
+	s := &syncHandler{Handler: newRecordingHandler(slog.LevelInfo)}
+	if err := New(NewMultiHandler(newRecordingHandler(slog.LevelInfo), s)).Sync(); err != nil {
+		t.Fatalf("unexpected sync error: %v", err)
+	}
+	if !s.synced {
+		t.Fatalf("expected the syncing child to be synced")
+	}
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"context"
+
+	ctxpkg "core/context"
+)
+
+// ExemplarLabelTraceID is the exemplar label carrying the trace id.
+const ExemplarLabelTraceID = "trace_id"
+
+// ExemplarObserver is implemented by histograms that can attach exemplars to observations.
+type ExemplarObserver interface {
+	// ObserveWithExemplar adds an observation linked to the given exemplar labels
+	ObserveWithExemplar(ctx context.Context, value float64, labels Labels, exemplar Labels)
+}
+
+// ExemplarFromContext returns exemplar labels for the trace id carried in ctx, or nil if
+// there is none. A nil ctx is allowed, as it is for Histogram.Observe.
+func ExemplarFromContext(ctx context.Context) Labels {
+	if ctx == nil {
+		return nil
+	}
+	rc, ok := ctxpkg.From(ctx)
+	if !ok || rc.TraceID == "" {
+		return nil
+	}
+	return Labels{ExemplarLabelTraceID: rc.TraceID}
+}
+
+// Observe records value on hist. When hist implements ExemplarObserver and ctx carries a
+// trace id, the observation is linked to it as an exemplar.
+func Observe(ctx context.Context, hist Histogram, value float64, labels Labels) {
+	if hist == nil {
+		return
+	}
+	if eo, ok := hist.(ExemplarObserver); ok {
+		if exemplar := ExemplarFromContext(ctx); exemplar != nil {
+			eo.ObserveWithExemplar(ctx, value, labels, exemplar)
+			return
+		}
+	}
+	hist.Observe(ctx, value, labels)
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	ctxpkg "core/context"
+)
+
+// exemplarRecorder captures the exemplar of every observation.
+type exemplarRecorder struct {
+	recordingHistogram
+	exemplars []Labels
+}
+
+func (r *exemplarRecorder) ObserveWithExemplar(ctx context.Context, value float64, labels Labels, exemplar Labels) {
+	r.Observe(ctx, value, labels)
+	r.exemplars = append(r.exemplars, exemplar)
+}
+
+func TestObserve_AttachesTraceID(t *testing.T) {
+	ctx := ctxpkg.WithTrace(context.Background(), "trace-123")
+	rec := &exemplarRecorder{}
+
+	Observe(ctx, rec, 0.5, nil)
+
+	if len(rec.exemplars) != 1 || rec.exemplars[0][ExemplarLabelTraceID] != "trace-123" {
+		t.Fatalf("want trace_id exemplar, got %v", rec.exemplars)
+	}
+	if len(rec.observed()) != 1 {
+		t.Fatalf("want one observation, got %v", rec.observed())
+	}
+}
+
+func TestObserve_NoTraceID(t *testing.T) {
+	rec := &exemplarRecorder{}
+	Observe(context.Background(), rec, 0.5, nil)
+
+	if len(rec.exemplars) != 0 {
+		t.Fatalf("want no exemplar without a trace id, got %v", rec.exemplars)
+	}
+	if len(rec.observed()) != 1 {
+		t.Fatalf("want plain observation, got %v", rec.observed())
+	}
+}
+
+func TestObserve_NilContext(t *testing.T) {
+	rec := &exemplarRecorder{}
+	Observe(nil, rec, 0.5, nil)
+	NewTimer(nil, rec, nil).Stop()
+
+	if len(rec.exemplars) != 0 || len(rec.observed()) != 2 {
+		t.Fatalf("want two plain observations, got %v / %v", rec.observed(), rec.exemplars)
+	}
+}
+
+func TestTimer_UsesExemplarObserver(t *testing.T) {
+	ctx := ctxpkg.WithTrace(context.Background(), "trace-timer")
+	rec := &exemplarRecorder{}
+
+	NewTimer(ctx, rec, Labels{"route": "/"}).Stop()
+
+	if len(rec.exemplars) != 1 || rec.exemplars[0][ExemplarLabelTraceID] != "trace-timer" {
+		t.Fatalf("want timer exemplar, got %v", rec.exemplars)
+	}
+}
+
+func TestMultiHistogram_ForwardsExemplar(t *testing.T) {
+	ctx := ctxpkg.WithTrace(context.Background(), "trace-multi")
+	rec := &exemplarRecorder{}
+	plain := &recordingHistogram{}
+	multi := &multiHistogram{histograms: []Histogram{rec, plain}}
+
+	Observe(ctx, multi, 1, nil)
+
+	if len(rec.exemplars) != 1 || len(plain.observed()) != 1 {
+		t.Fatalf("want exemplar on recorder and plain observation, got %v / %v", rec.exemplars, plain.observed())
+	}
+}
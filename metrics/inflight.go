@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+)
+
+// InFlight tracks concurrently running operations on a gauge.
+type InFlight struct {
+	gauge Gauge
+}
+
+// NewInFlight returns an InFlight backed by gauge. A nil gauge makes it a no-op.
+func NewInFlight(gauge Gauge) *InFlight {
+	return &InFlight{gauge: gauge}
+}
+
+// Enter increments the gauge and returns a func that decrements it again.
+// The returned func is safe to call more than once; only the first call has effect.
+//
+//	defer inflight.Enter(ctx, labels)()
+func (f *InFlight) Enter(ctx context.Context, labels Labels) func() {
+	if f == nil || f.gauge == nil {
+		return func() {}
+	}
+	f.gauge.Inc(ctx, labels)
+	var once sync.Once
+	return func() {
+		once.Do(func() { f.gauge.Dec(ctx, labels) })
+	}
+}
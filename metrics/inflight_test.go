@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+// recordingGauge keeps one value per route label.
+type recordingGauge struct {
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+func (g *recordingGauge) Set(ctx context.Context, value float64, labels Labels) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.values == nil {
+		g.values = make(map[string]float64)
+	}
+	g.values[labels["route"]] = value
+}
+
+func (g *recordingGauge) Add(ctx context.Context, delta float64, labels Labels) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.values == nil {
+		g.values = make(map[string]float64)
+	}
+	g.values[labels["route"]] += delta
+}
+
+func (g *recordingGauge) Inc(ctx context.Context, labels Labels) { g.Add(ctx, 1, labels) }
+func (g *recordingGauge) Dec(ctx context.Context, labels Labels) { g.Add(ctx, -1, labels) }
+
+func (g *recordingGauge) value(route string) float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.values[route]
+}
+
+func TestInFlight_EnterAndLeave(t *testing.T) {
+	g := &recordingGauge{}
+	inflight := NewInFlight(g)
+	ctx := context.Background()
+	labels := Labels{"route": "/users"}
+
+	leaveA := inflight.Enter(ctx, labels)
+	leaveB := inflight.Enter(ctx, labels)
+	if got := g.value("/users"); got != 2 {
+		t.Fatalf("want 2 in flight, got %v", got)
+	}
+
+	leaveA()
+	leaveA() // second call is a no-op
+	if got := g.value("/users"); got != 1 {
+		t.Fatalf("want 1 in flight after leave, got %v", got)
+	}
+	leaveB()
+	if got := g.value("/users"); got != 0 {
+		t.Fatalf("want 0 in flight, got %v", got)
+	}
+}
+
+func TestInFlight_Defer(t *testing.T) {
+	g := &recordingGauge{}
+	inflight := NewInFlight(g)
+
+	func() {
+		defer inflight.Enter(context.Background(), nil)()
+		if got := g.value(""); got != 1 {
+			t.Fatalf("want 1 in flight inside call, got %v", got)
+		}
+	}()
+	if got := g.value(""); got != 0 {
+		t.Fatalf("want 0 in flight after return, got %v", got)
+	}
+}
+
+func TestInFlight_NilGauge(t *testing.T) {
+	NewInFlight(nil).Enter(context.Background(), nil)()
+}
@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Placeholders substituted for high-cardinality path segments by NormalizePath.
+const (
+	PlaceholderID   = ":id"
+	PlaceholderUUID = ":uuid"
+	PlaceholderULID = ":ulid"
+)
+
+var (
+	numericSegmentRegex = regexp.MustCompile(`^[0-9]+$`)
+	uuidSegmentRegex    = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	ulidSegmentRegex    = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$`)
+)
+
+// NormalizePath replaces numeric, UUID, and ULID path segments with placeholders
+// to keep route label cardinality bounded (e.g., "/users/123" becomes "/users/:id").
+func NormalizePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		switch {
+		case segment == "":
+			continue
+		case numericSegmentRegex.MatchString(segment):
+			segments[i] = PlaceholderID
+		case uuidSegmentRegex.MatchString(segment):
+			segments[i] = PlaceholderUUID
+		case ulidSegmentRegex.MatchString(segment):
+			segments[i] = PlaceholderULID
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// RouteLabels builds method and route labels for an HTTP request, normalizing the path.
+func RouteLabels(method, path string) Labels {
+	return Labels{
+		"method": method,
+		"route":  NormalizePath(path),
+	}
+}
+
+// ErrLabelMismatch is returned by MatchLabelNames when labels don't match the declared names.
+var ErrLabelMismatch = errors.New("metrics: labels do not match declared label names")
+
+// ValidateLabelNames validates declared label names: each must be a valid label key,
+// unique, and not shadow a constant label.
+func ValidateLabelNames(names []string, constLabels Labels) error {
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if err := ValidateLabels(Labels{name: ""}); err != nil {
+			return err
+		}
+		if _, dup := seen[name]; dup {
+			return fmt.Errorf("duplicate label name %q", name)
+		}
+		if _, ok := constLabels[name]; ok {
+			return fmt.Errorf("label name %q is already a constant label", name)
+		}
+		seen[name] = struct{}{}
+	}
+	return nil
+}
+
+// MatchLabelNames reports whether labels contain exactly the declared names.
+// An empty names slice accepts any labels. The returned error wraps ErrLabelMismatch
+// and lists any unexpected and missing names.
+func MatchLabelNames(names []string, labels Labels) error {
+	if len(names) == 0 {
+		return nil
+	}
+	declared := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		declared[name] = struct{}{}
+	}
+	var unexpected, missing []string
+	for key := range labels {
+		if _, ok := declared[key]; !ok {
+			unexpected = append(unexpected, key)
+		}
+	}
+	for _, name := range names {
+		if _, ok := labels[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(unexpected) == 0 && len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(unexpected)
+	var details []string
+	if len(unexpected) > 0 {
+		details = append(details, fmt.Sprintf("unexpected %q", unexpected))
+	}
+	if len(missing) > 0 {
+		details = append(details, fmt.Sprintf("missing %q", missing))
+	}
+	return fmt.Errorf("%w: %s", ErrLabelMismatch, strings.Join(details, ", "))
+}
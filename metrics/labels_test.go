@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalizePath(t *testing.T) {
+	cases := []struct {
+		path string
+		want string
+	}{
+		{"/users/123", "/users/:id"},
+		{"/users/123/orders/456", "/users/:id/orders/:id"},
+		{"/orders/3f2b8c1e-9d4a-4b7e-8f10-2a6c5d9e0b1f", "/orders/:uuid"},
+		{"/orders/3F2B8C1E-9D4A-4B7E-8F10-2A6C5D9E0B1F/items", "/orders/:uuid/items"},
+		{"/events/01ARZ3NDEKTSV4RRFFQ69G5FAV", "/events/:ulid"},
+		{"/health/live", "/health/live"},
+		{"/", "/"},
+		{"", ""},
+		{"/v1/users/", "/v1/users/"},
+	}
+	for _, c := range cases {
+		if got := NormalizePath(c.path); got != c.want {
+			t.Fatalf("NormalizePath(%q)=%q, want %q", c.path, got, c.want)
+		}
+	}
+}
+
+func TestRouteLabels(t *testing.T) {
+	labels := RouteLabels("GET", "/users/42")
+	if labels["method"] != "GET" || labels["route"] != "/users/:id" {
+		t.Fatalf("unexpected labels: %v", labels)
+	}
+	if err := ValidateLabels(labels); err != nil {
+		t.Fatalf("route labels should be valid: %v", err)
+	}
+}
+
+func TestMatchLabelNames(t *testing.T) {
+	names := []string{"method", "route"}
+
+	if err := MatchLabelNames(names, Labels{"method": "GET", "route": "/"}); err != nil {
+		t.Fatalf("exact match should pass: %v", err)
+	}
+	if err := MatchLabelNames(nil, Labels{"anything": "x"}); err != nil {
+		t.Fatalf("undeclared names should accept any labels: %v", err)
+	}
+
+	err := MatchLabelNames(names, Labels{"method": "GET", "route": "/", "user_id": "42"})
+	if !errors.Is(err, ErrLabelMismatch) || !strings.Contains(err.Error(), "user_id") {
+		t.Fatalf("want unexpected label error, got %v", err)
+	}
+
+	err = MatchLabelNames(names, Labels{"method": "GET"})
+	if !errors.Is(err, ErrLabelMismatch) || !strings.Contains(err.Error(), "missing") || !strings.Contains(err.Error(), "route") {
+		t.Fatalf("want missing label error, got %v", err)
+	}
+}
+
+func TestValidateLabelNames(t *testing.T) {
+	if err := ValidateLabelNames([]string{"method", "route"}, Labels{"service": "api"}); err != nil {
+		t.Fatalf("valid names rejected: %v", err)
+	}
+	cases := map[string][]string{
+		"invalid":   {"bad-name"},
+		"reserved":  {"__name"},
+		"duplicate": {"method", "method"},
+		"shadowed":  {"service"},
+	}
+	for name, names := range cases {
+		if err := ValidateLabelNames(names, Labels{"service": "api"}); err == nil {
+			t.Fatalf("%s: expected error for %v", name, names)
+		}
+	}
+}
+
+func TestValidateOptions_LabelNames(t *testing.T) {
+	_, err := Default().NewCounter(MetricOptions{Name: "ok_total", LabelNames: []string{"a", "a"}})
+	if err == nil {
+		t.Fatal("expected duplicate label name error from registry")
+	}
+}
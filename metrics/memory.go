@@ -0,0 +1,313 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Snapshotter is implemented by registries that can report their current values in process,
+// which is useful for debugging endpoints and tests.
+type Snapshotter interface {
+	// Snapshot returns a point-in-time copy of all recorded values.
+	Snapshot() Snapshot
+}
+
+// Sample is the value of a counter or gauge for one label set.
+type Sample struct {
+	Labels Labels
+	Value  float64
+}
+
+// HistogramSample summarizes a histogram's observations for one label set.
+type HistogramSample struct {
+	Labels Labels
+	Count  uint64
+	Sum    float64
+}
+
+// Snapshot holds recorded values keyed by metric name. Sample labels include ConstLabels,
+// and samples are ordered by label set.
+type Snapshot struct {
+	Counters   map[string][]Sample
+	Gauges     map[string][]Sample
+	Histograms map[string][]HistogramSample
+}
+
+// Counter returns the value of the named counter for labels, or 0 if nothing was recorded.
+func (s Snapshot) Counter(name string, labels Labels) float64 {
+	return findSample(s.Counters[name], labels)
+}
+
+// Gauge returns the value of the named gauge for labels, or 0 if nothing was recorded.
+func (s Snapshot) Gauge(name string, labels Labels) float64 {
+	return findSample(s.Gauges[name], labels)
+}
+
+// Histogram returns the observations of the named histogram for labels.
+func (s Snapshot) Histogram(name string, labels Labels) HistogramSample {
+	key := labelKey(labels)
+	for _, sample := range s.Histograms[name] {
+		if labelKey(sample.Labels) == key {
+			return sample
+		}
+	}
+	return HistogramSample{Labels: labels}
+}
+
+func findSample(samples []Sample, labels Labels) float64 {
+	key := labelKey(labels)
+	for _, sample := range samples {
+		if labelKey(sample.Labels) == key {
+			return sample.Value
+		}
+	}
+	return 0
+}
+
+// MemoryRegistry is a Registry that keeps values in process and exposes them via Snapshot.
+// Creating an instrument whose name is already registered with the same kind returns the
+// existing instrument. Calls whose labels don't match declared LabelNames are dropped and
+// reported via ReportLabelError.
+type MemoryRegistry struct {
+	mu         sync.Mutex
+	counters   map[string]*memoryInstrument
+	gauges     map[string]*memoryInstrument
+	histograms map[string]*memoryInstrument
+}
+
+// NewMemoryRegistry returns an empty MemoryRegistry.
+func NewMemoryRegistry() *MemoryRegistry {
+	return &MemoryRegistry{
+		counters:   make(map[string]*memoryInstrument),
+		gauges:     make(map[string]*memoryInstrument),
+		histograms: make(map[string]*memoryInstrument),
+	}
+}
+
+// NewCounter creates an in-memory counter.
+func (r *MemoryRegistry) NewCounter(opts MetricOptions) (Counter, error) {
+	m, err := r.instrument(r.counters, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &memoryCounter{m: m}, nil
+}
+
+// NewGauge creates an in-memory gauge.
+func (r *MemoryRegistry) NewGauge(opts MetricOptions) (Gauge, error) {
+	m, err := r.instrument(r.gauges, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &memoryGauge{m: m}, nil
+}
+
+// NewHistogram creates an in-memory histogram that records observation count and sum.
+func (r *MemoryRegistry) NewHistogram(opts HistogramOptions) (Histogram, error) {
+	m, err := r.instrument(r.histograms, opts.MetricOptions)
+	if err != nil {
+		return nil, err
+	}
+	return &memoryHistogram{m: m}, nil
+}
+
+// instrument returns the instrument registered under opts.Name in kind, creating it if needed.
+func (r *MemoryRegistry) instrument(kind map[string]*memoryInstrument, opts MetricOptions) (*memoryInstrument, error) {
+	if err := ValidateOptions(opts); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if m, ok := kind[opts.Name]; ok {
+		return m, nil
+	}
+	for _, other := range []map[string]*memoryInstrument{r.counters, r.gauges, r.histograms} {
+		if _, taken := other[opts.Name]; taken {
+			return nil, fmt.Errorf("metric %q is already registered as another type", opts.Name)
+		}
+	}
+	m := &memoryInstrument{
+		name:        opts.Name,
+		constLabels: opts.ConstLabels,
+		declared:    append([]string(nil), opts.LabelNames...),
+		series:      make(map[string]*memorySeries),
+	}
+	kind[opts.Name] = m
+	return m, nil
+}
+
+// Snapshot returns a copy of every recorded value.
+func (r *MemoryRegistry) Snapshot() Snapshot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	snap := Snapshot{
+		Counters:   make(map[string][]Sample, len(r.counters)),
+		Gauges:     make(map[string][]Sample, len(r.gauges)),
+		Histograms: make(map[string][]HistogramSample, len(r.histograms)),
+	}
+	for name, m := range r.counters {
+		snap.Counters[name] = m.samples()
+	}
+	for name, m := range r.gauges {
+		snap.Gauges[name] = m.samples()
+	}
+	for name, m := range r.histograms {
+		snap.Histograms[name] = m.histogramSamples()
+	}
+	return snap
+}
+
+// memoryInstrument holds the series of one metric, keyed by canonical label set.
+type memoryInstrument struct {
+	name        string
+	constLabels Labels
+	declared    []string
+
+	mu     sync.Mutex
+	series map[string]*memorySeries
+}
+
+type memorySeries struct {
+	labels Labels
+	value  float64 // counter/gauge value, or histogram sum
+	count  uint64
+}
+
+// update applies fn to the series for labels, dropping calls that don't match the declared names.
+func (m *memoryInstrument) update(labels Labels, fn func(s *memorySeries)) {
+	if err := MatchLabelNames(m.declared, labels); err != nil {
+		ReportLabelError(m.name, err)
+		return
+	}
+	merged := make(Labels, len(labels)+len(m.constLabels))
+	for k, v := range labels {
+		merged[k] = v
+	}
+	for k, v := range m.constLabels {
+		merged[k] = v
+	}
+	key := labelKey(merged)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.series[key]
+	if !ok {
+		s = &memorySeries{labels: merged}
+		m.series[key] = s
+	}
+	fn(s)
+}
+
+func (m *memoryInstrument) sortedSeries() []*memorySeries {
+	keys := make([]string, 0, len(m.series))
+	for key := range m.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	series := make([]*memorySeries, len(keys))
+	for i, key := range keys {
+		series[i] = m.series[key]
+	}
+	return series
+}
+
+func (m *memoryInstrument) samples() []Sample {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var samples []Sample
+	for _, s := range m.sortedSeries() {
+		samples = append(samples, Sample{Labels: copyLabels(s.labels), Value: s.value})
+	}
+	return samples
+}
+
+func (m *memoryInstrument) histogramSamples() []HistogramSample {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var samples []HistogramSample
+	for _, s := range m.sortedSeries() {
+		samples = append(samples, HistogramSample{Labels: copyLabels(s.labels), Count: s.count, Sum: s.value})
+	}
+	return samples
+}
+
+// labelKey returns a canonical string for a label set, independent of map order.
+func labelKey(labels Labels) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(labels[k])
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+func copyLabels(labels Labels) Labels {
+	cp := make(Labels, len(labels))
+	for k, v := range labels {
+		cp[k] = v
+	}
+	return cp
+}
+
+type memoryCounter struct {
+	m *memoryInstrument
+}
+
+func (c *memoryCounter) Inc(ctx context.Context, labels Labels) {
+	c.Add(ctx, 1, labels)
+}
+
+func (c *memoryCounter) Add(ctx context.Context, delta float64, labels Labels) {
+	if delta < 0 {
+		return
+	}
+	c.m.update(labels, func(s *memorySeries) { s.value += delta })
+}
+
+type memoryGauge struct {
+	m *memoryInstrument
+}
+
+func (g *memoryGauge) Set(ctx context.Context, value float64, labels Labels) {
+	g.m.update(labels, func(s *memorySeries) { s.value = value })
+}
+
+func (g *memoryGauge) Add(ctx context.Context, delta float64, labels Labels) {
+	g.m.update(labels, func(s *memorySeries) { s.value += delta })
+}
+
+func (g *memoryGauge) Inc(ctx context.Context, labels Labels) {
+	g.Add(ctx, 1, labels)
+}
+
+func (g *memoryGauge) Dec(ctx context.Context, labels Labels) {
+	g.Add(ctx, -1, labels)
+}
+
+type memoryHistogram struct {
+	m *memoryInstrument
+}
+
+func (h *memoryHistogram) Observe(ctx context.Context, value float64, labels Labels) {
+	h.m.update(labels, func(s *memorySeries) {
+		s.count++
+		s.value += value
+	})
+}
+
+var (
+	_ Registry    = (*MemoryRegistry)(nil)
+	_ Snapshotter = (*MemoryRegistry)(nil)
+)
@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestMemoryRegistry_CountersAndGauges(t *testing.T) {
+	reg := NewMemoryRegistry()
+	ctx := context.Background()
+
+	requests, err := reg.NewCounter(MetricOptions{Name: "requests_total", ConstLabels: Labels{"service": "api"}})
+	if err != nil {
+		t.Fatalf("NewCounter: %v", err)
+	}
+	inflight, err := reg.NewGauge(MetricOptions{Name: "in_flight"})
+	if err != nil {
+		t.Fatalf("NewGauge: %v", err)
+	}
+
+	requests.Inc(ctx, Labels{"route": "/a"})
+	requests.Add(ctx, 2, Labels{"route": "/a"})
+	requests.Inc(ctx, Labels{"route": "/b"})
+	requests.Add(ctx, -5, Labels{"route": "/b"}) // ignored
+	inflight.Set(ctx, 10, nil)
+	inflight.Inc(ctx, nil)
+	inflight.Dec(ctx, nil)
+	inflight.Add(ctx, -3, nil)
+
+	snap := reg.Snapshot()
+	if got := snap.Counter("requests_total", Labels{"route": "/a", "service": "api"}); got != 3 {
+		t.Fatalf("want /a count 3, got %v", got)
+	}
+	if got := snap.Counter("requests_total", Labels{"route": "/b", "service": "api"}); got != 1 {
+		t.Fatalf("want /b count 1, got %v", got)
+	}
+	if got := len(snap.Counters["requests_total"]); got != 2 {
+		t.Fatalf("want 2 series, got %d", got)
+	}
+	if got := snap.Gauge("in_flight", nil); got != 7 {
+		t.Fatalf("want gauge 7, got %v", got)
+	}
+
+	// Snapshots are copies and don't change as values do.
+	requests.Inc(ctx, Labels{"route": "/a"})
+	if got := snap.Counter("requests_total", Labels{"route": "/a", "service": "api"}); got != 3 {
+		t.Fatalf("snapshot changed after update: %v", got)
+	}
+}
+
+func TestMemoryRegistry_Histogram(t *testing.T) {
+	reg := NewMemoryRegistry()
+	hist, err := reg.NewHistogram(HistogramOptions{MetricOptions: MetricOptions{Name: "duration_seconds"}})
+	if err != nil {
+		t.Fatalf("NewHistogram: %v", err)
+	}
+	hist.Observe(context.Background(), 0.5, nil)
+	hist.Observe(context.Background(), 1.5, nil)
+
+	got := reg.Snapshot().Histogram("duration_seconds", nil)
+	if got.Count != 2 || got.Sum != 2 {
+		t.Fatalf("want count 2 sum 2, got %+v", got)
+	}
+}
+
+func TestMemoryRegistry_ReusesAndRejects(t *testing.T) {
+	reg := NewMemoryRegistry()
+	ctx := context.Background()
+
+	a, _ := reg.NewCounter(MetricOptions{Name: "jobs_total"})
+	b, err := reg.NewCounter(MetricOptions{Name: "jobs_total"})
+	if err != nil {
+		t.Fatalf("re-registering a counter: %v", err)
+	}
+	a.Inc(ctx, nil)
+	b.Inc(ctx, nil)
+	if got := reg.Snapshot().Counter("jobs_total", nil); got != 2 {
+		t.Fatalf("want shared counter value 2, got %v", got)
+	}
+
+	if _, err := reg.NewGauge(MetricOptions{Name: "jobs_total"}); err == nil {
+		t.Fatalf("want error registering a gauge under a counter name")
+	}
+	if _, err := reg.NewCounter(MetricOptions{Name: "bad-name"}); err == nil {
+		t.Fatalf("want error for invalid metric name")
+	}
+}
+
+func TestMemoryRegistry_DeclaredLabelNames(t *testing.T) {
+	var mu sync.Mutex
+	var reported []error
+	SetLabelErrorHandler(func(metric string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		reported = append(reported, err)
+	})
+	defer SetLabelErrorHandler(nil)
+
+	reg := NewMemoryRegistry()
+	c, _ := reg.NewCounter(MetricOptions{Name: "events_total", LabelNames: []string{"kind"}})
+	c.Inc(context.Background(), Labels{"kind": "a"})
+	c.Inc(context.Background(), Labels{"kind": "a", "id": "1"})
+
+	if got := reg.Snapshot().Counter("events_total", Labels{"kind": "a"}); got != 1 {
+		t.Fatalf("want 1, got %v", got)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(reported) != 1 || !errors.Is(reported[0], ErrLabelMismatch) {
+		t.Fatalf("want one ErrLabelMismatch report, got %v", reported)
+	}
+}
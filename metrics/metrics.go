@@ -13,10 +13,11 @@ type Labels map[string]string
 
 // MetricOptions configure a metric instrument.
 type MetricOptions struct {
-	Name        string // Required: metric name (must match [a-zA-Z_:][a-zA-Z0-9_:]*
-	Help        string // Optional: human-readable description
-	Unit        string // Optional: unit of measurement (e.g., "seconds", "bytes")
-	ConstLabels Labels // Optional: labels attached to all samples
+	Name        string   // Required: metric name (must match [a-zA-Z_:][a-zA-Z0-9_:]*
+	Help        string   // Optional: human-readable description
+	Unit        string   // Optional: unit of measurement (e.g., "seconds", "bytes")
+	ConstLabels Labels   // Optional: labels attached to all samples
+	LabelNames  []string // Optional: per-call label names; when set, calls with other labels are rejected
 }
 
 // HistogramOptions configure a histogram instrument.
@@ -107,6 +108,17 @@ func ValidateLabels(labels Labels) error {
 	return nil
 }
 
+// ValidateOptions validates the metric name, constant labels and declared label names.
+func ValidateOptions(opts MetricOptions) error {
+	if err := ValidateMetricName(opts.Name); err != nil {
+		return err
+	}
+	if err := ValidateLabels(opts.ConstLabels); err != nil {
+		return err
+	}
+	return ValidateLabelNames(opts.LabelNames, opts.ConstLabels)
+}
+
 // NewTimer creates a timer that will record elapsed time to the given histogram.
 func NewTimer(ctx context.Context, hist Histogram, labels Labels) *Timer {
 	return &Timer{
@@ -117,17 +129,26 @@ func NewTimer(ctx context.Context, hist Histogram, labels Labels) *Timer {
 	}
 }
 
-// Stop stops the timer and records the elapsed time to the histogram.
-func (t *Timer) Stop() {
-	if t.hist != nil {
-		elapsed := time.Since(t.start).Seconds()
-		t.hist.Observe(t.ctx, elapsed, t.labels)
-	}
+// Stop stops the timer, records the elapsed time to the histogram and returns it.
+// The trace id in the timer's context is attached as an exemplar when the histogram supports it.
+func (t *Timer) Stop() time.Duration {
+	elapsed := time.Since(t.start)
+	Observe(t.ctx, t.hist, elapsed.Seconds(), t.labels)
+	return elapsed
 }
 
 // ObserveDuration is a convenience function to time a function call.
-func ObserveDuration(ctx context.Context, hist Histogram, labels Labels, fn func()) {
+// It returns the elapsed time that was recorded.
+func ObserveDuration(ctx context.Context, hist Histogram, labels Labels, fn func()) time.Duration {
 	timer := NewTimer(ctx, hist, labels)
-	defer timer.Stop()
 	fn()
+	return timer.Stop()
+}
+
+// ObserveDurationErr times a fallible function call. The duration is recorded whether
+// or not fn fails, and both the elapsed time and fn's error are returned.
+func ObserveDurationErr(ctx context.Context, hist Histogram, labels Labels, fn func() error) (time.Duration, error) {
+	timer := NewTimer(ctx, hist, labels)
+	err := fn()
+	return timer.Stop(), err
 }
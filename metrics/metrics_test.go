@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingHistogram keeps every observed value.
+type recordingHistogram struct {
+	mu     sync.Mutex
+	values []float64
+}
+
+func (h *recordingHistogram) Observe(ctx context.Context, value float64, labels Labels) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.values = append(h.values, value)
+}
+
+func (h *recordingHistogram) observed() []float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]float64(nil), h.values...)
+}
+
+func TestTimer_StopReturnsElapsed(t *testing.T) {
+	h := &recordingHistogram{}
+	timer := NewTimer(context.Background(), h, nil)
+	time.Sleep(time.Millisecond)
+	elapsed := timer.Stop()
+
+	if elapsed <= 0 {
+		t.Fatalf("want positive duration, got %v", elapsed)
+	}
+	values := h.observed()
+	if len(values) != 1 || values[0] != elapsed.Seconds() {
+		t.Fatalf("want recorded %v, got %v", elapsed.Seconds(), values)
+	}
+}
+
+func TestTimer_NilHistogram(t *testing.T) {
+	if elapsed := NewTimer(context.Background(), nil, nil).Stop(); elapsed < 0 {
+		t.Fatalf("want non-negative duration, got %v", elapsed)
+	}
+}
+
+func TestObserveDuration(t *testing.T) {
+	h := &recordingHistogram{}
+	elapsed := ObserveDuration(context.Background(), h, nil, func() {
+		time.Sleep(time.Millisecond)
+	})
+	if elapsed < time.Millisecond {
+		t.Fatalf("want at least 1ms, got %v", elapsed)
+	}
+	if len(h.observed()) != 1 {
+		t.Fatalf("want one observation, got %v", h.observed())
+	}
+}
+
+func TestObserveDurationErr(t *testing.T) {
+	h := &recordingHistogram{}
+	wantErr := errors.New("boom")
+
+	elapsed, err := ObserveDurationErr(context.Background(), h, nil, func() error {
+		time.Sleep(time.Millisecond)
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("want %v, got %v", wantErr, err)
+	}
+	if elapsed <= 0 {
+		t.Fatalf("want positive duration, got %v", elapsed)
+	}
+
+	elapsed, err = ObserveDurationErr(context.Background(), h, nil, func() error { return nil })
+	if err != nil || elapsed <= 0 {
+		t.Fatalf("want positive duration and nil error, got %v, %v", elapsed, err)
+	}
+	if len(h.observed()) != 2 {
+		t.Fatalf("want both calls recorded, got %v", h.observed())
+	}
+}
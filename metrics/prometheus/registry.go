@@ -0,0 +1,296 @@
+// Package prometheus implements metrics.Registry on top of the Prometheus client library.
+package prometheus
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"unicode/utf8"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+
+	"core/metrics"
+)
+
+// Registry is a metrics.Registry backed by a prometheus.Registerer.
+//
+// Prometheus requires label names to be fixed per metric, while metrics.Labels are supplied
+// per call. Instruments with declared LabelNames register their collector immediately;
+// others register on first use, taking their label names from that call. Calls whose labels
+// don't match are dropped and reported via metrics.ReportLabelError; so is a failed lazy
+// registration (e.g. the name is taken by a collector of another type), once, after which
+// the instrument records nothing.
+//
+// MetricOptions.Unit is ignored: Prometheus expects the unit as a name suffix such as
+// "_seconds", so include it in Name.
+type Registry struct {
+	reg prom.Registerer
+}
+
+// New returns a Registry that registers collectors with reg.
+// If reg is nil, prometheus.DefaultRegisterer is used.
+func New(reg prom.Registerer) *Registry {
+	if reg == nil {
+		reg = prom.DefaultRegisterer
+	}
+	return &Registry{reg: reg}
+}
+
+// NewCounter creates a counter backed by a prometheus.CounterVec.
+func (r *Registry) NewCounter(opts metrics.MetricOptions) (metrics.Counter, error) {
+	if err := metrics.ValidateOptions(opts); err != nil {
+		return nil, err
+	}
+	vec, err := newLazyVec(r.reg, opts, func(labelNames []string) *prom.CounterVec {
+		return prom.NewCounterVec(prom.CounterOpts{
+			Name:        opts.Name,
+			Help:        opts.Help,
+			ConstLabels: prom.Labels(opts.ConstLabels),
+		}, labelNames)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &counter{vec: vec}, nil
+}
+
+// NewGauge creates a gauge backed by a prometheus.GaugeVec.
+func (r *Registry) NewGauge(opts metrics.MetricOptions) (metrics.Gauge, error) {
+	if err := metrics.ValidateOptions(opts); err != nil {
+		return nil, err
+	}
+	vec, err := newLazyVec(r.reg, opts, func(labelNames []string) *prom.GaugeVec {
+		return prom.NewGaugeVec(prom.GaugeOpts{
+			Name:        opts.Name,
+			Help:        opts.Help,
+			ConstLabels: prom.Labels(opts.ConstLabels),
+		}, labelNames)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &gauge{vec: vec}, nil
+}
+
+// NewHistogram creates a histogram backed by a prometheus.HistogramVec.
+// Buckets default to metrics.DefaultBuckets.
+func (r *Registry) NewHistogram(opts metrics.HistogramOptions) (metrics.Histogram, error) {
+	if err := metrics.ValidateOptions(opts.MetricOptions); err != nil {
+		return nil, err
+	}
+	buckets := opts.Buckets
+	if len(buckets) == 0 {
+		buckets = metrics.DefaultBuckets
+	}
+	vec, err := newLazyVec(r.reg, opts.MetricOptions, func(labelNames []string) *prom.HistogramVec {
+		return prom.NewHistogramVec(prom.HistogramOpts{
+			Name:        opts.Name,
+			Help:        opts.Help,
+			ConstLabels: prom.Labels(opts.ConstLabels),
+			Buckets:     buckets,
+		}, labelNames)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &histogram{vec: vec}, nil
+}
+
+// lazyVec builds and registers a collector, either up front from declared label names
+// or on first use with the label names of that call.
+type lazyVec[V prom.Collector] struct {
+	reg      prom.Registerer
+	name     string
+	declared []string
+	build    func(labelNames []string) V
+
+	once sync.Once
+	vec  V
+	err  error
+	// reportOnce limits a failed lazy registration to a single report.
+	reportOnce sync.Once
+}
+
+func newLazyVec[V prom.Collector](reg prom.Registerer, opts metrics.MetricOptions, build func(labelNames []string) V) (*lazyVec[V], error) {
+	l := &lazyVec[V]{reg: reg, name: opts.Name, declared: append([]string(nil), opts.LabelNames...), build: build}
+	if len(l.declared) > 0 {
+		l.init(nil)
+		if l.err != nil {
+			return nil, l.err
+		}
+	}
+	return l, nil
+}
+
+func (l *lazyVec[V]) init(labels metrics.Labels) {
+	l.once.Do(func() {
+		names := l.declared
+		if len(names) == 0 {
+			names = labelNames(labels)
+		}
+		l.vec, l.err = register(l.reg, l.build(names))
+	})
+}
+
+// get returns the registered collector, or false if registration failed or labels
+// don't match the declared label names.
+func (l *lazyVec[V]) get(labels metrics.Labels) (V, bool) {
+	l.init(labels)
+	if l.err != nil {
+		l.reportOnce.Do(func() { l.report(l.err) })
+		return l.vec, false
+	}
+	if err := metrics.MatchLabelNames(l.declared, labels); err != nil {
+		l.report(err)
+		return l.vec, false
+	}
+	return l.vec, true
+}
+
+// report forwards a rejected call to the metrics label error handler.
+func (l *lazyVec[V]) report(err error) {
+	metrics.ReportLabelError(l.name, err)
+}
+
+// register registers c, returning the existing collector if an identical one is already registered.
+func register[V prom.Collector](reg prom.Registerer, c V) (V, error) {
+	err := reg.Register(c)
+	if err == nil {
+		return c, nil
+	}
+	var are prom.AlreadyRegisteredError
+	if errors.As(err, &are) {
+		if existing, ok := are.ExistingCollector.(V); ok {
+			return existing, nil
+		}
+	}
+	var zero V
+	return zero, err
+}
+
+func labelNames(labels metrics.Labels) []string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type counter struct {
+	vec *lazyVec[*prom.CounterVec]
+}
+
+func (c *counter) Inc(ctx context.Context, labels metrics.Labels) {
+	c.Add(ctx, 1, labels)
+}
+
+func (c *counter) Add(ctx context.Context, delta float64, labels metrics.Labels) {
+	if delta < 0 {
+		return
+	}
+	vec, ok := c.vec.get(labels)
+	if !ok {
+		return
+	}
+	m, err := vec.GetMetricWith(prom.Labels(labels))
+	if err != nil {
+		c.vec.report(err)
+		return
+	}
+	m.Add(delta)
+}
+
+type gauge struct {
+	vec *lazyVec[*prom.GaugeVec]
+}
+
+func (g *gauge) metric(labels metrics.Labels) (prom.Gauge, bool) {
+	vec, ok := g.vec.get(labels)
+	if !ok {
+		return nil, false
+	}
+	m, err := vec.GetMetricWith(prom.Labels(labels))
+	if err != nil {
+		g.vec.report(err)
+		return nil, false
+	}
+	return m, true
+}
+
+func (g *gauge) Set(ctx context.Context, value float64, labels metrics.Labels) {
+	if m, ok := g.metric(labels); ok {
+		m.Set(value)
+	}
+}
+
+func (g *gauge) Add(ctx context.Context, delta float64, labels metrics.Labels) {
+	if m, ok := g.metric(labels); ok {
+		m.Add(delta)
+	}
+}
+
+func (g *gauge) Inc(ctx context.Context, labels metrics.Labels) {
+	g.Add(ctx, 1, labels)
+}
+
+func (g *gauge) Dec(ctx context.Context, labels metrics.Labels) {
+	g.Add(ctx, -1, labels)
+}
+
+type histogram struct {
+	vec *lazyVec[*prom.HistogramVec]
+}
+
+func (h *histogram) Observe(ctx context.Context, value float64, labels metrics.Labels) {
+	if m, ok := h.metric(labels); ok {
+		m.Observe(value)
+	}
+}
+
+// ObserveWithExemplar records value with exemplar attached. Exemplars Prometheus would
+// reject (invalid UTF-8 or longer than prometheus.ExemplarMaxRunes) are dropped and the
+// value is recorded without one.
+func (h *histogram) ObserveWithExemplar(ctx context.Context, value float64, labels metrics.Labels, exemplar metrics.Labels) {
+	m, ok := h.metric(labels)
+	if !ok {
+		return
+	}
+	if eo, ok := m.(prom.ExemplarObserver); ok && validExemplar(exemplar) {
+		eo.ObserveWithExemplar(value, prom.Labels(exemplar))
+		return
+	}
+	m.Observe(value)
+}
+
+func (h *histogram) metric(labels metrics.Labels) (prom.Observer, bool) {
+	vec, ok := h.vec.get(labels)
+	if !ok {
+		return nil, false
+	}
+	m, err := vec.GetMetricWith(prom.Labels(labels))
+	if err != nil {
+		h.vec.report(err)
+		return nil, false
+	}
+	return m, true
+}
+
+func validExemplar(exemplar metrics.Labels) bool {
+	if err := metrics.ValidateLabels(exemplar); err != nil {
+		return false
+	}
+	runes := 0
+	for k, v := range exemplar {
+		if !utf8.ValidString(v) {
+			return false
+		}
+		runes += utf8.RuneCountInString(k) + utf8.RuneCountInString(v)
+	}
+	return runes <= prom.ExemplarMaxRunes
+}
+
+var _ metrics.ExemplarObserver = (*histogram)(nil)
+
+var _ metrics.Registry = (*Registry)(nil)
@@ -0,0 +1,292 @@
+package prometheus
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	ctxpkg "core/context"
+	"core/metrics"
+)
+
+func TestCounter(t *testing.T) {
+	reg := prom.NewRegistry()
+	r := New(reg)
+
+	c, err := r.NewCounter(metrics.MetricOptions{
+		Name:        "requests_total",
+		Help:        "Total requests.",
+		ConstLabels: metrics.Labels{"service": "api"},
+	})
+	if err != nil {
+		t.Fatalf("new counter: %v", err)
+	}
+	ctx := context.Background()
+	c.Inc(ctx, metrics.Labels{"method": "GET"})
+	c.Add(ctx, 2, metrics.Labels{"method": "GET"})
+	c.Add(ctx, -1, metrics.Labels{"method": "GET"}) // ignored
+	c.Inc(ctx, metrics.Labels{"method": "POST"})
+
+	want := `
+# HELP requests_total Total requests.
+# TYPE requests_total counter
+requests_total{method="GET",service="api"} 3
+requests_total{method="POST",service="api"} 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "requests_total"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGauge(t *testing.T) {
+	reg := prom.NewRegistry()
+	r := New(reg)
+
+	g, err := r.NewGauge(metrics.MetricOptions{Name: "in_flight", Help: "In-flight requests."})
+	if err != nil {
+		t.Fatalf("new gauge: %v", err)
+	}
+	ctx := context.Background()
+	g.Set(ctx, 5, nil)
+	g.Inc(ctx, nil)
+	g.Dec(ctx, nil)
+	g.Add(ctx, -2, nil)
+
+	want := `
+# HELP in_flight In-flight requests.
+# TYPE in_flight gauge
+in_flight 3
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "in_flight"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	reg := prom.NewRegistry()
+	r := New(reg)
+
+	h, err := r.NewHistogram(metrics.HistogramOptions{
+		MetricOptions: metrics.MetricOptions{Name: "latency_seconds", Help: "Latency."},
+		Buckets:       []float64{0.1, 1},
+	})
+	if err != nil {
+		t.Fatalf("new histogram: %v", err)
+	}
+	ctx := context.Background()
+	h.Observe(ctx, 0.05, metrics.Labels{"route": "/users"})
+	h.Observe(ctx, 0.5, metrics.Labels{"route": "/users"})
+
+	want := `
+# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{route="/users",le="0.1"} 1
+latency_seconds_bucket{route="/users",le="1"} 2
+latency_seconds_bucket{route="/users",le="+Inf"} 2
+latency_seconds_sum{route="/users"} 0.55
+latency_seconds_count{route="/users"} 2
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "latency_seconds"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHistogram_DefaultBuckets(t *testing.T) {
+	reg := prom.NewRegistry()
+	h, err := New(reg).NewHistogram(metrics.HistogramOptions{
+		MetricOptions: metrics.MetricOptions{Name: "default_buckets", Help: "h"},
+	})
+	if err != nil {
+		t.Fatalf("new histogram: %v", err)
+	}
+	h.Observe(context.Background(), 1, nil)
+
+	families, err := reg.Gather()
+	if err != nil || len(families) != 1 {
+		t.Fatalf("gather: %v (%d families)", err, len(families))
+	}
+	buckets := families[0].GetMetric()[0].GetHistogram().GetBucket()
+	if len(buckets) != len(metrics.DefaultBuckets) {
+		t.Fatalf("want %d buckets, got %d", len(metrics.DefaultBuckets), len(buckets))
+	}
+}
+
+func TestAlreadyRegistered(t *testing.T) {
+	reg := prom.NewRegistry()
+	opts := metrics.MetricOptions{Name: "shared_total", Help: "Shared."}
+
+	a, err := New(reg).NewCounter(opts)
+	if err != nil {
+		t.Fatalf("first counter: %v", err)
+	}
+	b, err := New(reg).NewCounter(opts)
+	if err != nil {
+		t.Fatalf("second counter: %v", err)
+	}
+	ctx := context.Background()
+	a.Inc(ctx, metrics.Labels{"k": "v"})
+	b.Inc(ctx, metrics.Labels{"k": "v"})
+
+	if got := testutil.ToFloat64(a.(*counter).vec.vec); got != 2 {
+		t.Fatalf("want shared collector with value 2, got %v", got)
+	}
+}
+
+func TestLazyRegistrationErrorReported(t *testing.T) {
+	var reported []error
+	metrics.SetLabelErrorHandler(func(metric string, err error) {
+		if metric == "clash_total" {
+			reported = append(reported, err)
+		}
+	})
+	defer metrics.SetLabelErrorHandler(nil)
+
+	reg := prom.NewRegistry()
+	r := New(reg)
+	c, err := r.NewCounter(metrics.MetricOptions{Name: "clash_total", Help: "Clash."})
+	if err != nil {
+		t.Fatalf("new counter: %v", err)
+	}
+	g, err := r.NewGauge(metrics.MetricOptions{Name: "clash_total", Help: "Clash."})
+	if err != nil {
+		t.Fatalf("new gauge: %v", err)
+	}
+
+	ctx := context.Background()
+	c.Inc(ctx, metrics.Labels{"k": "v"})
+	g.Set(ctx, 1, metrics.Labels{"k": "v"})
+	g.Set(ctx, 2, metrics.Labels{"k": "v"})
+
+	if len(reported) != 1 {
+		t.Fatalf("want registration failure reported once, got %v", reported)
+	}
+}
+
+func TestMismatchedLabelsDropped(t *testing.T) {
+	reg := prom.NewRegistry()
+	c, err := New(reg).NewCounter(metrics.MetricOptions{Name: "events_total", Help: "Events."})
+	if err != nil {
+		t.Fatalf("new counter: %v", err)
+	}
+	ctx := context.Background()
+	c.Inc(ctx, metrics.Labels{"topic": "a"})
+	c.Inc(ctx, metrics.Labels{"other": "b"})
+
+	if n := testutil.CollectAndCount(c.(*counter).vec.vec); n != 1 {
+		t.Fatalf("want 1 series, got %d", n)
+	}
+}
+
+func TestInvalidOptions(t *testing.T) {
+	r := New(prom.NewRegistry())
+	if _, err := r.NewCounter(metrics.MetricOptions{Name: "bad-name"}); err == nil {
+		t.Fatal("expected invalid metric name error")
+	}
+	if _, err := r.NewGauge(metrics.MetricOptions{Name: "ok", ConstLabels: metrics.Labels{"": "v"}}); err == nil {
+		t.Fatal("expected invalid label error")
+	}
+}
+
+func TestDeclaredLabelNames(t *testing.T) {
+	var reported []error
+	metrics.SetLabelErrorHandler(func(metric string, err error) {
+		if metric != "jobs_total" {
+			t.Errorf("unexpected metric %q", metric)
+		}
+		reported = append(reported, err)
+	})
+	defer metrics.SetLabelErrorHandler(nil)
+
+	reg := prom.NewRegistry()
+	c, err := New(reg).NewCounter(metrics.MetricOptions{
+		Name:       "jobs_total",
+		Help:       "Jobs.",
+		LabelNames: []string{"queue", "status"},
+	})
+	if err != nil {
+		t.Fatalf("new counter: %v", err)
+	}
+	ctx := context.Background()
+	c.Inc(ctx, metrics.Labels{"queue": "default", "status": "ok"})
+	c.Inc(ctx, metrics.Labels{"queue": "default", "status": "ok", "job_id": "123"}) // unexpected
+	c.Inc(ctx, metrics.Labels{"queue": "default"})                                  // missing
+
+	if len(reported) != 2 {
+		t.Fatalf("want 2 reported errors, got %d: %v", len(reported), reported)
+	}
+	for _, err := range reported {
+		if !errors.Is(err, metrics.ErrLabelMismatch) {
+			t.Fatalf("want ErrLabelMismatch, got %v", err)
+		}
+	}
+
+	want := `
+# HELP jobs_total Jobs.
+# TYPE jobs_total counter
+jobs_total{queue="default",status="ok"} 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "jobs_total"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeclaredLabelNames_RegistersEagerly(t *testing.T) {
+	reg := prom.NewRegistry()
+	r := New(reg)
+	if _, err := r.NewHistogram(metrics.HistogramOptions{
+		MetricOptions: metrics.MetricOptions{Name: "eager_seconds", Help: "h", LabelNames: []string{"route"}},
+	}); err != nil {
+		t.Fatalf("new histogram: %v", err)
+	}
+	// Same name with different label names conflicts at creation time.
+	if _, err := r.NewHistogram(metrics.HistogramOptions{
+		MetricOptions: metrics.MetricOptions{Name: "eager_seconds", Help: "h", LabelNames: []string{"method"}},
+	}); err == nil {
+		t.Fatal("expected registration conflict")
+	}
+}
+
+func TestHistogram_TraceExemplar(t *testing.T) {
+	reg := prom.NewRegistry()
+	h, err := New(reg).NewHistogram(metrics.HistogramOptions{
+		MetricOptions: metrics.MetricOptions{Name: "traced_seconds", Help: "h"},
+		Buckets:       []float64{1},
+	})
+	if err != nil {
+		t.Fatalf("new histogram: %v", err)
+	}
+	ctx := ctxpkg.WithTrace(context.Background(), "abc123")
+	metrics.Observe(ctx, h, 0.5, nil)
+
+	families, err := reg.Gather()
+	if err != nil || len(families) != 1 {
+		t.Fatalf("gather: %v (%d families)", err, len(families))
+	}
+	exemplar := families[0].GetMetric()[0].GetHistogram().GetBucket()[0].GetExemplar()
+	if exemplar == nil || len(exemplar.GetLabel()) != 1 {
+		t.Fatalf("want exemplar on first bucket, got %v", exemplar)
+	}
+	if l := exemplar.GetLabel()[0]; l.GetName() != metrics.ExemplarLabelTraceID || l.GetValue() != "abc123" {
+		t.Fatalf("unexpected exemplar label %v", l)
+	}
+}
+
+func TestHistogram_OversizedExemplarDropped(t *testing.T) {
+	reg := prom.NewRegistry()
+	h, err := New(reg).NewHistogram(metrics.HistogramOptions{
+		MetricOptions: metrics.MetricOptions{Name: "oversized_seconds", Help: "h"},
+	})
+	if err != nil {
+		t.Fatalf("new histogram: %v", err)
+	}
+	eo := h.(metrics.ExemplarObserver)
+	eo.ObserveWithExemplar(context.Background(), 0.5, nil, metrics.Labels{"trace_id": strings.Repeat("x", 200)})
+
+	if got := testutil.CollectAndCount(h.(*histogram).vec.vec); got != 1 {
+		t.Fatalf("want observation recorded without exemplar, got %d series", got)
+	}
+}
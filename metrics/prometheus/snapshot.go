@@ -0,0 +1,84 @@
+package prometheus
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// ErrNotGatherer is returned by SnapshotJSON when the registry's Registerer cannot be gathered.
+var ErrNotGatherer = errors.New("prometheus: registerer does not implement prometheus.Gatherer")
+
+// HistogramValue is the snapshot of one histogram series.
+type HistogramValue struct {
+	Count   uint64            `json:"count"`
+	Sum     float64           `json:"sum"`
+	Buckets map[string]uint64 `json:"buckets"` // cumulative counts keyed by upper bound
+}
+
+// SnapshotJSON gathers every metric in the underlying registry, not only those created
+// through r, and encodes them as {metric_name: {labels: value}} for ad-hoc inspection.
+// Series are keyed by their label set in Prometheus notation (`method="GET",service="api"`,
+// or "" without labels). Counters, gauges, and untyped metrics map to a number; histograms
+// map to a HistogramValue. It returns ErrNotGatherer if the Registerer passed to New is not
+// also a prometheus.Gatherer (prometheus.Registry and the default registerer are).
+func (r *Registry) SnapshotJSON() ([]byte, error) {
+	gatherer, ok := r.reg.(prom.Gatherer)
+	if !ok {
+		return nil, ErrNotGatherer
+	}
+	families, err := gatherer.Gather()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := make(map[string]map[string]any, len(families))
+	for _, family := range families {
+		series := make(map[string]any, len(family.GetMetric()))
+		for _, m := range family.GetMetric() {
+			key := seriesKey(m.GetLabel())
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				series[key] = m.GetCounter().GetValue()
+			case dto.MetricType_GAUGE:
+				series[key] = m.GetGauge().GetValue()
+			case dto.MetricType_UNTYPED:
+				series[key] = m.GetUntyped().GetValue()
+			case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+				series[key] = histogramValue(m.GetHistogram())
+			}
+		}
+		if len(series) > 0 {
+			snapshot[family.GetName()] = series
+		}
+	}
+	return json.Marshal(snapshot)
+}
+
+// seriesKey renders a label set in Prometheus notation, sorted by name.
+func seriesKey(labels []*dto.LabelPair) string {
+	pairs := make([]string, 0, len(labels))
+	for _, lp := range labels {
+		pairs = append(pairs, lp.GetName()+"="+strconv.Quote(lp.GetValue()))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func histogramValue(h *dto.Histogram) HistogramValue {
+	v := HistogramValue{
+		Count:   h.GetSampleCount(),
+		Sum:     h.GetSampleSum(),
+		Buckets: make(map[string]uint64, len(h.GetBucket())+1),
+	}
+	for _, b := range h.GetBucket() {
+		v.Buckets[strconv.FormatFloat(b.GetUpperBound(), 'g', -1, 64)] = b.GetCumulativeCount()
+	}
+	v.Buckets["+Inf"] = h.GetSampleCount()
+	return v
+}
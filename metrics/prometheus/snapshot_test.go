@@ -0,0 +1,69 @@
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+
+	"core/metrics"
+)
+
+func TestSnapshotJSON(t *testing.T) {
+	r := New(prom.NewRegistry())
+	ctx := context.Background()
+
+	c, _ := r.NewCounter(metrics.MetricOptions{Name: "jobs_total", Help: "Jobs.", ConstLabels: metrics.Labels{"service": "api"}})
+	g, _ := r.NewGauge(metrics.MetricOptions{Name: "queue_depth", Help: "Depth."})
+	h, _ := r.NewHistogram(metrics.HistogramOptions{
+		MetricOptions: metrics.MetricOptions{Name: "latency_seconds", Help: "Latency."},
+		Buckets:       []float64{0.1, 1},
+	})
+	c.Add(ctx, 3, metrics.Labels{"kind": "email"})
+	c.Inc(ctx, metrics.Labels{"kind": "sms"})
+	g.Set(ctx, 7, nil)
+	h.Observe(ctx, 0.05, metrics.Labels{"route": "/"})
+	h.Observe(ctx, 0.5, metrics.Labels{"route": "/"})
+	h.Observe(ctx, 5, metrics.Labels{"route": "/"})
+
+	data, err := r.SnapshotJSON()
+	if err != nil {
+		t.Fatalf("SnapshotJSON: %v", err)
+	}
+	var got struct {
+		Jobs    map[string]float64        `json:"jobs_total"`
+		Depth   map[string]float64        `json:"queue_depth"`
+		Latency map[string]HistogramValue `json:"latency_seconds"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, data)
+	}
+
+	if got.Jobs[`kind="email",service="api"`] != 3 || got.Jobs[`kind="sms",service="api"`] != 1 {
+		t.Fatalf("counter series: %v", got.Jobs)
+	}
+	if got.Depth[""] != 7 {
+		t.Fatalf("gauge series: %v", got.Depth)
+	}
+	lat := got.Latency[`route="/"`]
+	if lat.Count != 3 || lat.Sum != 5.55 {
+		t.Fatalf("histogram count/sum: %+v", lat)
+	}
+	want := map[string]uint64{"0.1": 1, "1": 2, "+Inf": 3}
+	for bound, n := range want {
+		if lat.Buckets[bound] != n {
+			t.Fatalf("bucket %s = %d, want %d (buckets %v)", bound, lat.Buckets[bound], n, lat.Buckets)
+		}
+	}
+}
+
+type registererOnly struct{ prom.Registerer }
+
+func TestSnapshotJSON_NotGatherer(t *testing.T) {
+	r := New(registererOnly{prom.NewRegistry()})
+	if _, err := r.SnapshotJSON(); !errors.Is(err, ErrNotGatherer) {
+		t.Fatalf("err = %v, want ErrNotGatherer", err)
+	}
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"context"
+	"time"
+)
+
+// REDSet bundles the rate, errors and duration instruments for one operation.
+// All three are recorded with the same labels.
+type REDSet struct {
+	requests Counter
+	errors   Counter
+	duration Histogram
+}
+
+// NewREDSet creates the instruments <name>_requests_total, <name>_errors_total and
+// <name>_duration_seconds on reg. If reg is nil, the default registry is used.
+func NewREDSet(reg Registry, name string) (*REDSet, error) {
+	if reg == nil {
+		reg = Default()
+	}
+	requests, err := reg.NewCounter(MetricOptions{
+		Name: name + "_requests_total",
+		Help: "Total number of " + name + " requests",
+		Unit: "requests",
+	})
+	if err != nil {
+		return nil, err
+	}
+	errs, err := reg.NewCounter(MetricOptions{
+		Name: name + "_errors_total",
+		Help: "Total number of failed " + name + " requests",
+		Unit: "requests",
+	})
+	if err != nil {
+		return nil, err
+	}
+	duration, err := reg.NewHistogram(HistogramOptions{MetricOptions: MetricOptions{
+		Name: name + "_duration_seconds",
+		Help: "Duration of " + name + " requests in seconds",
+		Unit: "seconds",
+	}})
+	if err != nil {
+		return nil, err
+	}
+	return &REDSet{requests: requests, errors: errs, duration: duration}, nil
+}
+
+// Observe records one request that took dur, counting it as an error if err is non-nil.
+// The trace id in ctx is attached to the duration as an exemplar when supported.
+func (s *REDSet) Observe(ctx context.Context, labels Labels, dur time.Duration, err error) {
+	if s == nil {
+		return
+	}
+	s.requests.Inc(ctx, labels)
+	if err != nil {
+		s.errors.Inc(ctx, labels)
+	}
+	Observe(ctx, s.duration, dur.Seconds(), labels)
+}
@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingCounter sums increments per route label.
+type recordingCounter struct {
+	mu     sync.Mutex
+	totals map[string]float64
+}
+
+func (c *recordingCounter) Inc(ctx context.Context, labels Labels) { c.Add(ctx, 1, labels) }
+
+func (c *recordingCounter) Add(ctx context.Context, delta float64, labels Labels) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.totals == nil {
+		c.totals = make(map[string]float64)
+	}
+	c.totals[labels["route"]] += delta
+}
+
+func (c *recordingCounter) total(route string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.totals[route]
+}
+
+// recordingRegistry hands out recording instruments keyed by metric name.
+type recordingRegistry struct {
+	counters   map[string]*recordingCounter
+	histograms map[string]*recordingHistogram
+}
+
+func newRecordingRegistry() *recordingRegistry {
+	return &recordingRegistry{
+		counters:   make(map[string]*recordingCounter),
+		histograms: make(map[string]*recordingHistogram),
+	}
+}
+
+func (r *recordingRegistry) NewCounter(opts MetricOptions) (Counter, error) {
+	c := &recordingCounter{}
+	r.counters[opts.Name] = c
+	return c, nil
+}
+
+func (r *recordingRegistry) NewGauge(opts MetricOptions) (Gauge, error) {
+	return &recordingGauge{}, nil
+}
+
+func (r *recordingRegistry) NewHistogram(opts HistogramOptions) (Histogram, error) {
+	h := &recordingHistogram{}
+	r.histograms[opts.Name] = h
+	return h, nil
+}
+
+func TestREDSet_Observe(t *testing.T) {
+	rec := newRecordingRegistry()
+	red, err := NewREDSet(Multi(Default(), rec), "http_server")
+	if err != nil {
+		t.Fatalf("NewREDSet: %v", err)
+	}
+
+	ctx := context.Background()
+	labels := Labels{"route": "/users"}
+	red.Observe(ctx, labels, 250*time.Millisecond, nil)
+	red.Observe(ctx, labels, time.Second, errors.New("boom"))
+
+	requests := rec.counters["http_server_requests_total"]
+	errs := rec.counters["http_server_errors_total"]
+	duration := rec.histograms["http_server_duration_seconds"]
+	if requests == nil || errs == nil || duration == nil {
+		t.Fatalf("want all three instruments registered, got counters=%v histograms=%v", rec.counters, rec.histograms)
+	}
+	if got := requests.total("/users"); got != 2 {
+		t.Fatalf("want 2 requests, got %v", got)
+	}
+	if got := errs.total("/users"); got != 1 {
+		t.Fatalf("want 1 error, got %v", got)
+	}
+	values := duration.observed()
+	if len(values) != 2 || values[0] != 0.25 || values[1] != 1 {
+		t.Fatalf("want durations [0.25 1], got %v", values)
+	}
+}
+
+func TestREDSet_DefaultRegistry(t *testing.T) {
+	red, err := NewREDSet(nil, "jobs")
+	if err != nil {
+		t.Fatalf("NewREDSet with noop default: %v", err)
+	}
+	red.Observe(context.Background(), nil, time.Millisecond, nil)
+}
+
+func TestREDSet_InvalidName(t *testing.T) {
+	if _, err := NewREDSet(nil, "bad-name"); err == nil {
+		t.Fatalf("want error for invalid metric name")
+	}
+}
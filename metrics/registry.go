@@ -7,8 +7,9 @@ import (
 )
 
 var (
-	globalMu        sync.RWMutex
-	defaultRegistry Registry = &noopRegistry{}
+	globalMu          sync.RWMutex
+	defaultRegistry   Registry = &noopRegistry{}
+	labelErrorHandler func(metric string, err error)
 )
 
 // SetDefault sets the global default registry.
@@ -30,6 +31,25 @@ func Default() Registry {
 	return defaultRegistry
 }
 
+// SetLabelErrorHandler sets the hook invoked when an instrument rejects a call
+// because its labels don't match the declared LabelNames. Passing nil discards these errors.
+func SetLabelErrorHandler(fn func(metric string, err error)) {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+	labelErrorHandler = fn
+}
+
+// ReportLabelError passes a rejected call's error to the configured label error handler.
+// Registry implementations call it when they drop a sample.
+func ReportLabelError(metric string, err error) {
+	globalMu.RLock()
+	fn := labelErrorHandler
+	globalMu.RUnlock()
+	if fn != nil {
+		fn(metric, err)
+	}
+}
+
 // Multi creates a registry that fans out to multiple registries.
 // If any registry fails during metric creation, the error is returned.
 func Multi(registries ...Registry) Registry {
@@ -137,34 +157,35 @@ func (m *multiHistogram) Observe(ctx context.Context, value float64, labels Labe
 	}
 }
 
+func (m *multiHistogram) ObserveWithExemplar(ctx context.Context, value float64, labels Labels, exemplar Labels) {
+	for _, h := range m.histograms {
+		if eo, ok := h.(ExemplarObserver); ok {
+			eo.ObserveWithExemplar(ctx, value, labels, exemplar)
+		} else {
+			h.Observe(ctx, value, labels)
+		}
+	}
+}
+
 // noopRegistry is a no-op implementation for when no registry is configured.
 type noopRegistry struct{}
 
 func (n *noopRegistry) NewCounter(opts MetricOptions) (Counter, error) {
-	if err := ValidateMetricName(opts.Name); err != nil {
-		return nil, err
-	}
-	if err := ValidateLabels(opts.ConstLabels); err != nil {
+	if err := ValidateOptions(opts); err != nil {
 		return nil, err
 	}
 	return &noopCounter{}, nil
 }
 
 func (n *noopRegistry) NewGauge(opts MetricOptions) (Gauge, error) {
-	if err := ValidateMetricName(opts.Name); err != nil {
-		return nil, err
-	}
-	if err := ValidateLabels(opts.ConstLabels); err != nil {
+	if err := ValidateOptions(opts); err != nil {
 		return nil, err
 	}
 	return &noopGauge{}, nil
 }
 
 func (n *noopRegistry) NewHistogram(opts HistogramOptions) (Histogram, error) {
-	if err := ValidateMetricName(opts.Name); err != nil {
-		return nil, err
-	}
-	if err := ValidateLabels(opts.ConstLabels); err != nil {
+	if err := ValidateOptions(opts.MetricOptions); err != nil {
 		return nil, err
 	}
 	return &noopHistogram{}, nil
@@ -0,0 +1,55 @@
+package metrics
+
+// WithConstLabels returns a Registry that adds labels to the ConstLabels of every
+// instrument created through it, so shared labels such as service and env need not be
+// repeated. Labels set by the caller win on conflict. Merged options are validated by
+// each New* call. If reg is nil, the current Default() registry is used.
+func WithConstLabels(reg Registry, labels Labels) Registry {
+	if reg == nil {
+		reg = Default()
+	}
+	return &scopedRegistry{reg: reg, labels: copyLabels(labels)}
+}
+
+type scopedRegistry struct {
+	reg    Registry
+	labels Labels
+}
+
+func (s *scopedRegistry) NewCounter(opts MetricOptions) (Counter, error) {
+	opts, err := s.scope(opts)
+	if err != nil {
+		return nil, err
+	}
+	return s.reg.NewCounter(opts)
+}
+
+func (s *scopedRegistry) NewGauge(opts MetricOptions) (Gauge, error) {
+	opts, err := s.scope(opts)
+	if err != nil {
+		return nil, err
+	}
+	return s.reg.NewGauge(opts)
+}
+
+func (s *scopedRegistry) NewHistogram(opts HistogramOptions) (Histogram, error) {
+	scoped, err := s.scope(opts.MetricOptions)
+	if err != nil {
+		return nil, err
+	}
+	opts.MetricOptions = scoped
+	return s.reg.NewHistogram(opts)
+}
+
+// scope merges the inherited labels into opts.ConstLabels without modifying the caller's map.
+func (s *scopedRegistry) scope(opts MetricOptions) (MetricOptions, error) {
+	merged := make(Labels, len(s.labels)+len(opts.ConstLabels))
+	for k, v := range s.labels {
+		merged[k] = v
+	}
+	for k, v := range opts.ConstLabels {
+		merged[k] = v
+	}
+	opts.ConstLabels = merged
+	return opts, ValidateOptions(opts)
+}
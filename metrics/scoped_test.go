@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWithConstLabels_Inherited(t *testing.T) {
+	mem := NewMemoryRegistry()
+	reg := WithConstLabels(mem, Labels{"service": "foo", "env": "prod"})
+	ctx := context.Background()
+
+	requests, err := reg.NewCounter(MetricOptions{Name: "requests_total"})
+	if err != nil {
+		t.Fatalf("NewCounter: %v", err)
+	}
+	queue, err := reg.NewGauge(MetricOptions{Name: "queue_depth", ConstLabels: Labels{"env": "staging", "queue": "jobs"}})
+	if err != nil {
+		t.Fatalf("NewGauge: %v", err)
+	}
+	latency, err := reg.NewHistogram(HistogramOptions{MetricOptions: MetricOptions{Name: "latency_seconds"}})
+	if err != nil {
+		t.Fatalf("NewHistogram: %v", err)
+	}
+
+	requests.Inc(ctx, Labels{"route": "/"})
+	queue.Set(ctx, 4, nil)
+	latency.Observe(ctx, 0.2, nil)
+
+	snap := mem.Snapshot()
+	if got := snap.Counter("requests_total", Labels{"route": "/", "service": "foo", "env": "prod"}); got != 1 {
+		t.Fatalf("counter should carry inherited labels, got samples %v", snap.Counters["requests_total"])
+	}
+	// Caller const labels win on conflict.
+	if got := snap.Gauge("queue_depth", Labels{"service": "foo", "env": "staging", "queue": "jobs"}); got != 4 {
+		t.Fatalf("gauge labels: %v", snap.Gauges["queue_depth"])
+	}
+	if got := snap.Histogram("latency_seconds", Labels{"service": "foo", "env": "prod"}); got.Count != 1 {
+		t.Fatalf("histogram labels: %v", snap.Histograms["latency_seconds"])
+	}
+}
+
+func TestWithConstLabels_DoesNotModifyCallerOptions(t *testing.T) {
+	reg := WithConstLabels(NewMemoryRegistry(), Labels{"service": "foo"})
+	own := Labels{"queue": "jobs"}
+	if _, err := reg.NewCounter(MetricOptions{Name: "jobs_total", ConstLabels: own}); err != nil {
+		t.Fatalf("NewCounter: %v", err)
+	}
+	if len(own) != 1 {
+		t.Fatalf("caller ConstLabels were modified: %v", own)
+	}
+}
+
+func TestWithConstLabels_ValidatesMergedLabels(t *testing.T) {
+	reg := WithConstLabels(NewMemoryRegistry(), Labels{"__service": "foo"})
+	if _, err := reg.NewCounter(MetricOptions{Name: "requests_total"}); err == nil {
+		t.Fatalf("want error for reserved inherited label")
+	}
+
+	reg = WithConstLabels(NewMemoryRegistry(), Labels{"route": "/"})
+	if _, err := reg.NewCounter(MetricOptions{Name: "requests_total", LabelNames: []string{"route"}}); err == nil {
+		t.Fatalf("want error when a declared label name shadows an inherited const label")
+	}
+}
@@ -0,0 +1,43 @@
+// Package recovery provides shared helpers for reporting recovered panics.
+package recovery
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+
+	"core/logging"
+)
+
+// maxStackSize caps the captured goroutine stack.
+const maxStackSize = 64 << 10
+
+// LogPanic logs a recovered panic value at Error level with the current goroutine stack,
+// the context fields from core/context, and any extra attributes (e.g., topic or event).
+// If l is nil, the default logger is used.
+func LogPanic(ctx context.Context, l *logging.Logger, recovered any, extra ...slog.Attr) {
+	if l == nil {
+		l = logging.Default()
+	}
+
+	attrs := make([]slog.Attr, 0, len(extra)+2)
+	attrs = append(attrs,
+		slog.String("panic", fmt.Sprint(recovered)),
+		slog.String("stack", Stack()),
+	)
+	attrs = append(attrs, extra...)
+	l.LogAttrs(ctx, slog.LevelError, "panic recovered", attrs...)
+}
+
+// Stack returns the formatted stack trace of the calling goroutine.
+func Stack() string {
+	buf := make([]byte, 4<<10)
+	for {
+		n := runtime.Stack(buf, false)
+		if n < len(buf) || len(buf) >= maxStackSize {
+			return string(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
@@ -0,0 +1,60 @@
+package recovery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	ctxpkg "core/context"
+	"core/logging"
+)
+
+func TestLogPanic(t *testing.T) {
+	var buf bytes.Buffer
+	l := logging.NewJSON(&buf, logging.DefaultConfig())
+	ctx := ctxpkg.WithRequestID(context.Background(), "req-1")
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				LogPanic(ctx, l, r, slog.String("topic", "orders"), slog.Int("attempt", 2))
+			}
+		}()
+		panic("boom")
+	}()
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("decode log record: %v (%s)", err, buf.String())
+	}
+	if record["level"] != "ERROR" || record["msg"] != "panic recovered" {
+		t.Fatalf("unexpected level/msg: %v", record)
+	}
+	if record["panic"] != "boom" {
+		t.Fatalf("panic value missing: %v", record["panic"])
+	}
+	stack, _ := record["stack"].(string)
+	if !strings.Contains(stack, "TestLogPanic") {
+		t.Fatalf("stack should include the panicking frame, got %q", stack)
+	}
+	if record["topic"] != "orders" || record["attempt"] != float64(2) {
+		t.Fatalf("extra attrs missing: %v", record)
+	}
+	if record["request_id"] != "req-1" {
+		t.Fatalf("context fields missing: %v", record)
+	}
+}
+
+func TestLogPanic_ErrorValue(t *testing.T) {
+	var buf bytes.Buffer
+	l := logging.NewJSON(&buf, logging.DefaultConfig())
+
+	LogPanic(context.Background(), l, context.Canceled)
+
+	if !strings.Contains(buf.String(), `"panic":"context canceled"`) {
+		t.Fatalf("error panic value not formatted: %s", buf.String())
+	}
+}
@@ -0,0 +1,243 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	chrono "core/chrono"
+)
+
+// ErrCircuitOpen is returned by CircuitBreaker.Execute while the breaker is open.
+// Do and DoAttempt stop retrying when an attempt returns it.
+var ErrCircuitOpen = errors.New("retry: circuit open")
+
+// State is the state of a CircuitBreaker.
+type State int
+
+const (
+	// StateClosed lets calls through and counts consecutive failures.
+	StateClosed State = iota
+	// StateOpen rejects calls with ErrCircuitOpen until the cooldown elapses.
+	StateOpen
+	// StateHalfOpen lets a single probe call through to decide whether to close again.
+	StateHalfOpen
+)
+
+// String returns a lowercase name for the state, suitable for logs and metric labels.
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// OnStateChange is called after a CircuitBreaker moves from one state to another.
+type OnStateChange func(from, to State)
+
+// BreakerOptions configures a CircuitBreaker.
+type BreakerOptions struct {
+	FailureThreshold int
+	Cooldown         time.Duration
+	IsFailure        func(err error) bool
+	OnStateChange    OnStateChange
+	Clock            chrono.Clock
+}
+
+// BreakerOption applies a mutation to BreakerOptions.
+type BreakerOption func(*BreakerOptions)
+
+// WithFailureThreshold sets how many consecutive failures open the breaker (>= 1). Default 5.
+func WithFailureThreshold(n int) BreakerOption {
+	return func(o *BreakerOptions) { o.FailureThreshold = n }
+}
+
+// WithCooldown sets how long the breaker stays open before half-opening. Default 30s.
+func WithCooldown(d time.Duration) BreakerOption { return func(o *BreakerOptions) { o.Cooldown = d } }
+
+// WithFailureIf sets a predicate deciding which errors count as failures.
+// Default: any non-nil error except context cancellation and deadline errors.
+func WithFailureIf(p func(err error) bool) BreakerOption {
+	return func(o *BreakerOptions) { o.IsFailure = p }
+}
+
+// WithOnStateChange sets a callback invoked on every state transition.
+// It runs after the breaker's lock is released, so it may call back into the breaker.
+func WithOnStateChange(cb OnStateChange) BreakerOption {
+	return func(o *BreakerOptions) { o.OnStateChange = cb }
+}
+
+// WithBreakerClock sets the clock used to time the cooldown. Default: chrono.Default.
+func WithBreakerClock(c chrono.Clock) BreakerOption { return func(o *BreakerOptions) { o.Clock = c } }
+
+// CircuitBreaker stops calls to a failing dependency. It opens after FailureThreshold
+// consecutive failures, rejects calls with ErrCircuitOpen for Cooldown, then half-opens
+// and lets one probe through: success closes it, failure opens it again.
+// It is safe for concurrent use.
+//
+// To retry within a closed breaker, call Execute from the function passed to Do;
+// retries stop as soon as the breaker opens:
+//
+//	err := retry.Do(ctx, func(ctx context.Context) error {
+//		return cb.Execute(ctx, callDependency)
+//	})
+type CircuitBreaker struct {
+	cfg BreakerOptions
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker returns a closed CircuitBreaker configured by opts.
+func NewCircuitBreaker(opts ...BreakerOption) *CircuitBreaker {
+	cfg := BreakerOptions{
+		FailureThreshold: 5,
+		Cooldown:         30 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 1
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = chrono.Default
+	}
+	return &CircuitBreaker{cfg: cfg}
+}
+
+// State returns the current state. An open breaker whose cooldown has elapsed reports
+// StateOpen until the next call half-opens it.
+func (b *CircuitBreaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Execute runs fn if the breaker allows it and records the outcome.
+// It returns ErrCircuitOpen without calling fn while the breaker is open, or while a
+// half-open probe is already in flight. Otherwise it returns fn's error.
+// A panic in fn is recorded as a failure and then re-raised.
+func (b *CircuitBreaker) Execute(ctx context.Context, fn Func) error {
+	if fn == nil {
+		return errors.New("retry: nil function")
+	}
+	if err := b.allow(); err != nil {
+		return err
+	}
+	completed := false
+	defer func() {
+		if !completed {
+			b.recordOutcome(true, false)
+		}
+	}()
+	err := fn(ctx)
+	completed = true
+	b.record(ctx, err)
+	return err
+}
+
+// allow reports whether a call may proceed, half-opening the breaker once the cooldown elapsed.
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	var changed *stateChange
+	switch b.state {
+	case StateOpen:
+		if b.cfg.Clock.Since(b.openedAt) < b.cfg.Cooldown {
+			b.mu.Unlock()
+			return ErrCircuitOpen
+		}
+		changed = b.transition(StateHalfOpen)
+		b.probing = true
+	case StateHalfOpen:
+		if b.probing {
+			b.mu.Unlock()
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	b.mu.Unlock()
+	b.notify(changed)
+	return nil
+}
+
+// record updates the breaker with the outcome of an allowed call.
+func (b *CircuitBreaker) record(ctx context.Context, err error) {
+	b.recordOutcome(b.isFailure(ctx, err), err == nil)
+}
+
+// recordOutcome updates the breaker after an allowed call that failed, succeeded,
+// or neither (an error that does not count as a failure).
+func (b *CircuitBreaker) recordOutcome(failed, succeeded bool) {
+	b.mu.Lock()
+	var changed *stateChange
+	switch b.state {
+	case StateHalfOpen:
+		b.probing = false
+		if failed {
+			changed = b.open()
+		} else if succeeded {
+			changed = b.transition(StateClosed)
+		}
+	case StateClosed:
+		if failed {
+			b.failures++
+			if b.failures >= b.cfg.FailureThreshold {
+				changed = b.open()
+			}
+		} else if succeeded {
+			b.failures = 0
+		}
+	}
+	b.mu.Unlock()
+	b.notify(changed)
+}
+
+func (b *CircuitBreaker) isFailure(ctx context.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+	if b.cfg.IsFailure != nil {
+		return b.cfg.IsFailure(err)
+	}
+	return !isContextErr(ctx, err)
+}
+
+// stateChange is a transition to report once the lock is released.
+type stateChange struct {
+	from, to State
+}
+
+// open moves to StateOpen and starts the cooldown; callers must hold b.mu.
+func (b *CircuitBreaker) open() *stateChange {
+	b.openedAt = b.cfg.Clock.Now()
+	return b.transition(StateOpen)
+}
+
+// transition sets the state and returns the change to notify, if any; callers must hold b.mu.
+func (b *CircuitBreaker) transition(to State) *stateChange {
+	from := b.state
+	b.state = to
+	b.failures = 0
+	if from == to {
+		return nil
+	}
+	return &stateChange{from: from, to: to}
+}
+
+// notify invokes the state change callback; callers must not hold b.mu.
+func (b *CircuitBreaker) notify(changed *stateChange) {
+	if changed != nil && b.cfg.OnStateChange != nil {
+		b.cfg.OnStateChange(changed.from, changed.to)
+	}
+}
@@ -0,0 +1,167 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	chrono "core/chrono"
+)
+
+func TestCircuitBreaker_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	clock := chrono.NewMockClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	var transitions []string
+	cb := NewCircuitBreaker(
+		WithFailureThreshold(3),
+		WithCooldown(time.Minute),
+		WithBreakerClock(clock),
+		WithOnStateChange(func(from, to State) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		}),
+	)
+
+	down := errors.New("dependency down")
+	calls := 0
+	failing := func(context.Context) error {
+		calls++
+		return down
+	}
+
+	// closed: failures below the threshold pass through
+	for i := 0; i < 3; i++ {
+		if err := cb.Execute(ctx, failing); !errors.Is(err, down) {
+			t.Fatalf("call %d: want dependency error, got %v", i, err)
+		}
+	}
+	if cb.State() != StateOpen || calls != 3 {
+		t.Fatalf("want open after 3 failures, got %v calls=%d", cb.State(), calls)
+	}
+
+	// open: calls are short-circuited
+	if err := cb.Execute(ctx, failing); !errors.Is(err, ErrCircuitOpen) || calls != 3 {
+		t.Fatalf("want ErrCircuitOpen without calling fn, got %v calls=%d", err, calls)
+	}
+
+	// half-open: a failed probe reopens the breaker
+	clock.Advance(time.Minute)
+	if err := cb.Execute(ctx, failing); !errors.Is(err, down) || calls != 4 {
+		t.Fatalf("want probe to run, got %v calls=%d", err, calls)
+	}
+	if cb.State() != StateOpen {
+		t.Fatalf("want reopened after failed probe, got %v", cb.State())
+	}
+
+	// half-open: a successful probe closes it
+	clock.Advance(time.Minute)
+	if err := cb.Execute(ctx, func(context.Context) error { return nil }); err != nil {
+		t.Fatalf("want probe success, got %v", err)
+	}
+	if cb.State() != StateClosed {
+		t.Fatalf("want closed after successful probe, got %v", cb.State())
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("want transitions %v, got %v", want, transitions)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Fatalf("want transitions %v, got %v", want, transitions)
+		}
+	}
+}
+
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	ctx := context.Background()
+	cb := NewCircuitBreaker(WithFailureThreshold(2))
+	fail := func(context.Context) error { return errors.New("boom") }
+	ok := func(context.Context) error { return nil }
+
+	_ = cb.Execute(ctx, fail)
+	_ = cb.Execute(ctx, ok)
+	_ = cb.Execute(ctx, fail)
+	if cb.State() != StateClosed {
+		t.Fatalf("want closed when failures are not consecutive, got %v", cb.State())
+	}
+}
+
+func TestCircuitBreaker_HalfOpenSingleProbe(t *testing.T) {
+	ctx := context.Background()
+	clock := chrono.NewMockClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	cb := NewCircuitBreaker(WithFailureThreshold(1), WithCooldown(time.Second), WithBreakerClock(clock))
+	_ = cb.Execute(ctx, func(context.Context) error { return errors.New("boom") })
+	clock.Advance(time.Second)
+
+	err := cb.Execute(ctx, func(context.Context) error {
+		if err := cb.Execute(ctx, func(context.Context) error { return nil }); !errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("want concurrent call rejected during probe, got %v", err)
+		}
+		return nil
+	})
+	if err != nil || cb.State() != StateClosed {
+		t.Fatalf("want probe to close the breaker, got err=%v state=%v", err, cb.State())
+	}
+}
+
+func TestCircuitBreaker_PanickingProbeReopens(t *testing.T) {
+	ctx := context.Background()
+	clock := chrono.NewMockClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	cb := NewCircuitBreaker(WithFailureThreshold(1), WithCooldown(time.Second), WithBreakerClock(clock))
+	_ = cb.Execute(ctx, func(context.Context) error { return errors.New("boom") })
+	clock.Advance(time.Second)
+
+	func() {
+		defer func() {
+			if r := recover(); r != "probe panic" {
+				t.Fatalf("want panic re-raised, got %v", r)
+			}
+		}()
+		_ = cb.Execute(ctx, func(context.Context) error { panic("probe panic") })
+	}()
+	if cb.State() != StateOpen {
+		t.Fatalf("want panicking probe to reopen the breaker, got %v", cb.State())
+	}
+
+	// The probe slot is free again once the cooldown elapses
+	clock.Advance(time.Second)
+	if err := cb.Execute(ctx, func(context.Context) error { return nil }); err != nil || cb.State() != StateClosed {
+		t.Fatalf("want next probe to close the breaker, got err=%v state=%v", err, cb.State())
+	}
+}
+
+func TestCircuitBreaker_IgnoresContextErrors(t *testing.T) {
+	cb := NewCircuitBreaker(WithFailureThreshold(1))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = cb.Execute(ctx, func(ctx context.Context) error { return ctx.Err() })
+	if cb.State() != StateClosed {
+		t.Fatalf("want cancellation not counted as failure, got %v", cb.State())
+	}
+}
+
+func TestCircuitBreaker_ComposesWithDo(t *testing.T) {
+	ctx := context.Background()
+	cb := NewCircuitBreaker(WithFailureThreshold(2), WithCooldown(time.Hour))
+	calls := 0
+	err := Do(ctx, func(ctx context.Context) error {
+		return cb.Execute(ctx, func(context.Context) error {
+			calls++
+			return errors.New("boom")
+		})
+	}, WithMaxAttempts(5), WithPolicy(Constant(time.Millisecond)))
+
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("want ErrCircuitOpen once the breaker opens, got %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("want retries to stop after the breaker opened, got %d calls", calls)
+	}
+}
+
+func TestState_String(t *testing.T) {
+	if StateClosed.String() != "closed" || StateOpen.String() != "open" || StateHalfOpen.String() != "half-open" || State(9).String() != "unknown" {
+		t.Fatalf("unexpected state names")
+	}
+}
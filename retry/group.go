@@ -0,0 +1,72 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// TaskGroup runs independent functions under one retry configuration; create it with Group.
+type TaskGroup struct {
+	ctx  context.Context
+	opts []Option
+	sem  chan struct{}
+
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs []error
+}
+
+// Group returns a TaskGroup whose tasks are retried with opts and run with ctx.
+// It is like errgroup with per-task retries: a failing task does not cancel the others,
+// and WithConcurrency bounds how many tasks run at once.
+func Group(ctx context.Context, opts ...Option) *TaskGroup {
+	cfg := defaults()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	g := &TaskGroup{ctx: ctx, opts: opts}
+	if cfg.Concurrency > 0 {
+		g.sem = make(chan struct{}, cfg.Concurrency)
+	}
+	return g
+}
+
+// Add starts fn in its own goroutine, retried as by Do. It does not block; when the
+// concurrency limit is reached, fn waits for a running task to finish.
+func (g *TaskGroup) Add(fn Func) {
+	g.mu.Lock()
+	idx := len(g.errs)
+	g.errs = append(g.errs, nil)
+	g.mu.Unlock()
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			select {
+			case g.sem <- struct{}{}:
+				defer func() { <-g.sem }()
+			case <-g.ctx.Done():
+				g.setErr(idx, g.ctx.Err())
+				return
+			}
+		}
+		g.setErr(idx, Do(g.ctx, fn, g.opts...))
+	}()
+}
+
+func (g *TaskGroup) setErr(idx int, err error) {
+	g.mu.Lock()
+	g.errs[idx] = err
+	g.mu.Unlock()
+}
+
+// Wait blocks until every added task has finished and returns their final errors joined
+// with errors.Join in the order the tasks were added, or nil if all succeeded.
+func (g *TaskGroup) Wait() error {
+	g.wg.Wait()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return errors.Join(g.errs...)
+}
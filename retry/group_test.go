@@ -0,0 +1,90 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroup_JoinsFailures(t *testing.T) {
+	errA := errors.New("a failed")
+	errB := errors.New("b failed")
+	var flakyCalls atomic.Int32
+
+	g := Group(context.Background(), WithMaxAttempts(3), WithPolicy(Constant(time.Millisecond)))
+	g.Add(func(context.Context) error { return errA })
+	g.Add(func(context.Context) error { return nil })
+	g.Add(func(context.Context) error {
+		if flakyCalls.Add(1) < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+	g.Add(func(context.Context) error { return Unrecoverable(errB) })
+
+	err := g.Wait()
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Fatalf("want joined errA and errB, got %v", err)
+	}
+	if got := err.Error(); got != "a failed\nb failed" {
+		t.Fatalf("errors should be joined in Add order, got %q", got)
+	}
+	if flakyCalls.Load() != 3 {
+		t.Fatalf("flaky task should be retried with the shared policy, got %d calls", flakyCalls.Load())
+	}
+}
+
+func TestGroup_AllSucceed(t *testing.T) {
+	g := Group(context.Background())
+	for i := 0; i < 5; i++ {
+		g.Add(func(context.Context) error { return nil })
+	}
+	if err := g.Wait(); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+	if err := Group(context.Background()).Wait(); err != nil {
+		t.Fatalf("empty group: %v", err)
+	}
+}
+
+func TestGroup_ConcurrencyBound(t *testing.T) {
+	var running, peak atomic.Int32
+	g := Group(context.Background(), WithConcurrency(2))
+	for i := 0; i < 8; i++ {
+		g.Add(func(context.Context) error {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if got := peak.Load(); got != 2 {
+		t.Fatalf("peak concurrency = %d, want 2", got)
+	}
+}
+
+func TestGroup_CanceledWhileWaitingForSlot(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	started, release := make(chan struct{}), make(chan struct{})
+	g := Group(ctx, WithConcurrency(1), WithMaxAttempts(1))
+	g.Add(func(context.Context) error { close(started); <-release; return nil })
+	<-started
+	g.Add(func(context.Context) error { t.Error("queued task should not run after cancel"); return nil })
+
+	cancel()
+	close(release)
+	if err := g.Wait(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("want context.Canceled, got %v", err)
+	}
+}
@@ -51,3 +51,33 @@ func EqualJitter(r *rand.Rand) Jitter {
 		return half + time.Duration(r.Int63n(int64(half)))
 	}
 }
+
+// DecorrelatedJitter returns an AWS-style decorrelated jitter policy:
+// sleep = min(maxDelay, rand(base, prev*3)), where prev is the previous delay.
+// The returned Policy is stateful: it remembers the previous delay and resets on attempt 1.
+// It is not safe for concurrent use; create one per Do call.
+func DecorrelatedJitter(base, maxDelay time.Duration) Policy {
+	if base <= 0 {
+		base = time.Millisecond
+	}
+	if maxDelay < base {
+		maxDelay = base
+	}
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	prev := base
+	return func(attempt int) time.Duration {
+		if attempt <= 1 {
+			prev = base
+		}
+		upper := prev * 3
+		if upper < prev || upper > maxDelay { // overflow or beyond cap
+			upper = maxDelay
+		}
+		d := base
+		if upper > base {
+			d = base + time.Duration(r.Int63n(int64(upper-base)))
+		}
+		prev = d
+		return d
+	}
+}
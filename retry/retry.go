@@ -9,6 +9,9 @@ import (
 // Func is the function to retry.
 type Func func(ctx context.Context) error
 
+// AttemptFunc is a function to retry that receives the 1-based attempt number.
+type AttemptFunc func(ctx context.Context, attempt int) error
+
 // ResultFunc runs a function that returns a value and error.
 type ResultFunc[T any] func(ctx context.Context) (T, error)
 
@@ -25,6 +28,9 @@ type RetryIf func(err error) bool
 // OnRetry is called after a failed attempt, before sleeping.
 type OnRetry func(ctx context.Context, attempt int, err error, nextDelay time.Duration)
 
+// OnGiveUp is called once when all attempts are exhausted without success.
+type OnGiveUp func(ctx context.Context, attempts int, err error)
+
 // Options configures retry behavior.
 type Options struct {
 	MaxAttempts int
@@ -33,6 +39,12 @@ type Options struct {
 	MaxDelay    time.Duration
 	RetryIf     RetryIf
 	OnRetry     OnRetry
+	OnGiveUp    OnGiveUp
+
+	RespectRetryAfter bool
+
+	// Concurrency bounds how many Group tasks run at once; <= 0 means unbounded.
+	Concurrency int
 }
 
 // Option applies a mutation to Options.
@@ -56,6 +68,26 @@ func WithRetryIf(p RetryIf) Option { return func(o *Options) { o.RetryIf = p } }
 // WithOnRetry sets a callback invoked after each failed attempt.
 func WithOnRetry(cb OnRetry) Option { return func(o *Options) { o.OnRetry = cb } }
 
+// WithOnGiveUp sets a callback invoked once when the final attempt fails.
+// It does not fire on success, on context cancellation, or when RetryIf or
+// Unrecoverable stops retrying early.
+func WithOnGiveUp(cb OnGiveUp) Option { return func(o *Options) { o.OnGiveUp = cb } }
+
+// WithRespectRetryAfter uses the delay hinted by errors implementing
+// interface{ RetryAfter() time.Duration } (e.g., HTTP 429/503) instead of the policy delay.
+// The hint is still capped by MaxDelay. Default: off.
+func WithRespectRetryAfter(enabled bool) Option {
+	return func(o *Options) { o.RespectRetryAfter = enabled }
+}
+
+// WithConcurrency limits how many tasks of a Group run at once. Do ignores it. Default: unbounded.
+func WithConcurrency(n int) Option { return func(o *Options) { o.Concurrency = n } }
+
+// retryAfterHint is implemented by errors that carry a server-provided retry delay.
+type retryAfterHint interface {
+	RetryAfter() time.Duration
+}
+
 func defaults() Options {
 	return Options{
 		MaxAttempts: 3,
@@ -66,8 +98,18 @@ func defaults() Options {
 }
 
 // Do executes fn with retries according to options.
-// Returns nil on success or the last error encountered.
+// Returns nil on success or the last error encountered. An attempt failing with
+// ErrCircuitOpen stops retrying immediately.
 func Do(ctx context.Context, fn Func, opts ...Option) error {
+	if fn == nil {
+		return errors.New("retry: nil function")
+	}
+	return DoAttempt(ctx, func(ctx context.Context, _ int) error { return fn(ctx) }, opts...)
+}
+
+// DoAttempt is like Do but passes the 1-based attempt number to fn,
+// letting it behave differently on retries (e.g., bypass a cache).
+func DoAttempt(ctx context.Context, fn AttemptFunc, opts ...Option) error {
 	if fn == nil {
 		return errors.New("retry: nil function")
 	}
@@ -85,11 +127,24 @@ func Do(ctx context.Context, fn Func, opts ...Option) error {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
-		if err := fn(ctx); err == nil {
+		if err := fn(ctx, attempt); err == nil {
 			return nil
 		} else {
+			var permanent *unrecoverableError
+			if errors.As(err, &permanent) {
+				return permanent.err
+			}
+			if errors.Is(err, ErrCircuitOpen) {
+				return err
+			}
 			lastErr = err
-			if !cfg.RetryIf(err) || attempt == cfg.MaxAttempts {
+			if !cfg.RetryIf(err) {
+				return lastErr
+			}
+			if attempt == cfg.MaxAttempts {
+				if cfg.OnGiveUp != nil && !isContextErr(ctx, err) {
+					cfg.OnGiveUp(ctx, attempt, err)
+				}
 				return lastErr
 			}
 			// Compute next delay
@@ -97,6 +152,12 @@ func Do(ctx context.Context, fn Func, opts ...Option) error {
 			if cfg.Jitter != nil {
 				d = cfg.Jitter(d, attempt)
 			}
+			if cfg.RespectRetryAfter {
+				var hint retryAfterHint
+				if errors.As(err, &hint) && hint.RetryAfter() > 0 {
+					d = hint.RetryAfter()
+				}
+			}
 			if cfg.MaxDelay > 0 && d > cfg.MaxDelay {
 				d = cfg.MaxDelay
 			}
@@ -115,6 +176,22 @@ func Do(ctx context.Context, fn Func, opts ...Option) error {
 	return lastErr
 }
 
+// Unrecoverable marks err as permanent so Do stops immediately and returns err,
+// regardless of RetryIf. It returns nil if err is nil.
+func Unrecoverable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &unrecoverableError{err: err}
+}
+
+type unrecoverableError struct {
+	err error
+}
+
+func (e *unrecoverableError) Error() string { return e.err.Error() }
+func (e *unrecoverableError) Unwrap() error { return e.err }
+
 // DoWithResult executes fn with retries and returns its result or the last error.
 func DoWithResult[T any](ctx context.Context, fn ResultFunc[T], opts ...Option) (T, error) {
 	var zero T
@@ -135,6 +212,11 @@ func DoWithResult[T any](ctx context.Context, fn ResultFunc[T], opts ...Option)
 	return out, nil
 }
 
+// isContextErr reports whether err stems from ctx being cancelled or timing out.
+func isContextErr(ctx context.Context, err error) bool {
+	return ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
 func sleep(ctx context.Context, d time.Duration) error {
 	if d <= 0 {
 		return nil
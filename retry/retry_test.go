@@ -3,6 +3,7 @@ package retry
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 )
@@ -82,3 +83,183 @@ func TestJitterApplied(t *testing.T) {
 		t.Fatalf("expected jittered sleep in (0, 10ms], got %v", slept)
 	}
 }
+
+func TestDoAttempt_PassesAttemptNumber(t *testing.T) {
+	var seen []int
+	wantErr := errors.New("boom")
+	err := DoAttempt(context.Background(), func(_ context.Context, attempt int) error {
+		seen = append(seen, attempt)
+		return wantErr
+	}, WithMaxAttempts(3), WithPolicy(Constant(time.Millisecond)))
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("want err %v, got %v", wantErr, err)
+	}
+	if len(seen) != 3 || seen[0] != 1 || seen[1] != 2 || seen[2] != 3 {
+		t.Fatalf("want attempts [1 2 3], got %v", seen)
+	}
+}
+
+func TestDoAttempt_SucceedsOnRetry(t *testing.T) {
+	last := 0
+	err := DoAttempt(context.Background(), func(_ context.Context, attempt int) error {
+		last = attempt
+		if attempt == 1 {
+			return errors.New("cold cache")
+		}
+		return nil
+	}, WithPolicy(Constant(time.Millisecond)))
+	if err != nil || last != 2 {
+		t.Fatalf("want success on attempt 2, got err=%v last=%d", err, last)
+	}
+}
+
+func TestDecorrelatedJitter_Bounds(t *testing.T) {
+	base, limit := 10*time.Millisecond, 500*time.Millisecond
+	p := DecorrelatedJitter(base, limit)
+	for run := 0; run < 20; run++ {
+		prev := base
+		for attempt := 1; attempt <= 10; attempt++ {
+			d := p(attempt)
+			if d < base || d > limit {
+				t.Fatalf("attempt %d: delay %v outside [%v, %v]", attempt, d, base, limit)
+			}
+			if d > prev*3 {
+				t.Fatalf("attempt %d: delay %v exceeds 3x previous %v", attempt, d, prev)
+			}
+			prev = d
+		}
+	}
+}
+
+func TestDecorrelatedJitter_WithDo(t *testing.T) {
+	var delays []time.Duration
+	_ = Do(context.Background(), func(context.Context) error {
+		return errors.New("boom")
+	}, WithMaxAttempts(4),
+		WithPolicy(DecorrelatedJitter(time.Millisecond, 5*time.Millisecond)),
+		WithOnRetry(func(_ context.Context, _ int, _ error, next time.Duration) { delays = append(delays, next) }))
+	if len(delays) != 3 {
+		t.Fatalf("want 3 delays, got %v", delays)
+	}
+	for _, d := range delays {
+		if d < time.Millisecond || d > 5*time.Millisecond {
+			t.Fatalf("delay %v outside bounds", d)
+		}
+	}
+}
+
+type throttledError struct {
+	after time.Duration
+}
+
+func (e *throttledError) Error() string             { return "throttled" }
+func (e *throttledError) RetryAfter() time.Duration { return e.after }
+
+func TestDo_RespectRetryAfter(t *testing.T) {
+	var delays []time.Duration
+	onRetry := func(_ context.Context, _ int, _ error, next time.Duration) { delays = append(delays, next) }
+	calls := 0
+	fn := func(context.Context) error {
+		calls++
+		if calls == 1 {
+			return fmt.Errorf("request failed: %w", &throttledError{after: 7 * time.Millisecond})
+		}
+		return nil
+	}
+
+	err := Do(context.Background(), fn, WithPolicy(Constant(time.Millisecond)), WithOnRetry(onRetry), WithRespectRetryAfter(true))
+	if err != nil || len(delays) != 1 || delays[0] != 7*time.Millisecond {
+		t.Fatalf("want honored 7ms delay, got err=%v delays=%v", err, delays)
+	}
+
+	// Off by default: the policy delay is used.
+	calls, delays = 0, nil
+	_ = Do(context.Background(), fn, WithPolicy(Constant(time.Millisecond)), WithOnRetry(onRetry))
+	if len(delays) != 1 || delays[0] != time.Millisecond {
+		t.Fatalf("want policy delay when disabled, got %v", delays)
+	}
+
+	// The hint is capped by MaxDelay.
+	calls, delays = 0, nil
+	_ = Do(context.Background(), fn, WithPolicy(Constant(time.Millisecond)), WithOnRetry(onRetry),
+		WithRespectRetryAfter(true), WithMaxDelay(3*time.Millisecond))
+	if len(delays) != 1 || delays[0] != 3*time.Millisecond {
+		t.Fatalf("want hint capped at 3ms, got %v", delays)
+	}
+}
+
+func TestDo_UnrecoverableStopsImmediately(t *testing.T) {
+	calls := 0
+	wantErr := errors.New("invalid credentials")
+	err := Do(context.Background(), func(context.Context) error {
+		calls++
+		return Unrecoverable(wantErr)
+	}, WithMaxAttempts(5), WithPolicy(Constant(time.Millisecond)), WithRetryIf(func(error) bool { return true }))
+	if calls != 1 {
+		t.Fatalf("want 1 call, got %d", calls)
+	}
+	if err != wantErr {
+		t.Fatalf("want unwrapped error %v, got %v", wantErr, err)
+	}
+}
+
+func TestDo_UnrecoverableWrapped(t *testing.T) {
+	calls := 0
+	wantErr := errors.New("not found")
+	err := Do(context.Background(), func(context.Context) error {
+		calls++
+		return fmt.Errorf("load user: %w", Unrecoverable(wantErr))
+	}, WithMaxAttempts(3), WithPolicy(Constant(time.Millisecond)))
+	if calls != 1 || !errors.Is(err, wantErr) {
+		t.Fatalf("want single call returning %v, got calls=%d err=%v", wantErr, calls, err)
+	}
+	if Unrecoverable(nil) != nil {
+		t.Fatalf("Unrecoverable(nil) should be nil")
+	}
+}
+
+func TestDo_OnGiveUpFiresOnceAfterLastAttempt(t *testing.T) {
+	wantErr := errors.New("boom")
+	fired := 0
+	var gotAttempts int
+	var gotErr error
+	err := Do(context.Background(), func(context.Context) error {
+		return wantErr
+	}, WithMaxAttempts(4), WithPolicy(Constant(time.Millisecond)), WithOnGiveUp(func(_ context.Context, attempts int, err error) {
+		fired++
+		gotAttempts, gotErr = attempts, err
+	}))
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("want err %v, got %v", wantErr, err)
+	}
+	if fired != 1 || gotAttempts != 4 || !errors.Is(gotErr, wantErr) {
+		t.Fatalf("want one give-up after 4 attempts, got fired=%d attempts=%d err=%v", fired, gotAttempts, gotErr)
+	}
+}
+
+func TestDo_OnGiveUpNotFiredOnSuccessOrCancel(t *testing.T) {
+	fired := 0
+	onGiveUp := WithOnGiveUp(func(context.Context, int, error) { fired++ })
+
+	calls := 0
+	_ = Do(context.Background(), func(context.Context) error {
+		calls++
+		if calls < 2 {
+			return errors.New("transient")
+		}
+		return nil
+	}, WithPolicy(Constant(time.Millisecond)), onGiveUp)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := Do(ctx, func(context.Context) error {
+		cancel()
+		return context.Canceled
+	}, WithMaxAttempts(1), onGiveUp)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("want context.Canceled, got %v", err)
+	}
+
+	if fired != 0 {
+		t.Fatalf("OnGiveUp should not fire on success or cancellation, fired=%d", fired)
+	}
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Byte size units in decimal (SI) and binary (IEC) multiples.
+const (
+	Byte int64 = 1
+
+	KB int64 = 1000 * Byte
+	MB int64 = 1000 * KB
+	GB int64 = 1000 * MB
+	TB int64 = 1000 * GB
+
+	KiB int64 = 1024 * Byte
+	MiB int64 = 1024 * KiB
+	GiB int64 = 1024 * MiB
+	TiB int64 = 1024 * GiB
+)
+
+var byteSizeUnits = map[string]int64{
+	"":    Byte,
+	"b":   Byte,
+	"kb":  KB,
+	"mb":  MB,
+	"gb":  GB,
+	"tb":  TB,
+	"kib": KiB,
+	"mib": MiB,
+	"gib": GiB,
+	"tib": TiB,
+}
+
+// ParseByteSize parses a human-readable byte size such as "10MB", "512KiB", or "1.5 GB".
+// Decimal suffixes (KB, MB, GB, TB) use powers of 1000 and binary suffixes (KiB, MiB, GiB, TiB)
+// use powers of 1024. Suffixes are case-insensitive; a bare number is interpreted as bytes.
+func ParseByteSize(s string) (int64, error) {
+	str := strings.TrimSpace(s)
+	if str == "" {
+		return 0, fmt.Errorf("invalid byte size: empty string")
+	}
+
+	i := 0
+	for i < len(str) && (str[i] >= '0' && str[i] <= '9' || str[i] == '.') {
+		i++
+	}
+	number, unit := str[:i], strings.ToLower(strings.TrimSpace(str[i:]))
+	if number == "" {
+		return 0, fmt.Errorf("invalid byte size: %s", s)
+	}
+
+	multiplier, ok := byteSizeUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid byte size unit: %s", str[i:])
+	}
+
+	if !strings.Contains(number, ".") {
+		n, err := strconv.ParseInt(number, 10, 64)
+		if err != nil || n > math.MaxInt64/multiplier {
+			return 0, fmt.Errorf("invalid byte size: %s", s)
+		}
+		return n * multiplier, nil
+	}
+
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size: %s", s)
+	}
+	size := f * float64(multiplier)
+	if size >= math.MaxInt64 {
+		return 0, fmt.Errorf("invalid byte size: %s", s)
+	}
+	return int64(size), nil
+}
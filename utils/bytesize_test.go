@@ -0,0 +1,33 @@
+package utils
+
+import "testing"
+
+func TestParseByteSize(t *testing.T) {
+	cases := []struct {
+		in   string
+		want int64
+	}{
+		{"10MB", 10 * MB},
+		{"512KiB", 512 * KiB},
+		{"1GB", GB},
+		{"2GiB", 2 * GiB},
+		{"1.5 KB", 1500},
+		{"10mb", 10 * MB},
+		{"42", 42},
+		{"42B", 42},
+	}
+	for _, c := range cases {
+		got, err := ParseByteSize(c.in)
+		if err != nil || got != c.want {
+			t.Fatalf("ParseByteSize(%q)=%d err=%v, want %d", c.in, got, err, c.want)
+		}
+	}
+}
+
+func TestParseByteSize_Invalid(t *testing.T) {
+	for _, in := range []string{"", "MB", "10XB", "10 megabytes", "1.2.3MB", "-5MB", "9999999999TiB"} {
+		if _, err := ParseByteSize(in); err == nil {
+			t.Fatalf("ParseByteSize(%q) should fail", in)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package utils
+
+import "reflect"
+
+// DeepCopy returns a deep copy of src using reflection.
+// Nested structs, slices, arrays, maps, and interfaces are copied recursively and pointers
+// are followed, so mutating the copy never affects the original. Channels and funcs are
+// copied by reference. Unexported struct fields are copied shallowly.
+func DeepCopy[T any](src T) T {
+	srcVal := reflect.ValueOf(&src).Elem()
+	dst := reflect.New(srcVal.Type()).Elem()
+	copyValue(dst, srcVal, make(map[visitKey]reflect.Value))
+	return *dst.Addr().Interface().(*T)
+}
+
+// visitKey identifies a source pointer. The type is part of the key because a pointer to a
+// struct and a pointer to its first field share an address.
+type visitKey struct {
+	t reflect.Type
+	p uintptr
+}
+
+// copyValue recursively copies src into dst. visited maps source pointers to their copies
+// so that shared and cyclic references are preserved instead of looping forever.
+func copyValue(dst, src reflect.Value, visited map[visitKey]reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		key := visitKey{t: src.Type(), p: src.Pointer()}
+		if seen, ok := visited[key]; ok {
+			dst.Set(seen)
+			return
+		}
+		ptr := reflect.New(src.Type().Elem())
+		visited[key] = ptr
+		copyValue(ptr.Elem(), src.Elem(), visited)
+		dst.Set(ptr)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		elem := src.Elem()
+		elemCopy := reflect.New(elem.Type()).Elem()
+		copyValue(elemCopy, elem, visited)
+		dst.Set(elemCopy)
+	case reflect.Struct:
+		// Copy everything first so unexported fields are carried over shallowly
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				copyValue(dst.Field(i), src.Field(i), visited)
+			}
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		slice := reflect.MakeSlice(src.Type(), src.Len(), src.Cap())
+		for i := 0; i < src.Len(); i++ {
+			copyValue(slice.Index(i), src.Index(i), visited)
+		}
+		dst.Set(slice)
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			copyValue(dst.Index(i), src.Index(i), visited)
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		m := reflect.MakeMapWithSize(src.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			key := reflect.New(src.Type().Key()).Elem()
+			copyValue(key, iter.Key(), visited)
+			value := reflect.New(src.Type().Elem()).Elem()
+			copyValue(value, iter.Value(), visited)
+			m.SetMapIndex(key, value)
+		}
+		dst.Set(m)
+	default:
+		dst.Set(src)
+	}
+}
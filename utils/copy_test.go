@@ -0,0 +1,98 @@
+package utils
+
+import "testing"
+
+type copyAddress struct {
+	Street string
+	Tags   []string
+}
+
+type copyUser struct {
+	Name    string
+	Roles   []string
+	Attrs   map[string]int
+	Address *copyAddress
+	Meta    any
+	Notify  chan struct{}
+}
+
+func TestDeepCopy_SliceAndMap(t *testing.T) {
+	orig := copyUser{
+		Name:  "alice",
+		Roles: []string{"admin", "user"},
+		Attrs: map[string]int{"age": 30},
+	}
+
+	cp := DeepCopy(orig)
+	cp.Roles[0] = "guest"
+	cp.Roles = append(cp.Roles, "extra")
+	cp.Attrs["age"] = 99
+	cp.Attrs["new"] = 1
+
+	if orig.Roles[0] != "admin" || len(orig.Roles) != 2 {
+		t.Fatalf("original slice mutated: %v", orig.Roles)
+	}
+	if orig.Attrs["age"] != 30 || len(orig.Attrs) != 1 {
+		t.Fatalf("original map mutated: %v", orig.Attrs)
+	}
+}
+
+func TestDeepCopy_PointersAndInterfaces(t *testing.T) {
+	orig := &copyUser{
+		Address: &copyAddress{Street: "Main", Tags: []string{"home"}},
+		Meta:    map[string]string{"k": "v"},
+		Notify:  make(chan struct{}),
+	}
+
+	cp := DeepCopy(orig)
+	if cp == orig || cp.Address == orig.Address {
+		t.Fatalf("pointers were not followed")
+	}
+	cp.Address.Street = "Side"
+	cp.Address.Tags[0] = "work"
+	cp.Meta.(map[string]string)["k"] = "changed"
+
+	if orig.Address.Street != "Main" || orig.Address.Tags[0] != "home" {
+		t.Fatalf("original nested struct mutated: %+v", orig.Address)
+	}
+	if orig.Meta.(map[string]string)["k"] != "v" {
+		t.Fatalf("original interface value mutated")
+	}
+	if cp.Notify != orig.Notify {
+		t.Fatalf("channels should be copied by reference")
+	}
+}
+
+func TestDeepCopy_Cycle(t *testing.T) {
+	type node struct {
+		Next *node
+		Val  int
+	}
+	n := &node{Val: 1}
+	n.Next = n
+
+	cp := DeepCopy(n)
+	if cp == n || cp.Next != cp {
+		t.Fatalf("cycle not preserved in copy")
+	}
+}
+
+func TestDeepCopy_PointerToFirstField(t *testing.T) {
+	type inner struct {
+		N int
+	}
+	type holder struct {
+		Whole *inner
+		First *int
+	}
+	in := &inner{N: 7}
+	h := holder{Whole: in, First: &in.N}
+
+	cp := DeepCopy(h)
+	if cp.Whole == in || cp.Whole.N != 7 {
+		t.Fatalf("Whole not copied: %+v", cp.Whole)
+	}
+	if cp.First == &in.N || *cp.First != 7 {
+		t.Fatalf("First not copied")
+	}
+}
@@ -1,6 +1,9 @@
 package utils
 
-import "reflect"
+import (
+	"reflect"
+	"strings"
+)
 
 // GetStructTags extracts all struct tags for a given entity.
 // It returns a map of field names to their tag values.
@@ -67,3 +70,43 @@ func getStructTagsRecursive(val reflect.Value, tagName string) map[string]string
 
 	return tags
 }
+
+// GetStructTagNames is like GetStructTags but strips tag options, so
+// `json:"name,omitempty"` maps to "name". A tag with an empty name
+// (`json:",omitempty"`) maps to the field name, mirroring encoding/json.
+func GetStructTagNames(targetStruct any, tagName string) map[string]string {
+	raw := GetStructTags(targetStruct, tagName)
+	names := make(map[string]string, len(raw))
+	for field, value := range raw {
+		name, _ := parseTag(value)
+		if name == "" {
+			name = field
+		}
+		names[field] = name
+	}
+	return names
+}
+
+// GetStructTagOptions returns the options following the tag name for each tagged field,
+// e.g. ["omitempty"] for `json:"name,omitempty"`. Fields without options map to nil.
+func GetStructTagOptions(targetStruct any, tagName string) map[string][]string {
+	raw := GetStructTags(targetStruct, tagName)
+	options := make(map[string][]string, len(raw))
+	for field, value := range raw {
+		_, opts := parseTag(value)
+		options[field] = opts
+	}
+	return options
+}
+
+// parseTag splits a tag value into its name and comma-separated options, dropping empty options
+func parseTag(value string) (string, []string) {
+	name, rest, _ := strings.Cut(value, ",")
+	var opts []string
+	for _, opt := range strings.Split(rest, ",") {
+		if opt != "" {
+			opts = append(opts, opt)
+		}
+	}
+	return name, opts
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+type tagBase struct {
+	ID string `json:"id,omitempty"`
+}
+
+type tagged struct {
+	tagBase
+	Name    string `json:"name,omitempty"`
+	Email   string `json:"email"`
+	Secret  string `json:"-"`
+	Dash    string `json:"-,"`
+	Created string `json:",omitempty"`
+	Plain   string
+}
+
+func TestGetStructTags_Raw(t *testing.T) {
+	got := GetStructTags(&tagged{}, "json")
+	if got["Name"] != "name,omitempty" {
+		t.Fatalf("expected raw value, got %q", got["Name"])
+	}
+	if _, ok := got["Secret"]; ok {
+		t.Fatalf("expected \"-\" to be skipped")
+	}
+}
+
+func TestGetStructTagNames(t *testing.T) {
+	got := GetStructTagNames(tagged{}, "json")
+	want := map[string]string{
+		"ID":      "id",
+		"Name":    "name",
+		"Email":   "email",
+		"Dash":    "-",
+		"Created": "Created",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestGetStructTagOptions(t *testing.T) {
+	got := GetStructTagOptions(&tagged{}, "json")
+	want := map[string][]string{
+		"ID":      {"omitempty"},
+		"Name":    {"omitempty"},
+		"Email":   nil,
+		"Dash":    nil,
+		"Created": {"omitempty"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
@@ -1,6 +1,9 @@
 package utils
 
-import "strings"
+import (
+	"strings"
+	"unicode"
+)
 
 // ToSnakeCase converts a string to snake_case.
 func ToSnakeCase(str string) string {
@@ -13,3 +16,68 @@ func ToSnakeCase(str string) string {
 	}
 	return strings.ToLower(result)
 }
+
+// ToCamelCase converts snake_case, kebab-case, space-separated or PascalCase input to
+// camelCase. Acronyms are treated as words, so "HTTPServer" becomes "httpServer" and
+// "user_id" becomes "userId", which converts back with ToSnakeCase.
+func ToCamelCase(str string) string {
+	words := splitWords(str)
+	for i, w := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(w)
+		} else {
+			words[i] = capitalize(w)
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// ToPascalCase is like ToCamelCase but also capitalizes the first word,
+// e.g. "first_name" becomes "FirstName".
+func ToPascalCase(str string) string {
+	words := splitWords(str)
+	for i, w := range words {
+		words[i] = capitalize(w)
+	}
+	return strings.Join(words, "")
+}
+
+// capitalize upper-cases the first rune of w and lower-cases the rest.
+func capitalize(w string) string {
+	runes := []rune(strings.ToLower(w))
+	if len(runes) > 0 {
+		runes[0] = unicode.ToUpper(runes[0])
+	}
+	return string(runes)
+}
+
+// splitWords splits str on '_', '-' and whitespace, and on case changes within a word:
+// "userID" splits into "user", "ID"; "HTTPServer" into "HTTP", "Server".
+func splitWords(str string) []string {
+	var words []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = current[:0]
+		}
+	}
+	runes := []rune(str)
+	for i, r := range runes {
+		if r == '_' || r == '-' || unicode.IsSpace(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(current) > 0 {
+			prev := current[len(current)-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// lower→Upper starts a word; so does the last capital of an acronym before a lowercase run.
+			if !unicode.IsUpper(prev) || nextLower {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return words
+}
@@ -0,0 +1,60 @@
+package utils
+
+import "testing"
+
+func TestToCamelCase(t *testing.T) {
+	cases := map[string]string{
+		"first_name":     "firstName",
+		"first-name":     "firstName",
+		"first name":     "firstName",
+		"FirstName":      "firstName",
+		"firstName":      "firstName",
+		"userID":         "userId",
+		"user_id":        "userId",
+		"HTTPServer":     "httpServer",
+		"http_server":    "httpServer",
+		"ID":             "id",
+		"address2_line":  "address2Line",
+		"__leading__":    "leading",
+		"already":        "already",
+		"":               "",
+		"multi  spaced ": "multiSpaced",
+	}
+	for in, want := range cases {
+		if got := ToCamelCase(in); got != want {
+			t.Fatalf("ToCamelCase(%q)=%q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestToPascalCase(t *testing.T) {
+	cases := map[string]string{
+		"first_name":  "FirstName",
+		"first-name":  "FirstName",
+		"first name":  "FirstName",
+		"firstName":   "FirstName",
+		"userID":      "UserId",
+		"HTTPServer":  "HttpServer",
+		"getHTTPURL":  "GetHttpurl", // adjacent acronyms cannot be told apart
+		"created_at":  "CreatedAt",
+		"x":           "X",
+		"":            "",
+		"über_straße": "ÜberStraße",
+	}
+	for in, want := range cases {
+		if got := ToPascalCase(in); got != want {
+			t.Fatalf("ToPascalCase(%q)=%q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCaseRoundTrip(t *testing.T) {
+	for _, snake := range []string{"id", "first_name", "created_at", "user_id", "http_server", "deleted_at"} {
+		if got := ToSnakeCase(ToPascalCase(snake)); got != snake {
+			t.Fatalf("ToSnakeCase(ToPascalCase(%q))=%q", snake, got)
+		}
+		if got := ToSnakeCase(ToCamelCase(snake)); got != snake {
+			t.Fatalf("ToSnakeCase(ToCamelCase(%q))=%q", snake, got)
+		}
+	}
+}
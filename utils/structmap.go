@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"encoding"
+	"reflect"
+)
+
+// maxStructMapDepth bounds how deep StructToMap recurses. Cyclic pointer graphs stop
+// expanding at this depth instead of recursing forever.
+const maxStructMapDepth = 32
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// StructToMap converts v into a map keyed by each exported field's tag name, falling back
+// to the field name when the tag is missing or has an empty name. Fields tagged "-" are
+// skipped, and untagged embedded structs are flattened into the parent map.
+// Pointers are dereferenced (nil pointers become nil) and nested structs become nested
+// maps, except for types implementing encoding.TextMarshaler such as time.Time, which are
+// kept as-is. Other values are stored unchanged. If v is not a struct or pointer to one,
+// an empty map is returned.
+func StructToMap(v any, tag string) map[string]any {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return map[string]any{}
+	}
+	return structToMap(val, tag, 0)
+}
+
+// structToMap converts a struct value, tracking the current recursion depth
+func structToMap(val reflect.Value, tag string, depth int) map[string]any {
+	out := make(map[string]any, val.NumField())
+	appendStructFields(out, val, tag, depth)
+	return out
+}
+
+// appendStructFields writes the fields of val into out, flattening untagged embedded structs
+func appendStructFields(out map[string]any, val reflect.Value, tag string, depth int) {
+	valType := val.Type()
+	for i := 0; i < valType.NumField(); i++ {
+		field := valType.Field(i)
+		tagValue := field.Tag.Get(tag)
+		if tagValue == "-" {
+			continue
+		}
+		fieldVal := val.Field(i)
+
+		if field.Anonymous && tagValue == "" {
+			embedded := fieldVal
+			if embedded.Kind() == reflect.Ptr {
+				if embedded.IsNil() {
+					continue
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if depth < maxStructMapDepth {
+					appendStructFields(out, embedded, tag, depth+1)
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _ := parseTag(tagValue)
+		if name == "" {
+			name = field.Name
+		}
+		out[name] = structMapValue(fieldVal, tag, depth+1)
+	}
+}
+
+// structMapValue converts a single field value, dereferencing pointers and expanding structs
+func structMapValue(val reflect.Value, tag string, depth int) any {
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+	if val.Kind() == reflect.Struct && !val.Type().Implements(textMarshalerType) &&
+		!reflect.PointerTo(val.Type()).Implements(textMarshalerType) {
+		if depth >= maxStructMapDepth {
+			return nil
+		}
+		return structToMap(val, tag, depth)
+	}
+	return val.Interface()
+}
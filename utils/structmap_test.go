@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type mapAddress struct {
+	City string `json:"city"`
+	Zip  string
+}
+
+type mapAudit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type mapUser struct {
+	mapAudit
+	Name     string      `json:"name,omitempty"`
+	Password string      `json:"-"`
+	Address  mapAddress  `json:"address"`
+	Billing  *mapAddress `json:"billing"`
+	Shipping *mapAddress `json:"shipping"`
+	Joined   time.Time   `json:"joined"`
+	internal string
+}
+
+func TestStructToMap_Nested(t *testing.T) {
+	joined := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	u := &mapUser{
+		mapAudit: mapAudit{CreatedBy: "system"},
+		Name:     "alice",
+		Password: "secret",
+		Address:  mapAddress{City: "Berlin", Zip: "10115"},
+		Billing:  &mapAddress{City: "Paris"},
+		Joined:   joined,
+		internal: "hidden",
+	}
+
+	got := StructToMap(u, "json")
+	want := map[string]any{
+		"created_by": "system",
+		"name":       "alice",
+		"address":    map[string]any{"city": "Berlin", "Zip": "10115"},
+		"billing":    map[string]any{"city": "Paris", "Zip": ""},
+		"shipping":   nil,
+		"joined":     joined,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestStructToMap_SkipsDash(t *testing.T) {
+	got := StructToMap(mapUser{Password: "secret"}, "json")
+	if _, ok := got["Password"]; ok {
+		t.Fatalf("expected Password to be skipped, got %v", got)
+	}
+}
+
+func TestStructToMap_NonStruct(t *testing.T) {
+	if got := StructToMap(42, "json"); len(got) != 0 {
+		t.Fatalf("expected empty map, got %v", got)
+	}
+	var u *mapUser
+	if got := StructToMap(u, "json"); len(got) != 0 {
+		t.Fatalf("expected empty map for nil pointer, got %v", got)
+	}
+}
+
+type mapNode struct {
+	Name string   `json:"name"`
+	Next *mapNode `json:"next"`
+}
+
+func TestStructToMap_Cycle(t *testing.T) {
+	n := &mapNode{Name: "a"}
+	n.Next = n
+
+	got := StructToMap(n, "json")
+	depth := 0
+	for cur := got; cur != nil; depth++ {
+		next, _ := cur["next"].(map[string]any)
+		cur = next
+	}
+	if depth != maxStructMapDepth {
+		t.Fatalf("expected expansion to stop at depth %d, got %d", maxStructMapDepth, depth)
+	}
+}
@@ -0,0 +1,58 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+
+	"core/utils"
+)
+
+// ByteSizeValidator validates human-readable byte sizes such as "10MB" within optional bounds
+type ByteSizeValidator struct {
+	Min *int64
+	Max *int64
+}
+
+func (v *ByteSizeValidator) Validate(value any) error {
+	val := reflect.ValueOf(value)
+	if val.Kind() != reflect.String {
+		return fmt.Errorf("bytesize validation only applies to strings")
+	}
+
+	size, err := utils.ParseByteSize(val.String())
+	if err != nil {
+		return err
+	}
+	if v.Min != nil && size < *v.Min {
+		return fmt.Errorf("size must be at least %d bytes", *v.Min)
+	}
+	if v.Max != nil && size > *v.Max {
+		return fmt.Errorf("size must be at most %d bytes", *v.Max)
+	}
+	return nil
+}
+
+// New creates a new ByteSizeValidator from parameters
+func (v *ByteSizeValidator) New(params map[string]string) (Validator, error) {
+	validator := &ByteSizeValidator{}
+	for key, target := range map[string]**int64{"min": &validator.Min, "max": &validator.Max} {
+		raw := params[key]
+		if raw == "" {
+			continue
+		}
+		size, err := utils.ParseByteSize(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bytesize %s value: %s", key, raw)
+		}
+		*target = &size
+	}
+	if validator.Min != nil && validator.Max != nil && *validator.Min > *validator.Max {
+		return nil, fmt.Errorf("bytesize min must not exceed max")
+	}
+	return validator, nil
+}
+
+// Key returns the registration key for this validator
+func (v *ByteSizeValidator) Key() string {
+	return "bytesize"
+}
@@ -0,0 +1,85 @@
+package validation
+
+import (
+	"testing"
+
+	"core/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestByteSizeValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		wantErr bool
+	}{
+		{"decimal megabytes", "10MB", false},
+		{"binary kibibytes", "512KiB", false},
+		{"plain bytes", "2048", false},
+		{"invalid suffix", "10XB", true},
+		{"no number", "MB", true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&ByteSizeValidator{}).Validate(tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid byte size")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestByteSizeValidator_Validate_Range(t *testing.T) {
+	validator, err := (&ByteSizeValidator{}).New(map[string]string{"min": "1KiB", "max": "10MB"})
+	require.NoError(t, err)
+
+	assert.NoError(t, validator.Validate("512KiB"))
+	assert.NoError(t, validator.Validate("10MB"))
+
+	err = validator.Validate("512")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "size must be at least 1024 bytes")
+
+	err = validator.Validate("11MB")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "size must be at most 10000000 bytes")
+}
+
+func TestByteSizeValidator_Validate_NonStringTypes(t *testing.T) {
+	err := (&ByteSizeValidator{}).Validate(1024)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bytesize validation only applies to strings")
+}
+
+func TestByteSizeValidator_New(t *testing.T) {
+	min, max := utils.KiB, 10*utils.MB
+	testValidatorNew(t, &ByteSizeValidator{}, map[string]string{}, (*int64)(nil), "Min")
+	testValidatorNew(t, &ByteSizeValidator{}, map[string]string{"min": "1KiB", "max": "10MB"}, &min, "Min")
+	testValidatorNew(t, &ByteSizeValidator{}, map[string]string{"min": "1KiB", "max": "10MB"}, &max, "Max")
+	testValidatorNewError(t, &ByteSizeValidator{}, map[string]string{"min": "big"}, "invalid bytesize min value: big")
+	testValidatorNewError(t, &ByteSizeValidator{}, map[string]string{"min": "10MB", "max": "1MB"}, "bytesize min must not exceed max")
+}
+
+func TestByteSizeValidator_Key(t *testing.T) {
+	testValidatorKey(t, &ByteSizeValidator{}, "bytesize")
+}
+
+func TestValidate_ByteSizeTag(t *testing.T) {
+	type Config struct {
+		MaxUploadSize string `validate:"bytesize:min=1KB,max=100MB"`
+	}
+
+	result := Validate(Config{MaxUploadSize: "10MB"})
+	assert.True(t, result.IsValid)
+
+	result = Validate(Config{MaxUploadSize: "1GiB"})
+	require.False(t, result.IsValid)
+	assert.Equal(t, "bytesize", result.Errors[0].Rule)
+}
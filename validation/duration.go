@@ -0,0 +1,57 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// DurationValidator validates duration strings such as "30s" or "1h30m" within optional bounds
+type DurationValidator struct {
+	Min *time.Duration
+	Max *time.Duration
+}
+
+func (v *DurationValidator) Validate(value any) error {
+	val := reflect.ValueOf(value)
+	if val.Kind() != reflect.String {
+		return fmt.Errorf("duration validation only applies to strings")
+	}
+
+	d, err := time.ParseDuration(val.String())
+	if err != nil {
+		return fmt.Errorf("invalid duration: %s", val.String())
+	}
+	if v.Min != nil && d < *v.Min {
+		return fmt.Errorf("duration must be at least %v", *v.Min)
+	}
+	if v.Max != nil && d > *v.Max {
+		return fmt.Errorf("duration must be at most %v", *v.Max)
+	}
+	return nil
+}
+
+// New creates a new DurationValidator from parameters
+func (v *DurationValidator) New(params map[string]string) (Validator, error) {
+	validator := &DurationValidator{}
+	for key, target := range map[string]**time.Duration{"min": &validator.Min, "max": &validator.Max} {
+		raw := params[key]
+		if raw == "" {
+			continue
+		}
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %s value: %s", key, raw)
+		}
+		*target = &d
+	}
+	if validator.Min != nil && validator.Max != nil && *validator.Min > *validator.Max {
+		return nil, fmt.Errorf("duration min must not exceed max")
+	}
+	return validator, nil
+}
+
+// Key returns the registration key for this validator
+func (v *DurationValidator) Key() string {
+	return "duration"
+}
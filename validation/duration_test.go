@@ -0,0 +1,84 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurationValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		wantErr bool
+	}{
+		{"seconds", "30s", false},
+		{"minutes", "5m", false},
+		{"compound", "1h30m", false},
+		{"fractional", "1.5h", false},
+		{"missing unit", "30", true},
+		{"unknown unit", "5 minutes", true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&DurationValidator{}).Validate(tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid duration")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDurationValidator_Validate_Range(t *testing.T) {
+	validator, err := (&DurationValidator{}).New(map[string]string{"min": "1s", "max": "1h"})
+	require.NoError(t, err)
+
+	assert.NoError(t, validator.Validate("30s"))
+	assert.NoError(t, validator.Validate("1h"))
+
+	err = validator.Validate("500ms")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duration must be at least 1s")
+
+	err = validator.Validate("1h30m")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duration must be at most 1h0m0s")
+}
+
+func TestDurationValidator_Validate_NonStringTypes(t *testing.T) {
+	err := (&DurationValidator{}).Validate(time.Second)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duration validation only applies to strings")
+}
+
+func TestDurationValidator_New(t *testing.T) {
+	min, max := time.Second, time.Hour
+	testValidatorNew(t, &DurationValidator{}, map[string]string{}, (*time.Duration)(nil), "Min")
+	testValidatorNew(t, &DurationValidator{}, map[string]string{"min": "1s", "max": "1h"}, &min, "Min")
+	testValidatorNew(t, &DurationValidator{}, map[string]string{"min": "1s", "max": "1h"}, &max, "Max")
+	testValidatorNewError(t, &DurationValidator{}, map[string]string{"max": "soon"}, "invalid duration max value: soon")
+	testValidatorNewError(t, &DurationValidator{}, map[string]string{"min": "1h", "max": "1m"}, "duration min must not exceed max")
+}
+
+func TestDurationValidator_Key(t *testing.T) {
+	testValidatorKey(t, &DurationValidator{}, "duration")
+}
+
+func TestValidate_DurationTag(t *testing.T) {
+	type Config struct {
+		Timeout string `validate:"duration:min=1s,max=5m"`
+	}
+
+	assert.True(t, Validate(Config{Timeout: "30s"}).IsValid)
+
+	result := Validate(Config{Timeout: "10m"})
+	require.False(t, result.IsValid)
+	assert.Equal(t, "duration", result.Errors[0].Rule)
+}
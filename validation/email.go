@@ -1,13 +1,51 @@
 package validation
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
 	"reflect"
 	"regexp"
+	"strings"
+	"time"
 )
 
-// EmailValidator validates email format
-type EmailValidator struct{}
+const (
+	// emailModeMX enables the MX record check, e.g. `validate:"email:mx"`
+	emailModeMX = "mx"
+	// mxTimeoutParam overrides DefaultMXTimeout, e.g. `validate:"email:mx,timeout=1s"`
+	mxTimeoutParam = "timeout"
+
+	// DefaultMXTimeout bounds the MX lookup performed by the email validator in mx mode
+	DefaultMXTimeout = 3 * time.Second
+)
+
+// MXResolver looks up the MX records of a domain. *net.Resolver implements it.
+type MXResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+}
+
+// mxResolver is the resolver used by email validators created from tags
+var mxResolver MXResolver = net.DefaultResolver
+
+// SetMXResolver sets the resolver used by `email:mx` rules. Passing nil restores
+// net.DefaultResolver. Like validator registration, call it during initialization.
+func SetMXResolver(r MXResolver) {
+	if r == nil {
+		r = net.DefaultResolver
+	}
+	mxResolver = r
+}
+
+// EmailValidator validates email format.
+// When CheckMX is set, it also requires the domain to publish at least one MX record,
+// looked up through Resolver within Timeout.
+type EmailValidator struct {
+	CheckMX  bool
+	Resolver MXResolver
+	Timeout  time.Duration
+}
 
 func (v *EmailValidator) Validate(value any) error {
 	val := reflect.ValueOf(value)
@@ -20,13 +58,60 @@ func (v *EmailValidator) Validate(value any) error {
 	if !emailRegex.MatchString(email) {
 		return fmt.Errorf("invalid email format")
 	}
+	if v.CheckMX {
+		return v.checkMX(email[strings.LastIndex(email, "@")+1:])
+	}
+	return nil
+}
+
+// checkMX fails unless domain has an MX record accepting mail. A lone null MX
+// (host ".", RFC 7505) means the domain explicitly accepts no mail.
+func (v *EmailValidator) checkMX(domain string) error {
+	resolver := v.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	timeout := v.Timeout
+	if timeout <= 0 {
+		timeout = DefaultMXTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	records, err := resolver.LookupMX(ctx, domain)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return fmt.Errorf("email domain has no MX records")
+		}
+		return fmt.Errorf("could not verify email domain: %w", err)
+	}
+	if len(records) == 0 || (len(records) == 1 && records[0].Host == ".") {
+		return fmt.Errorf("email domain has no MX records")
+	}
 	return nil
 }
 
 // New creates a new EmailValidator from parameters
 func (v *EmailValidator) New(params map[string]string) (Validator, error) {
-	// Email validator doesn't need any parameters
-	return &EmailValidator{}, nil
+	mode := params["value"]
+	if mode == "" {
+		return &EmailValidator{}, nil
+	}
+	if mode != emailModeMX {
+		return nil, fmt.Errorf("invalid email mode: %s (expected %s)", mode, emailModeMX)
+	}
+
+	timeout := DefaultMXTimeout
+	if raw := params[mxTimeoutParam]; raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid %s value: %s", mxTimeoutParam, raw)
+		}
+		timeout = d
+	}
+	return &EmailValidator{CheckMX: true, Resolver: mxResolver, Timeout: timeout}, nil
 }
 
 // Key returns the registration key for this validator
@@ -1,7 +1,11 @@
 package validation
 
 import (
+	"context"
+	"errors"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -81,3 +85,107 @@ func TestEmailValidator_Key(t *testing.T) {
 	result := validator.Key()
 	assert.Equal(t, "email", result)
 }
+
+// stubResolver serves MX records from a map so tests never touch the network
+type stubResolver struct {
+	records map[string][]*net.MX
+	err     error
+	lookups []string
+}
+
+func (r *stubResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	r.lookups = append(r.lookups, name)
+	if r.err != nil {
+		return nil, r.err
+	}
+	records, ok := r.records[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return records, nil
+}
+
+func TestEmailValidator_CheckMX(t *testing.T) {
+	resolver := &stubResolver{records: map[string][]*net.MX{
+		"example.com": {{Host: "mx.example.com.", Pref: 10}},
+		"nomail.com":  {{Host: ".", Pref: 0}},
+		"empty.com":   {},
+	}}
+	validator := &EmailValidator{CheckMX: true, Resolver: resolver}
+
+	tests := []struct {
+		name    string
+		email   string
+		wantErr string
+	}{
+		{"has mx", "user@example.com", ""},
+		{"unknown domain", "user@missing.com", "email domain has no MX records"},
+		{"null mx", "user@nomail.com", "email domain has no MX records"},
+		{"no records", "user@empty.com", "email domain has no MX records"},
+		{"bad format skips lookup", "not-an-email", "invalid email format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(tt.email)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+	assert.Equal(t, []string{"example.com", "missing.com", "nomail.com", "empty.com"}, resolver.lookups)
+}
+
+func TestEmailValidator_CheckMX_LookupError(t *testing.T) {
+	lookupErr := errors.New("connection refused")
+	validator := &EmailValidator{CheckMX: true, Resolver: &stubResolver{err: lookupErr}}
+
+	err := validator.Validate("user@example.com")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, lookupErr)
+	assert.Contains(t, err.Error(), "could not verify email domain")
+}
+
+func TestEmailValidator_NewMX(t *testing.T) {
+	resolver := &stubResolver{}
+	SetMXResolver(resolver)
+	defer SetMXResolver(nil)
+
+	result, err := (&EmailValidator{}).New(map[string]string{"value": "mx", "timeout": "1s"})
+	require.NoError(t, err)
+	emailValidator := result.(*EmailValidator)
+	assert.True(t, emailValidator.CheckMX)
+	assert.Equal(t, time.Second, emailValidator.Timeout)
+	assert.Same(t, resolver, emailValidator.Resolver)
+
+	_, err = (&EmailValidator{}).New(map[string]string{"value": "smtp"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid email mode")
+
+	_, err = (&EmailValidator{}).New(map[string]string{"value": "mx", "timeout": "soon"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid timeout value")
+}
+
+func TestValidate_EmailMXTag(t *testing.T) {
+	SetMXResolver(&stubResolver{records: map[string][]*net.MX{
+		"example.com": {{Host: "mx.example.com.", Pref: 10}},
+	}})
+	defer SetMXResolver(nil)
+
+	type signup struct {
+		Email   string `validate:"required,email:mx,timeout=500ms"`
+		Contact string `validate:"email"`
+	}
+
+	assert.True(t, Validate(signup{Email: "user@example.com", Contact: "user@missing.com"}).IsValid)
+
+	result := Validate(signup{Email: "user@missing.com", Contact: "user@example.com"})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "Email", result.Errors[0].Field)
+	assert.Equal(t, "email domain has no MX records", result.Errors[0].Message)
+}
@@ -0,0 +1,83 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// EqValidator validates that a value equals a constant, e.g. `validate:"eq=active"` or `validate:"eq:active"`.
+// Values are compared as strings; numbers also match numerically equal constants ("1.0" for 1).
+type EqValidator struct {
+	Value string
+}
+
+func (v *EqValidator) Validate(value any) error {
+	if !equalsConstant(value, v.Value) {
+		return fmt.Errorf("value must equal %s", v.Value)
+	}
+	return nil
+}
+
+// New creates a new EqValidator from parameters
+func (v *EqValidator) New(params map[string]string) (Validator, error) {
+	constant := params["value"]
+	if constant == "" {
+		return nil, fmt.Errorf("eq validation requires a value parameter")
+	}
+	return &EqValidator{Value: constant}, nil
+}
+
+// Key returns the registration key for this validator
+func (v *EqValidator) Key() string {
+	return "eq"
+}
+
+// NeValidator validates that a value differs from a constant, e.g. `validate:"ne=deleted"`.
+// It uses the same comparison as EqValidator.
+type NeValidator struct {
+	Value string
+}
+
+func (v *NeValidator) Validate(value any) error {
+	if equalsConstant(value, v.Value) {
+		return fmt.Errorf("value must not equal %s", v.Value)
+	}
+	return nil
+}
+
+// New creates a new NeValidator from parameters
+func (v *NeValidator) New(params map[string]string) (Validator, error) {
+	constant := params["value"]
+	if constant == "" {
+		return nil, fmt.Errorf("ne validation requires a value parameter")
+	}
+	return &NeValidator{Value: constant}, nil
+}
+
+// Key returns the registration key for this validator
+func (v *NeValidator) Key() string {
+	return "ne"
+}
+
+// equalsConstant reports whether value's string form equals constant, or, for numeric
+// values, whether constant parses to the same number.
+func equalsConstant(value any, constant string) bool {
+	if fmt.Sprintf("%v", value) == constant {
+		return true
+	}
+	val := reflect.ValueOf(value)
+	var actual float64
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		actual = float64(val.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		actual = float64(val.Uint())
+	case reflect.Float32, reflect.Float64:
+		actual = val.Float()
+	default:
+		return false
+	}
+	expected, err := strconv.ParseFloat(constant, 64)
+	return err == nil && actual == expected
+}
@@ -0,0 +1,101 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEqValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		constant string
+		value    any
+		wantErr  bool
+	}{
+		{"matching string", "active", "active", false},
+		{"different string", "active", "deleted", true},
+		{"case sensitive", "active", "Active", true},
+		{"matching int", "3", 3, false},
+		{"different int", "3", 4, true},
+		{"uint", "7", uint8(7), false},
+		{"float equals int constant", "2", 2.0, false},
+		{"int equals float constant", "1.0", 1, false},
+		{"different float", "1.5", 1.25, true},
+		{"numeric constant against string", "1.0", "1", true},
+		{"bool", "true", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&EqValidator{Value: tt.constant}).Validate(tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "value must equal "+tt.constant)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNeValidator_Validate(t *testing.T) {
+	validator := &NeValidator{Value: "deleted"}
+	require.NoError(t, validator.Validate("active"))
+	err := validator.Validate("deleted")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "value must not equal deleted")
+
+	numeric := &NeValidator{Value: "0"}
+	require.NoError(t, numeric.Validate(5))
+	require.Error(t, numeric.Validate(0.0))
+}
+
+func TestEqNeValidator_New(t *testing.T) {
+	testValidatorNew(t, &EqValidator{}, map[string]string{"value": "active"}, "active", "Value")
+	testValidatorNew(t, &NeValidator{}, map[string]string{"value": "deleted"}, "deleted", "Value")
+	testValidatorNewError(t, &EqValidator{}, map[string]string{}, "eq validation requires a value parameter")
+	testValidatorNewError(t, &NeValidator{}, map[string]string{}, "ne validation requires a value parameter")
+}
+
+func TestEqNeValidator_Key(t *testing.T) {
+	testValidatorKey(t, &EqValidator{}, "eq")
+	testValidatorKey(t, &NeValidator{}, "ne")
+}
+
+func TestEqNeValidator_Tags(t *testing.T) {
+	type account struct {
+		Status  string `validate:"eq:active"`
+		State   string `validate:"ne:deleted"`
+		Version int    `validate:"eq:2"`
+	}
+
+	require.True(t, Validate(account{Status: "active", State: "suspended", Version: 2}).IsValid)
+
+	result := Validate(account{Status: "pending", State: "deleted", Version: 1})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 3)
+	assert.Equal(t, "eq", result.Errors[0].Rule)
+	assert.Equal(t, "ne", result.Errors[1].Rule)
+	assert.Equal(t, map[string]string{"value": "2"}, result.Errors[2].Params)
+}
+
+func TestEqNeValidator_EqualsFormTags(t *testing.T) {
+	type account struct {
+		Status string `validate:"eq=active"`
+		State  string `validate:"required,ne=deleted"`
+		Plan   string `validate:"required,eq=pro,msg=only pro accounts"`
+	}
+
+	require.True(t, Validate(account{Status: "active", State: "suspended", Plan: "pro"}).IsValid)
+
+	result := Validate(account{Status: "pending", State: "deleted", Plan: "free"})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 3)
+	assert.Equal(t, "Status", result.Errors[0].Field)
+	assert.Equal(t, "eq", result.Errors[0].Rule)
+	assert.Equal(t, "State", result.Errors[1].Field)
+	assert.Equal(t, "ne", result.Errors[1].Rule)
+	assert.Equal(t, "only pro accounts", result.Errors[2].Message)
+}
@@ -4,10 +4,15 @@ import "fmt"
 
 // Error represents a single validation error
 type Error struct {
-	Field   string
-	Rule    string
-	Message string
-	Value   any
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	// Value is the rejected value. It is never serialized, so secrets such as passwords
+	// or card numbers that fail a rule do not leak into API responses or JSON logs.
+	Value any `json:"-"`
+	// Params holds the failed rule's tag parameters (e.g. "value" for min:5), excluding msg,
+	// so clients can build localized messages without parsing Message.
+	Params map[string]string `json:"params,omitempty"`
 }
 
 // NewValidationError creates a new ValidationError instance
@@ -0,0 +1,58 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+
+	"core/ids"
+)
+
+// UUIDValidator validates UUID format using the ids package
+type UUIDValidator struct{}
+
+func (v *UUIDValidator) Validate(value any) error {
+	val := reflect.ValueOf(value)
+	if val.Kind() != reflect.String {
+		return fmt.Errorf("uuid validation only applies to strings")
+	}
+
+	if !ids.IsUUID(val.String()) {
+		return fmt.Errorf("invalid UUID format")
+	}
+	return nil
+}
+
+// New creates a new UUIDValidator from parameters
+func (v *UUIDValidator) New(params map[string]string) (Validator, error) {
+	return &UUIDValidator{}, nil
+}
+
+// Key returns the registration key for this validator
+func (v *UUIDValidator) Key() string {
+	return "uuid"
+}
+
+// ULIDValidator validates ULID format using the ids package
+type ULIDValidator struct{}
+
+func (v *ULIDValidator) Validate(value any) error {
+	val := reflect.ValueOf(value)
+	if val.Kind() != reflect.String {
+		return fmt.Errorf("ulid validation only applies to strings")
+	}
+
+	if !ids.IsULID(val.String()) {
+		return fmt.Errorf("invalid ULID format")
+	}
+	return nil
+}
+
+// New creates a new ULIDValidator from parameters
+func (v *ULIDValidator) New(params map[string]string) (Validator, error) {
+	return &ULIDValidator{}, nil
+}
+
+// Key returns the registration key for this validator
+func (v *ULIDValidator) Key() string {
+	return "ulid"
+}
@@ -0,0 +1,116 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"core/ids"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUUIDValidator_Validate(t *testing.T) {
+	generated, err := ids.NewUUID()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		wantErr bool
+	}{
+		{"generated uuid", generated, false},
+		{"must uuid", ids.MustUUID(), false},
+		{"uuid v7", ids.MustUUIDv7(), false},
+		{"uppercase uuid", "3F2B8C1E-9D4A-4B7E-8F10-2A6C5D9E0B1F", false},
+		{"wrong version", "3f2b8c1e-9d4a-1b7e-8f10-2a6c5d9e0b1f", true},
+		{"wrong variant", "3f2b8c1e-9d4a-4b7e-cf10-2a6c5d9e0b1f", true},
+		{"missing hyphens", "3f2b8c1e9d4a4b7e8f102a6c5d9e0b1f", true},
+		{"non-hex chars", "3f2b8c1e-9d4a-4b7e-8f10-2a6c5d9e0bzz", true},
+		{"ulid", ids.MustULID(), true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &UUIDValidator{}
+			err := validator.Validate(tt.value)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid UUID format")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUUIDValidator_Validate_NonStringTypes(t *testing.T) {
+	err := (&UUIDValidator{}).Validate(123)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "uuid validation only applies to strings")
+}
+
+func TestUUIDValidator_Key(t *testing.T) {
+	testValidatorKey(t, &UUIDValidator{}, "uuid")
+}
+
+func TestULIDValidator_Validate(t *testing.T) {
+	epoch, err := ids.NewULID(time.Unix(0, 0))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		wantErr bool
+	}{
+		{"epoch ulid", epoch, false},
+		{"must ulid", ids.MustULID(), false},
+		{"lowercase ulid", "01arz3ndektsv4rrffq69g5fav", false},
+		{"too short", "01ARZ3NDEKTSV4RRFFQ69G5FA", true},
+		{"invalid char I", "01ARZ3NDEKTSV4RRFFQ69G5FAI", true},
+		{"invalid char U", "01ARZ3NDEKTSV4RRFFQ69G5FAU", true},
+		{"uuid", ids.MustUUID(), true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &ULIDValidator{}
+			err := validator.Validate(tt.value)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid ULID format")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestULIDValidator_Validate_NonStringTypes(t *testing.T) {
+	err := (&ULIDValidator{}).Validate([]byte("01ARZ3NDEKTSV4RRFFQ69G5FAV"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ulid validation only applies to strings")
+}
+
+func TestULIDValidator_Key(t *testing.T) {
+	testValidatorKey(t, &ULIDValidator{}, "ulid")
+}
+
+func TestIdentifierValidators_Tag(t *testing.T) {
+	type request struct {
+		UserID  string `validate:"required,uuid"`
+		EventID string `validate:"ulid"`
+	}
+
+	assert.True(t, Validate(request{UserID: ids.MustUUID(), EventID: ids.MustULID()}).IsValid)
+
+	result := Validate(request{UserID: "user-1", EventID: "event-1"})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, "uuid", result.Errors[0].Rule)
+	assert.Equal(t, "ulid", result.Errors[1].Rule)
+}
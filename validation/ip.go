@@ -0,0 +1,85 @@
+package validation
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+)
+
+// IP versions accepted by IPValidator
+const (
+	IPVersionAny = ""
+	IPVersion4   = "v4"
+	IPVersion6   = "v6"
+)
+
+// IPValidator validates IP address format, optionally restricted to a single version
+type IPValidator struct {
+	Version string
+}
+
+func (v *IPValidator) Validate(value any) error {
+	val := reflect.ValueOf(value)
+	if val.Kind() != reflect.String {
+		return fmt.Errorf("ip validation only applies to strings")
+	}
+
+	ip := net.ParseIP(val.String())
+	if ip == nil {
+		return fmt.Errorf("invalid IP address format")
+	}
+
+	isV4 := ip.To4() != nil
+	switch v.Version {
+	case IPVersion4:
+		if !isV4 {
+			return fmt.Errorf("value must be an IPv4 address")
+		}
+	case IPVersion6:
+		if isV4 {
+			return fmt.Errorf("value must be an IPv6 address")
+		}
+	}
+	return nil
+}
+
+// New creates a new IPValidator from parameters
+func (v *IPValidator) New(params map[string]string) (Validator, error) {
+	version := params["value"]
+	switch version {
+	case IPVersionAny, IPVersion4, IPVersion6:
+		return &IPValidator{Version: version}, nil
+	default:
+		return nil, fmt.Errorf("invalid ip version: %s", version)
+	}
+}
+
+// Key returns the registration key for this validator
+func (v *IPValidator) Key() string {
+	return "ip"
+}
+
+// CIDRValidator validates CIDR notation (e.g., "10.0.0.0/8")
+type CIDRValidator struct{}
+
+func (v *CIDRValidator) Validate(value any) error {
+	val := reflect.ValueOf(value)
+	if val.Kind() != reflect.String {
+		return fmt.Errorf("cidr validation only applies to strings")
+	}
+
+	if _, _, err := net.ParseCIDR(val.String()); err != nil {
+		return fmt.Errorf("invalid CIDR format")
+	}
+	return nil
+}
+
+// New creates a new CIDRValidator from parameters
+func (v *CIDRValidator) New(params map[string]string) (Validator, error) {
+	return &CIDRValidator{}, nil
+}
+
+// Key returns the registration key for this validator
+func (v *CIDRValidator) Key() string {
+	return "cidr"
+}
@@ -0,0 +1,122 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		value   interface{}
+		wantErr string
+	}{
+		{"valid ipv4", IPVersionAny, "192.168.1.1", ""},
+		{"valid ipv6", IPVersionAny, "2001:db8::1", ""},
+		{"valid ipv6 loopback", IPVersionAny, "::1", ""},
+		{"invalid ipv4 octet", IPVersionAny, "256.1.1.1", "invalid IP address format"},
+		{"invalid ipv4 short", IPVersionAny, "10.0.0", "invalid IP address format"},
+		{"invalid ipv6", IPVersionAny, "2001:db8::g", "invalid IP address format"},
+		{"hostname", IPVersionAny, "localhost", "invalid IP address format"},
+		{"empty", IPVersionAny, "", "invalid IP address format"},
+		{"v4 only accepts ipv4", IPVersion4, "10.0.0.1", ""},
+		{"v4 only rejects ipv6", IPVersion4, "2001:db8::1", "value must be an IPv4 address"},
+		{"v6 only accepts ipv6", IPVersion6, "fe80::1", ""},
+		{"v6 only rejects ipv4", IPVersion6, "10.0.0.1", "value must be an IPv6 address"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &IPValidator{Version: tt.version}
+			err := validator.Validate(tt.value)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIPValidator_Validate_NonStringTypes(t *testing.T) {
+	validator := &IPValidator{}
+
+	for _, value := range []interface{}{123, []byte{127, 0, 0, 1}, nil} {
+		err := validator.Validate(value)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ip validation only applies to strings")
+	}
+}
+
+func TestIPValidator_New(t *testing.T) {
+	validator := &IPValidator{}
+
+	testValidatorNew(t, validator, map[string]string{}, IPVersionAny, "Version")
+	testValidatorNew(t, validator, map[string]string{"value": "v4"}, IPVersion4, "Version")
+	testValidatorNew(t, validator, map[string]string{"value": "v6"}, IPVersion6, "Version")
+	testValidatorNewError(t, validator, map[string]string{"value": "v5"}, "invalid ip version: v5")
+}
+
+func TestIPValidator_Key(t *testing.T) {
+	testValidatorKey(t, &IPValidator{}, "ip")
+}
+
+func TestCIDRValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		wantErr bool
+	}{
+		{"valid ipv4 cidr", "10.0.0.0/8", false},
+		{"valid ipv4 host cidr", "192.168.1.1/32", false},
+		{"valid ipv6 cidr", "2001:db8::/32", false},
+		{"missing prefix", "10.0.0.0", true},
+		{"prefix too large", "10.0.0.0/33", true},
+		{"invalid address", "300.0.0.0/8", true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &CIDRValidator{}
+			err := validator.Validate(tt.value)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid CIDR format")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCIDRValidator_Validate_NonStringTypes(t *testing.T) {
+	err := (&CIDRValidator{}).Validate(8)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cidr validation only applies to strings")
+}
+
+func TestCIDRValidator_Key(t *testing.T) {
+	testValidatorKey(t, &CIDRValidator{}, "cidr")
+}
+
+func TestIPValidator_Tag(t *testing.T) {
+	type network struct {
+		Gateway string `validate:"ip:v4"`
+		Subnet  string `validate:"cidr"`
+	}
+
+	assert.True(t, Validate(network{Gateway: "10.0.0.1", Subnet: "10.0.0.0/24"}).IsValid)
+
+	result := Validate(network{Gateway: "::1", Subnet: "10.0.0.0"})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, "ip", result.Errors[0].Rule)
+	assert.Equal(t, "cidr", result.Errors[1].Rule)
+}
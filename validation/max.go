@@ -6,9 +6,11 @@ import (
 	"strconv"
 )
 
-// MaxValidator validates maximum values
+// MaxValidator validates maximum values for numbers and maximum length
+// for strings, slices, arrays, and maps. Unsupported types pass unless Strict is set, in which case they produce an error.
 type MaxValidator struct {
-	Max float64
+	Max    float64
+	Strict bool
 }
 
 func (v *MaxValidator) Validate(value any) error {
@@ -31,6 +33,14 @@ func (v *MaxValidator) Validate(value any) error {
 		if float64(len(val.String())) > v.Max {
 			return fmt.Errorf("string length must be at most %v", v.Max)
 		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if float64(val.Len()) > v.Max {
+			return fmt.Errorf("length must be at most %v", v.Max)
+		}
+	default:
+		if v.Strict {
+			return fmt.Errorf("max not applicable to type %s", val.Kind())
+		}
 	}
 	return nil
 }
@@ -45,7 +55,11 @@ func (v *MaxValidator) New(params map[string]string) (Validator, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid max value: %s", maxStr)
 	}
-	return &MaxValidator{Max: maxValue}, nil
+	strict, err := parseBoolParam(params, strictParam)
+	if err != nil {
+		return nil, err
+	}
+	return &MaxValidator{Max: maxValue, Strict: strict}, nil
 }
 
 // Key returns the registration key for this validator
@@ -87,6 +87,36 @@ func TestMaxValidator_Validate_String(t *testing.T) {
 	}
 }
 
+func TestMaxValidator_Validate_Collections(t *testing.T) {
+	tests := []struct {
+		name    string
+		max     float64
+		value   interface{}
+		wantErr bool
+	}{
+		{"small slice", 3, []string{"a"}, false},
+		{"large slice", 2, []string{"a", "b", "c"}, true},
+		{"array", 1, [2]int{1, 2}, true},
+		{"map at cap", 5, map[int]bool{1: true, 2: true, 3: true, 4: true, 5: true}, false},
+		{"map over cap", 5, map[int]bool{1: true, 2: true, 3: true, 4: true, 5: true, 6: true}, true},
+		{"empty map", 5, map[int]bool{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &MaxValidator{Max: tt.max}
+			err := validator.Validate(tt.value)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "length must be at most")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestMaxValidator_Validate_UnsupportedTypes(t *testing.T) {
 	validator := &MaxValidator{Max: 10}
 
@@ -95,9 +125,8 @@ func TestMaxValidator_Validate_UnsupportedTypes(t *testing.T) {
 		value interface{}
 	}{
 		{"bool", true},
-		{"slice", []int{1, 2, 3}},
-		{"map", map[string]int{"a": 1}},
 		{"struct", struct{}{}},
+		{"pointer", new(int)},
 	}
 
 	for _, tt := range tests {
@@ -109,6 +138,45 @@ func TestMaxValidator_Validate_UnsupportedTypes(t *testing.T) {
 	}
 }
 
+func TestMaxValidator_Validate_UnsupportedTypes_Strict(t *testing.T) {
+	validator := &MaxValidator{Max: 10, Strict: true}
+
+	tests := []struct {
+		name  string
+		value interface{}
+		kind  string
+	}{
+		{"bool", true, "bool"},
+		{"struct", struct{}{}, "struct"},
+		{"pointer", new(int), "ptr"},
+		{"channel", make(chan int), "chan"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(tt.value)
+			require.Error(t, err)
+			assert.Equal(t, "max not applicable to type "+tt.kind, err.Error())
+		})
+	}
+}
+
+func TestMaxValidator_New_Strict(t *testing.T) {
+	validator := &MaxValidator{}
+
+	result, err := validator.New(map[string]string{"value": "10", "strict": "true"})
+	require.NoError(t, err)
+	assert.True(t, result.(*MaxValidator).Strict)
+
+	result, err = validator.New(map[string]string{"value": "10"})
+	require.NoError(t, err)
+	assert.False(t, result.(*MaxValidator).Strict)
+
+	_, err = validator.New(map[string]string{"value": "10", "strict": "maybe"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid strict value: maybe")
+}
+
 func TestMaxValidator_New(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -6,9 +6,11 @@ import (
 	"strconv"
 )
 
-// MinValidator validates minimum values
+// MinValidator validates minimum values for numbers and minimum length
+// for strings, slices, arrays, and maps. Unsupported types pass unless Strict is set, in which case they produce an error.
 type MinValidator struct {
-	Min float64
+	Min    float64
+	Strict bool
 }
 
 func (v *MinValidator) Validate(value any) error {
@@ -31,6 +33,14 @@ func (v *MinValidator) Validate(value any) error {
 		if float64(len(val.String())) < v.Min {
 			return fmt.Errorf("string length must be at least %v", v.Min)
 		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if float64(val.Len()) < v.Min {
+			return fmt.Errorf("length must be at least %v", v.Min)
+		}
+	default:
+		if v.Strict {
+			return fmt.Errorf("min not applicable to type %s", val.Kind())
+		}
 	}
 	return nil
 }
@@ -45,7 +55,11 @@ func (v *MinValidator) New(params map[string]string) (Validator, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid min value: %s", minStr)
 	}
-	return &MinValidator{Min: minValue}, nil
+	strict, err := parseBoolParam(params, strictParam)
+	if err != nil {
+		return nil, err
+	}
+	return &MinValidator{Min: minValue, Strict: strict}, nil
 }
 
 // Key returns the registration key for this validator
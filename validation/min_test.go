@@ -87,6 +87,36 @@ func TestMinValidator_Validate_String(t *testing.T) {
 	}
 }
 
+func TestMinValidator_Validate_Collections(t *testing.T) {
+	tests := []struct {
+		name    string
+		min     float64
+		value   interface{}
+		wantErr bool
+	}{
+		{"non-empty slice", 1, []string{"a"}, false},
+		{"empty slice", 1, []string{}, true},
+		{"nil slice", 1, []string(nil), true},
+		{"array", 3, [2]int{1, 2}, true},
+		{"map at minimum", 2, map[string]int{"a": 1, "b": 2}, false},
+		{"small map", 2, map[string]int{"a": 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &MinValidator{Min: tt.min}
+			err := validator.Validate(tt.value)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "length must be at least")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestMinValidator_Validate_UnsupportedTypes(t *testing.T) {
 	validator := &MinValidator{Min: 10}
 
@@ -95,9 +125,8 @@ func TestMinValidator_Validate_UnsupportedTypes(t *testing.T) {
 		value interface{}
 	}{
 		{"bool", true},
-		{"slice", []int{1, 2, 3}},
-		{"map", map[string]int{"a": 1}},
 		{"struct", struct{}{}},
+		{"pointer", new(int)},
 	}
 
 	for _, tt := range tests {
@@ -109,6 +138,45 @@ func TestMinValidator_Validate_UnsupportedTypes(t *testing.T) {
 	}
 }
 
+func TestMinValidator_Validate_UnsupportedTypes_Strict(t *testing.T) {
+	validator := &MinValidator{Min: 10, Strict: true}
+
+	tests := []struct {
+		name  string
+		value interface{}
+		kind  string
+	}{
+		{"bool", true, "bool"},
+		{"struct", struct{}{}, "struct"},
+		{"pointer", new(int), "ptr"},
+		{"channel", make(chan int), "chan"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(tt.value)
+			require.Error(t, err)
+			assert.Equal(t, "min not applicable to type "+tt.kind, err.Error())
+		})
+	}
+}
+
+func TestMinValidator_New_Strict(t *testing.T) {
+	validator := &MinValidator{}
+
+	result, err := validator.New(map[string]string{"value": "10", "strict": "true"})
+	require.NoError(t, err)
+	assert.True(t, result.(*MinValidator).Strict)
+
+	result, err = validator.New(map[string]string{"value": "10"})
+	require.NoError(t, err)
+	assert.False(t, result.(*MinValidator).Strict)
+
+	_, err = validator.New(map[string]string{"value": "10", "strict": "maybe"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid strict value: maybe")
+}
+
 func TestMinValidator_New(t *testing.T) {
 	tests := []struct {
 		name    string
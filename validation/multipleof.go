@@ -0,0 +1,77 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// multipleOfEpsilon is the tolerance used when checking float remainders
+const multipleOfEpsilon = 1e-9
+
+// MultipleOfValidator validates that a numeric value is a multiple of Step
+type MultipleOfValidator struct {
+	Step float64
+}
+
+func (v *MultipleOfValidator) Validate(value any) error {
+	val := reflect.ValueOf(value)
+
+	var isMultiple bool
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.isIntegralStep() {
+			isMultiple = val.Int()%int64(v.Step) == 0
+		} else {
+			isMultiple = isFloatMultiple(float64(val.Int()), v.Step)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.isIntegralStep() {
+			isMultiple = val.Uint()%uint64(math.Abs(v.Step)) == 0
+		} else {
+			isMultiple = isFloatMultiple(float64(val.Uint()), v.Step)
+		}
+	case reflect.Float32, reflect.Float64:
+		isMultiple = isFloatMultiple(val.Float(), v.Step)
+	default:
+		return fmt.Errorf("multipleof validation only applies to numeric types")
+	}
+
+	if !isMultiple {
+		return fmt.Errorf("value must be a multiple of %v", v.Step)
+	}
+	return nil
+}
+
+// isIntegralStep reports whether Step can be used for exact integer arithmetic
+func (v *MultipleOfValidator) isIntegralStep() bool {
+	return v.Step == math.Trunc(v.Step)
+}
+
+// isFloatMultiple checks value/step is integral within a relative epsilon
+func isFloatMultiple(value, step float64) bool {
+	quotient := value / step
+	return math.Abs(quotient-math.Round(quotient)) <= multipleOfEpsilon*math.Max(1, math.Abs(quotient))
+}
+
+// New creates a new MultipleOfValidator from parameters
+func (v *MultipleOfValidator) New(params map[string]string) (Validator, error) {
+	stepStr := params["value"]
+	if stepStr == "" {
+		return nil, fmt.Errorf("multipleof validation requires a value parameter")
+	}
+	step, err := strconv.ParseFloat(stepStr, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid multipleof value: %s", stepStr)
+	}
+	if step == 0 {
+		return nil, fmt.Errorf("multipleof value must not be zero")
+	}
+	return &MultipleOfValidator{Step: step}, nil
+}
+
+// Key returns the registration key for this validator
+func (v *MultipleOfValidator) Key() string {
+	return "multipleof"
+}
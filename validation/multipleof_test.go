@@ -0,0 +1,110 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultipleOfValidator_Validate_Integers(t *testing.T) {
+	tests := []struct {
+		name    string
+		step    float64
+		value   interface{}
+		wantErr bool
+	}{
+		{"int multiple", 5, 15, false},
+		{"int zero", 5, 0, false},
+		{"int not multiple", 5, 12, true},
+		{"negative int multiple", 5, -10, false},
+		{"int64 multiple", 5, int64(25), false},
+		{"int8 not multiple", 5, int8(7), true},
+		{"uint multiple", 5, uint(20), false},
+		{"uint not multiple", 5, uint(21), true},
+		{"uint negative step", -5, uint(20), false},
+		{"int fractional step", 2.5, 5, false},
+		{"int fractional step not multiple", 2.5, 6, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &MultipleOfValidator{Step: tt.step}
+			err := validator.Validate(tt.value)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "value must be a multiple of")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMultipleOfValidator_Validate_Floats(t *testing.T) {
+	tests := []struct {
+		name    string
+		step    float64
+		value   interface{}
+		wantErr bool
+	}{
+		{"exact multiple", 0.5, 2.5, false},
+		{"rounding noise within tolerance", 0.1, 0.1 + 0.2, false},
+		{"large multiple", 0.01, 12345.67, false},
+		{"float32 multiple", 0.25, float32(1.75), false},
+		{"not multiple", 0.5, 2.3, true},
+		{"negative multiple", 0.5, -1.5, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &MultipleOfValidator{Step: tt.step}
+			err := validator.Validate(tt.value)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "value must be a multiple of")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMultipleOfValidator_Validate_NonNumericTypes(t *testing.T) {
+	validator := &MultipleOfValidator{Step: 5}
+
+	for _, value := range []interface{}{"10", true, []int{5}} {
+		err := validator.Validate(value)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "multipleof validation only applies to numeric types")
+	}
+}
+
+func TestMultipleOfValidator_New(t *testing.T) {
+	validator := &MultipleOfValidator{}
+
+	testValidatorNew(t, validator, map[string]string{"value": "5"}, 5.0, "Step")
+	testValidatorNew(t, validator, map[string]string{"value": "0.25"}, 0.25, "Step")
+
+	testValidatorNewError(t, validator, map[string]string{}, "multipleof validation requires a value parameter")
+	testValidatorNewError(t, validator, map[string]string{"value": "abc"}, "invalid multipleof value: abc")
+	testValidatorNewError(t, validator, map[string]string{"value": "0"}, "multipleof value must not be zero")
+}
+
+func TestMultipleOfValidator_Key(t *testing.T) {
+	testValidatorKey(t, &MultipleOfValidator{}, "multipleof")
+}
+
+func TestMultipleOfValidator_Tag(t *testing.T) {
+	type order struct {
+		Quantity int `validate:"multipleof:5"`
+	}
+
+	assert.True(t, Validate(order{Quantity: 10}).IsValid)
+
+	result := Validate(order{Quantity: 7})
+	require.False(t, result.IsValid)
+	assert.Equal(t, "multipleof", result.Errors[0].Rule)
+}
@@ -0,0 +1,33 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// NotBlankValidator validates that a string contains something other than whitespace.
+// Unlike required, it rejects strings such as " " or "\t\n".
+type NotBlankValidator struct{}
+
+func (v *NotBlankValidator) Validate(value any) error {
+	val := reflect.ValueOf(value)
+	if val.Kind() != reflect.String {
+		return fmt.Errorf("notblank validation only applies to strings")
+	}
+
+	if strings.TrimSpace(val.String()) == "" {
+		return fmt.Errorf("field must not be blank")
+	}
+	return nil
+}
+
+// New creates a new NotBlankValidator from parameters
+func (v *NotBlankValidator) New(params map[string]string) (Validator, error) {
+	return &NotBlankValidator{}, nil
+}
+
+// Key returns the registration key for this validator
+func (v *NotBlankValidator) Key() string {
+	return "notblank"
+}
@@ -0,0 +1,67 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotBlankValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"non-blank", "alice", false},
+		{"padded non-blank", "  alice\t", false},
+		{"empty", "", true},
+		{"spaces", "   ", true},
+		{"tabs", "\t\t", true},
+		{"newlines", "\n\r\n", true},
+		{"mixed whitespace", " \t\n ", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&NotBlankValidator{}).Validate(tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "field must not be blank")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNotBlankValidator_ContrastsWithRequired(t *testing.T) {
+	for _, value := range []string{" ", "\t", "\n"} {
+		assert.NoError(t, (&RequiredValidator{}).Validate(value), "required accepts %q", value)
+		assert.Error(t, (&NotBlankValidator{}).Validate(value), "notblank rejects %q", value)
+	}
+
+	type profile struct {
+		Name     string `validate:"required"`
+		Nickname string `validate:"notblank"`
+	}
+	result := Validate(profile{Name: " ", Nickname: " "})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "Nickname", result.Errors[0].Field)
+	assert.Equal(t, "notblank", result.Errors[0].Rule)
+}
+
+func TestNotBlankValidator_NonString(t *testing.T) {
+	err := (&NotBlankValidator{}).Validate(42)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "notblank validation only applies to strings")
+}
+
+func TestNotBlankValidator_NewAndKey(t *testing.T) {
+	result, err := (&NotBlankValidator{}).New(map[string]string{})
+	require.NoError(t, err)
+	assert.IsType(t, &NotBlankValidator{}, result)
+	testValidatorKey(t, &NotBlankValidator{}, "notblank")
+	assert.True(t, HasValidator("notblank"))
+}
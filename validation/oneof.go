@@ -5,16 +5,19 @@ import (
 	"strings"
 )
 
-// OneOfValidator validates that a value is one of the allowed values
+// OneOfValidator validates that a value is one of the allowed values.
+// Comparison is case sensitive unless CaseInsensitive is set.
 type OneOfValidator struct {
-	AllowedValues []string
+	AllowedValues   []string
+	CaseInsensitive bool
 }
 
 func (v *OneOfValidator) Validate(value any) error {
 	valueStr := fmt.Sprintf("%v", value)
 
 	for _, allowed := range v.AllowedValues {
-		if strings.TrimSpace(allowed) == valueStr {
+		allowed = strings.TrimSpace(allowed)
+		if allowed == valueStr || (v.CaseInsensitive && strings.EqualFold(allowed, valueStr)) {
 			return nil
 		}
 	}
@@ -28,8 +31,12 @@ func (v *OneOfValidator) New(params map[string]string) (Validator, error) {
 	if valuesStr == "" {
 		return nil, fmt.Errorf("oneof validation requires a values parameter")
 	}
+	caseInsensitive, err := parseBoolParam(params, caseInsensitiveParam)
+	if err != nil {
+		return nil, err
+	}
 	allowedValues := strings.Split(valuesStr, "|")
-	return &OneOfValidator{AllowedValues: allowedValues}, nil
+	return &OneOfValidator{AllowedValues: allowedValues, CaseInsensitive: caseInsensitive}, nil
 }
 
 // Key returns the registration key for this validator
@@ -144,6 +144,60 @@ func TestOneOfValidator_Validate_EdgeCases(t *testing.T) {
 	}
 }
 
+func TestOneOfValidator_Validate_CaseInsensitive(t *testing.T) {
+	tests := []struct {
+		name            string
+		allowedValues   []string
+		caseInsensitive bool
+		value           interface{}
+		wantErr         bool
+	}{
+		{"ci lowercase input", []string{"US", "CA", "MX"}, true, "us", false},
+		{"ci mixed case input", []string{"US", "CA", "MX"}, true, "Ca", false},
+		{"ci mixed case allowed values", []string{"Us", "cA"}, true, "US", false},
+		{"ci no match", []string{"US", "CA", "MX"}, true, "fr", true},
+		{"ci whitespace handling", []string{" US ", " CA "}, true, "ca", false},
+		{"non-ci lowercase input", []string{"US", "CA", "MX"}, false, "us", true},
+		{"non-ci exact match", []string{"US", "CA", "MX"}, false, "US", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &OneOfValidator{AllowedValues: tt.allowedValues, CaseInsensitive: tt.caseInsensitive}
+			err := validator.Validate(tt.value)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "value must be one of:")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOneOfValidator_New_CaseInsensitive(t *testing.T) {
+	validator := &OneOfValidator{}
+
+	testValidatorNew(t, validator, map[string]string{"values": "US|CA", "ci": "true"}, true, "CaseInsensitive")
+	testValidatorNew(t, validator, map[string]string{"values": "US|CA"}, false, "CaseInsensitive")
+	testValidatorNewError(t, validator, map[string]string{"values": "US|CA", "ci": "yes please"}, "invalid ci value: yes please")
+}
+
+func TestOneOfValidator_Tag_CaseInsensitive(t *testing.T) {
+	type address struct {
+		Country string `validate:"oneof:values=US|CA|MX,ci=true"`
+		Region  string `validate:"oneof:values=NA|EU"`
+	}
+
+	assert.True(t, Validate(address{Country: "us", Region: "NA"}).IsValid)
+
+	result := Validate(address{Country: "us", Region: "na"})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "Region", result.Errors[0].Field)
+}
+
 func TestOneOfValidator_New(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -4,8 +4,26 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sync"
 )
 
+// compiledPatterns caches compiled regexps keyed by pattern string.
+// Patterns come from struct tags, so the set is small and bounded by the program's types.
+var compiledPatterns sync.Map
+
+// compilePattern returns the cached compiled regexp for pattern, compiling it on first use
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := compiledPatterns.Load(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := compiledPatterns.LoadOrStore(pattern, regex)
+	return actual.(*regexp.Regexp), nil
+}
+
 // RegexpValidator validates string against a regex pattern
 type RegexpValidator struct {
 	Pattern *regexp.Regexp
@@ -29,7 +47,7 @@ func (v *RegexpValidator) New(params map[string]string) (Validator, error) {
 	if pattern == "" {
 		return nil, fmt.Errorf("regexp validation requires a pattern parameter")
 	}
-	regex, err := regexp.Compile(pattern)
+	regex, err := compilePattern(pattern)
 	if err != nil {
 		return nil, fmt.Errorf("invalid regexp pattern: %s", pattern)
 	}
@@ -187,3 +187,48 @@ func TestRegexpValidator_Key(t *testing.T) {
 	result := validator.Key()
 	assert.Equal(t, "regexp", result)
 }
+
+func TestRegexpValidator_New_SharesCompiledPattern(t *testing.T) {
+	params := map[string]string{"pattern": `^[a-z]{3}-\d{4}$`}
+
+	first, err := (&RegexpValidator{}).New(params)
+	require.NoError(t, err)
+	second, err := (&RegexpValidator{}).New(params)
+	require.NoError(t, err)
+
+	assert.Same(t, first.(*RegexpValidator).Pattern, second.(*RegexpValidator).Pattern)
+}
+
+func TestRegexpValidator_New_InvalidPatternNotCached(t *testing.T) {
+	_, err := (&RegexpValidator{}).New(map[string]string{"pattern": "[unclosed"})
+	require.Error(t, err)
+
+	_, cached := compiledPatterns.Load("[unclosed")
+	assert.False(t, cached)
+}
+
+type regexpBenchmarkTarget struct {
+	SKU string `validate:"regexp:pattern=^[A-Z]{2}-[0-9]{6}$"`
+}
+
+func BenchmarkValidate_RegexpField(b *testing.B) {
+	target := regexpBenchmarkTarget{SKU: "AB-123456"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if result := Validate(target); !result.IsValid {
+			b.Fatalf("unexpected errors: %v", result.Errors)
+		}
+	}
+}
+
+func BenchmarkValidate_RegexpField_Uncached(b *testing.B) {
+	target := regexpBenchmarkTarget{SKU: "AB-123456"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		// Simulates the previous behavior of compiling on every rule instantiation
+		compiledPatterns.Delete("^[A-Z]{2}-[0-9]{6}$")
+		if result := Validate(target); !result.IsValid {
+			b.Fatalf("unexpected errors: %v", result.Errors)
+		}
+	}
+}
@@ -1,16 +1,21 @@
 package validation
 
-import "fmt"
+import (
+	"fmt"
+	"reflect"
+)
 
 // validatorRegistry holds all available validators and provides methods for managing them
 type validatorRegistry struct {
-	validators map[string]Validator
+	validators       map[string]Validator
+	structValidators map[reflect.Type][]StructValidatorFunc
 }
 
 // newValidatorRegistry creates a new validator registry with built-in validators (internal use)
 func newValidatorRegistry() *validatorRegistry {
 	registry := &validatorRegistry{
-		validators: make(map[string]Validator),
+		validators:       make(map[string]Validator),
+		structValidators: make(map[reflect.Type][]StructValidatorFunc),
 	}
 
 	registry.registerBuiltInValidators()
@@ -20,14 +25,24 @@ func newValidatorRegistry() *validatorRegistry {
 // registerBuiltInValidators registers all the built-in validators
 func (r *validatorRegistry) registerBuiltInValidators() {
 	r.registerValidator(&RequiredValidator{})
+	r.registerValidator(&NotBlankValidator{})
 	r.registerValidator(&EmailValidator{})
 	r.registerValidator(&URLValidator{})
+	r.registerValidator(&IPValidator{})
+	r.registerValidator(&CIDRValidator{})
+	r.registerValidator(&UUIDValidator{})
+	r.registerValidator(&ULIDValidator{})
 
 	r.registerValidator(&MinValidator{})
 	r.registerValidator(&MaxValidator{})
 	r.registerValidator(&LenValidator{})
 	r.registerValidator(&OneOfValidator{})
+	r.registerValidator(&EqValidator{})
+	r.registerValidator(&NeValidator{})
 	r.registerValidator(&RegexpValidator{})
+	r.registerValidator(&MultipleOfValidator{})
+	r.registerValidator(&ByteSizeValidator{})
+	r.registerValidator(&DurationValidator{})
 
 	r.registerValidator(&ComparisonValidator{Operator: ">"})
 	r.registerValidator(&ComparisonValidator{Operator: "<"})
@@ -37,7 +52,25 @@ func (r *validatorRegistry) registerBuiltInValidators() {
 
 // registerValidator adds a validator to the registry using its own Key() method (internal use)
 func (r *validatorRegistry) registerValidator(validator Validator) {
-	r.validators[validator.Key()] = validator
+	r.registerValidatorAs(validator.Key(), validator)
+}
+
+// registerValidatorAs adds a validator to the registry under name instead of its Key() (internal use)
+func (r *validatorRegistry) registerValidatorAs(name string, validator Validator) {
+	r.validators[name] = validator
+}
+
+// registerStructValidator adds a struct-level validator for the struct type of typ (internal use).
+// Pointer types are dereferenced so T{} and &T{} register the same type.
+func (r *validatorRegistry) registerStructValidator(typ any, fn StructValidatorFunc) {
+	t := reflect.TypeOf(typ)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct || fn == nil {
+		return
+	}
+	r.structValidators[t] = append(r.structValidators[t], fn)
 }
 
 // hasValidator checks if a validator exists (internal use)
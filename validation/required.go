@@ -3,10 +3,14 @@ package validation
 import (
 	"fmt"
 	"reflect"
+	"strings"
 )
 
-// RequiredValidator validates that a field is not empty
-type RequiredValidator struct{}
+// RequiredValidator validates that a field is not empty.
+// When Trim is set, strings are trimmed first so whitespace-only values are rejected.
+type RequiredValidator struct {
+	Trim bool
+}
 
 func (v *RequiredValidator) Validate(value any) error {
 	if value == nil {
@@ -16,12 +20,19 @@ func (v *RequiredValidator) Validate(value any) error {
 	if val.IsZero() {
 		return fmt.Errorf("field is required")
 	}
+	if v.Trim && val.Kind() == reflect.String && strings.TrimSpace(val.String()) == "" {
+		return fmt.Errorf("field is required")
+	}
 	return nil
 }
 
 // New creates a new RequiredValidator from parameters
 func (v *RequiredValidator) New(params map[string]string) (Validator, error) {
-	return &RequiredValidator{}, nil
+	trim, err := parseBoolParam(params, trimParam)
+	if err != nil {
+		return nil, err
+	}
+	return &RequiredValidator{Trim: trim}, nil
 }
 
 // Key returns the registration key for this validator
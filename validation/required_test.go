@@ -83,6 +83,58 @@ func TestRequiredValidator_Validate_EdgeCases(t *testing.T) {
 	}
 }
 
+func TestRequiredValidator_Validate_Trim(t *testing.T) {
+	tests := []struct {
+		name    string
+		trim    bool
+		value   interface{}
+		wantErr bool
+	}{
+		{"spaces with trim", true, "  ", true},
+		{"spaces without trim", false, "  ", false},
+		{"tabs and newlines with trim", true, "\t\n", true},
+		{"padded value with trim", true, "  test  ", false},
+		{"normal value with trim", true, "test", false},
+		{"normal value without trim", false, "test", false},
+		{"empty string with trim", true, "", true},
+		{"non-string with trim", true, 42, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &RequiredValidator{Trim: tt.trim}
+			err := validator.Validate(tt.value)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "field is required")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRequiredValidator_New_Trim(t *testing.T) {
+	validator := &RequiredValidator{}
+
+	testValidatorNew(t, validator, map[string]string{"trim": "true"}, true, "Trim")
+	testValidatorNew(t, validator, map[string]string{}, false, "Trim")
+	testValidatorNewError(t, validator, map[string]string{"trim": "maybe"}, "invalid trim value: maybe")
+}
+
+func TestRequiredValidator_Tag_Trim(t *testing.T) {
+	type form struct {
+		Name     string `validate:"required,trim=true"`
+		Nickname string `validate:"required"`
+	}
+
+	result := Validate(form{Name: "   ", Nickname: "   "})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "Name", result.Errors[0].Field)
+}
+
 func TestRequiredValidator_New(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -3,6 +3,8 @@
 // validation framework for the Synergy Framework.
 package validation
 
+import "strings"
+
 // Validator defines the contract for validation rules
 type Validator interface {
 	Validate(value any) error
@@ -12,6 +14,11 @@ type Validator interface {
 	Key() string
 }
 
+// StructValidatorFunc validates a whole struct value, typically enforcing invariants that
+// span several fields. It receives the struct by value and returns any errors found;
+// field names in the returned errors are relative to the struct.
+type StructValidatorFunc func(s any) []Error
+
 // Rule represents a single validation rule
 type Rule struct {
 	Name   string
@@ -20,6 +27,37 @@ type Rule struct {
 
 // The Result contains the result of a validation operation
 type Result struct {
-	IsValid bool
-	Errors  []Error
+	IsValid bool    `json:"valid"`
+	Errors  []Error `json:"errors"`
+}
+
+// ByField returns error messages grouped by field name
+func (r *Result) ByField() map[string][]string {
+	grouped := make(map[string][]string, len(r.Errors))
+	for _, err := range r.Errors {
+		grouped[err.Field] = append(grouped[err.Field], err.Message)
+	}
+	return grouped
+}
+
+// Err returns r as an error when validation failed, and nil otherwise. Use it rather
+// than returning a *Result as an error directly, which is never nil even when valid.
+func (r *Result) Err() error {
+	if r == nil || r.IsValid || len(r.Errors) == 0 {
+		return nil
+	}
+	return r
+}
+
+// Error implements the error interface; it returns "" for a valid result, so obtain the
+// error through Err
+func (r *Result) Error() string {
+	if len(r.Errors) == 0 {
+		return ""
+	}
+	messages := make([]string, 0, len(r.Errors))
+	for _, err := range r.Errors {
+		messages = append(messages, err.Field+": "+err.Message)
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
 }
@@ -0,0 +1,92 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type resultTestUser struct {
+	Name  string `validate:"required,min:3"`
+	Email string `validate:"required,email"`
+	Age   int    `validate:"min:18"`
+}
+
+func TestResult_JSON(t *testing.T) {
+	result := &Result{
+		IsValid: false,
+		Errors: []Error{
+			NewValidationError("Age", "min", "value must be at least 18", 16),
+		},
+	}
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"valid": false,
+		"errors": [{"field": "Age", "rule": "min", "message": "value must be at least 18"}]
+	}`, string(data))
+}
+
+func TestResult_JSON_OmitsRejectedValue(t *testing.T) {
+	type login struct {
+		Password string `validate:"min:12"`
+	}
+	result := Validate(login{Password: "hunter2"})
+	require.False(t, result.IsValid)
+	assert.Equal(t, "hunter2", result.Errors[0].Value)
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "hunter2")
+}
+
+func TestResult_JSON_Valid(t *testing.T) {
+	result := Validate(resultTestUser{Name: "Alice", Email: "alice@example.com", Age: 30})
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"valid": true, "errors": []}`, string(data))
+}
+
+func TestResult_ByField(t *testing.T) {
+	result := Validate(resultTestUser{Name: "", Email: "bad", Age: 30})
+	require.False(t, result.IsValid)
+
+	grouped := result.ByField()
+	assert.Equal(t, []string{"field is required", "string length must be at least 3"}, grouped["Name"])
+	assert.Equal(t, []string{"invalid email format"}, grouped["Email"])
+	assert.NotContains(t, grouped, "Age")
+}
+
+func TestResult_Error(t *testing.T) {
+	result := Validate(resultTestUser{Name: "Alice", Email: "alice@example.com", Age: 16})
+	require.False(t, result.IsValid)
+
+	err := result.Err()
+	require.Error(t, err)
+	assert.Equal(t, "validation failed: Age: value must be at least 18", err.Error())
+
+	var target *Result
+	require.True(t, errors.As(err, &target))
+	assert.Same(t, result, target)
+
+	assert.Empty(t, (&Result{IsValid: true}).Error())
+}
+
+func TestResult_Err(t *testing.T) {
+	valid := Validate(resultTestUser{Name: "Alice", Email: "alice@example.com", Age: 30})
+	require.True(t, valid.IsValid)
+	assert.NoError(t, valid.Err())
+	assert.NoError(t, (*Result)(nil).Err())
+
+	invalid := Validate(resultTestUser{Name: "Al", Email: "alice@example.com", Age: 30})
+	err := invalid.Err()
+	require.Error(t, err)
+	var target *Result
+	require.ErrorAs(t, err, &target)
+	assert.Same(t, invalid, target)
+}
@@ -4,12 +4,29 @@
 package validation
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 )
 
 const (
 	defaultRuleCount = 3
+
+	// messageParam overrides the validator's default error message for a rule
+	messageParam = "msg"
+	// strictParam makes validators reject types they do not support instead of passing
+	strictParam = "strict"
+	// trimParam makes required treat whitespace-only strings as empty
+	trimParam = "trim"
+	// caseInsensitiveParam makes oneof compare values case-insensitively
+	caseInsensitiveParam = "ci"
+
+	// escapableSeparators are the tag separators that lose their meaning when preceded by a
+	// backslash, e.g. `validate:"regexp:pattern=^a\\,b$"` for the pattern "^a,b$"
+	escapableSeparators = ",:="
 )
 
 // Global validator registry with built-in validators
@@ -20,6 +37,13 @@ func Validate(targetStruct any) *Result {
 	return validateWithRegistry(targetStruct, defaultRegistry)
 }
 
+// ValidateFast validates a struct using the default registry and returns the first
+// validation error encountered, or nil if the struct is valid. Unlike Validate it stops
+// at the first failing rule, which suits hot paths that only need a pass/fail answer.
+func ValidateFast(targetStruct any) error {
+	return validateFastWithRegistry(targetStruct, defaultRegistry)
+}
+
 // ValidateWithCustomValidators validates a struct with additional custom validators
 // without permanently registering them with the default registry
 func ValidateWithCustomValidators(targetStruct any, customValidators ...Validator) *Result {
@@ -35,6 +59,13 @@ func RegisterCustomValidator(validator Validator) {
 	defaultRegistry.registerValidator(validator)
 }
 
+// RegisterCustomValidatorAs registers a validator with the default registry under name
+// instead of its Key(), so one implementation can serve several tag names.
+// Rules using name are built through the validator's New method, like any other rule.
+func RegisterCustomValidatorAs(name string, validator Validator) {
+	defaultRegistry.registerValidatorAs(name, validator)
+}
+
 // RegisterCustomValidators registers multiple custom validators with the default registry
 func RegisterCustomValidators(validators ...Validator) {
 	for _, validator := range validators {
@@ -42,6 +73,13 @@ func RegisterCustomValidators(validators ...Validator) {
 	}
 }
 
+// RegisterStructValidator registers a struct-level validator with the default registry.
+// fn runs after field validation for every struct of the same type as typ, including
+// nested ones, and its errors are merged into the Result.
+func RegisterStructValidator(typ any, fn func(s any) []Error) {
+	defaultRegistry.registerStructValidator(typ, fn)
+}
+
 // GetRegisteredValidators returns all registered validator names
 func GetRegisteredValidators() []string {
 	return defaultRegistry.listValidators()
@@ -53,13 +91,30 @@ func HasValidator(name string) bool {
 }
 
 func validateWithRegistry(targetStruct any, registry *validatorRegistry) *Result {
+	return runValidation(targetStruct, registry, false)
+}
+
+func validateFastWithRegistry(targetStruct any, registry *validatorRegistry) error {
+	result := runValidation(targetStruct, registry, true)
+	if result.IsValid {
+		return nil
+	}
+	return result.Errors[0]
+}
+
+// runValidation validates targetStruct, stopping at the first error when failFast is set
+func runValidation(targetStruct any, registry *validatorRegistry, failFast bool) *Result {
 	result := &Result{
 		IsValid: true,
 		Errors:  []Error{},
 	}
 
 	val := reflect.ValueOf(targetStruct)
+	visiting := make(map[visit]bool)
 	if val.Kind() == reflect.Ptr {
+		if !val.IsNil() {
+			visiting[visitOf(val)] = true
+		}
 		val = val.Elem()
 	}
 
@@ -69,7 +124,7 @@ func validateWithRegistry(targetStruct any, registry *validatorRegistry) *Result
 		return result
 	}
 
-	validateStruct(val, "", result, registry)
+	validateStruct(val, "", result, registry, failFast, visiting)
 	return result
 }
 
@@ -77,25 +132,74 @@ func isValidStruct(val reflect.Value) bool {
 	return val.Kind() == reflect.Struct
 }
 
-func validateStruct(val reflect.Value, prefix string, result *Result, registry *validatorRegistry) {
+// visit identifies a pointer on the current path of nested structs. The type is part of
+// the key because a struct and its first field share an address.
+type visit struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+func visitOf(ptr reflect.Value) visit {
+	return visit{typ: ptr.Type(), ptr: ptr.Pointer()}
+}
+
+// validateStruct validates each tagged field of val and reports whether validation
+// stopped early because failFast is set and an error was recorded. visiting holds the
+// pointers already being validated further up, so cyclic values are not recursed into again.
+func validateStruct(val reflect.Value, prefix string, result *Result, registry *validatorRegistry, failFast bool, visiting map[visit]bool) bool {
 	valType := val.Type()
 
 	for i := 0; i < valType.NumField(); i++ {
 		field := valType.Field(i)
 		fieldValue := val.Field(i)
 
-		validationTag := field.Tag.Get("validate")
-		if validationTag == "" {
-			continue
+		fieldName := buildFieldName(prefix, field.Name)
+		if validationTag := field.Tag.Get("validate"); validationTag != "" {
+			if validateField(fieldValue, fieldName, validationTag, result, registry, failFast) {
+				return true
+			}
 		}
 
-		fieldName := buildFieldName(prefix, field.Name)
-		validateField(fieldValue, fieldName, validationTag, result, registry)
+		if nested, ptrs, ok := nestedStruct(field, fieldValue, visiting); ok {
+			for _, p := range ptrs {
+				visiting[p] = true
+			}
+			stopped := validateStruct(nested, fieldName, result, registry, failFast, visiting)
+			for _, p := range ptrs {
+				delete(visiting, p)
+			}
+			if stopped {
+				return true
+			}
+		}
+	}
+	return runStructValidators(val, prefix, result, registry, failFast)
+}
 
-		if isEmbeddedStruct(field, fieldValue) {
-			validateStruct(fieldValue, fieldName, result, registry)
+// runStructValidators applies the struct-level validators registered for val's type,
+// qualifying the returned field names with prefix
+func runStructValidators(val reflect.Value, prefix string, result *Result, registry *validatorRegistry, failFast bool) bool {
+	validators := registry.structValidators[val.Type()]
+	if len(validators) == 0 || !val.CanInterface() {
+		return false
+	}
+
+	s := val.Interface()
+	for _, fn := range validators {
+		for _, err := range fn(s) {
+			if err.Field == "" {
+				err.Field = prefix
+			} else {
+				err.Field = buildFieldName(prefix, err.Field)
+			}
+			result.IsValid = false
+			result.Errors = append(result.Errors, err)
+			if failFast {
+				return true
+			}
 		}
 	}
+	return false
 }
 
 func buildFieldName(prefix, fieldName string) string {
@@ -105,15 +209,26 @@ func buildFieldName(prefix, fieldName string) string {
 	return prefix + "." + fieldName
 }
 
-func validateField(fieldValue reflect.Value, fieldName, validationTag string, result *Result, registry *validatorRegistry) {
+func validateField(fieldValue reflect.Value, fieldName, validationTag string, result *Result, registry *validatorRegistry, failFast bool) bool {
 	rules := parseValidationRules(validationTag)
+	fieldValue, isNilPointer := indirectValue(fieldValue)
 
 	for _, rule := range rules {
+		// A nil pointer is an absent value: only presence rules apply to it
+		if isNilPointer && !isPresenceRule(rule.Name) {
+			continue
+		}
 		if err := applyValidationRule(fieldValue, rule, registry); err != nil {
 			result.IsValid = false
-			result.Errors = append(result.Errors, NewValidationError(fieldName, rule.Name, err.Error(), fieldValue.Interface()))
+			verr := NewValidationError(fieldName, rule.Name, err.Error(), fieldValue.Interface())
+			verr.Params = errorParams(rule.Params)
+			result.Errors = append(result.Errors, verr)
+			if failFast {
+				return true
+			}
 		}
 	}
+	return false
 }
 
 func applyValidationRule(fieldValue reflect.Value, rule Rule, registry *validatorRegistry) error {
@@ -122,37 +237,160 @@ func applyValidationRule(fieldValue reflect.Value, rule Rule, registry *validato
 		return err
 	}
 
-	return validator.Validate(fieldValue.Interface())
+	if err := validator.Validate(fieldValue.Interface()); err != nil {
+		if msg := rule.Params[messageParam]; msg != "" {
+			return errors.New(msg)
+		}
+		return err
+	}
+	return nil
 }
 
-func isEmbeddedStruct(field reflect.StructField, fieldValue reflect.Value) bool {
-	return field.Anonymous && fieldValue.Kind() == reflect.Struct
+// errorParams copies a rule's parameters for an Error, dropping the message override.
+func errorParams(params map[string]string) map[string]string {
+	if len(params) == 0 {
+		return nil
+	}
+	cp := make(map[string]string, len(params))
+	for k, v := range params {
+		if k != messageParam {
+			cp[k] = v
+		}
+	}
+	if len(cp) == 0 {
+		return nil
+	}
+	return cp
+}
+
+// indirectValue dereferences pointers so value-based rules see the pointed-to value.
+// It reports whether a nil pointer was encountered, in which case the pointer itself is returned.
+func indirectValue(fieldValue reflect.Value) (reflect.Value, bool) {
+	for fieldValue.Kind() == reflect.Ptr {
+		if fieldValue.IsNil() {
+			return fieldValue, true
+		}
+		fieldValue = fieldValue.Elem()
+	}
+	return fieldValue, false
+}
+
+// isPresenceRule reports whether a rule checks for a value's presence and so applies to nil pointers
+func isPresenceRule(name string) bool {
+	return name == "required"
+}
+
+// nestedStruct returns the struct held by an exported field, embedded or named,
+// dereferencing pointers, along with the pointers it followed. Nil pointers, pointers
+// already in visiting, and non-struct fields are not recursed into.
+func nestedStruct(field reflect.StructField, fieldValue reflect.Value, visiting map[visit]bool) (reflect.Value, []visit, bool) {
+	if !field.IsExported() {
+		return reflect.Value{}, nil, false
+	}
+	var ptrs []visit
+	for fieldValue.Kind() == reflect.Ptr {
+		if fieldValue.IsNil() {
+			return reflect.Value{}, nil, false
+		}
+		v := visitOf(fieldValue)
+		if visiting[v] {
+			return reflect.Value{}, nil, false
+		}
+		ptrs = append(ptrs, v)
+		fieldValue = fieldValue.Elem()
+	}
+	if fieldValue.Kind() != reflect.Struct {
+		return reflect.Value{}, nil, false
+	}
+	return fieldValue, ptrs, true
 }
 
 func parseValidationRules(tag string) []Rule {
 	rules := make([]Rule, 0, defaultRuleCount)
 
-	ruleStrings := strings.Split(tag, ",")
+	ruleStrings := splitUnescaped(tag, ',')
 	for _, ruleString := range ruleStrings {
 		ruleString = strings.TrimSpace(ruleString)
 		if ruleString == "" {
 			continue
 		}
 
-		rule := parseSingleRule(ruleString)
-		rules = append(rules, rule)
+		if isRuleParameter(ruleString) {
+			key, value := parseParameterKeyValue(ruleString)
+			// A trailing modifier or continued parameter belongs to the preceding rule,
+			// e.g. "min:18,msg=You must be 18 or older" or "duration:min=1s,max=5m"
+			if len(rules) > 0 && (isModifierParam(key) || continuesRule(rules[len(rules)-1], key)) {
+				rules[len(rules)-1].Params[key] = value
+				continue
+			}
+			// Otherwise "name=value" is shorthand for "name:value", e.g. "eq=active"
+			rules = append(rules, Rule{Name: key, Params: map[string]string{"value": value}})
+			continue
+		}
+
+		rules = append(rules, parseSingleRule(ruleString))
 	}
 
 	return rules
 }
 
+// isModifierParam reports whether key is a parameter that adjusts the preceding rule
+// rather than naming a rule of its own.
+func isModifierParam(key string) bool {
+	switch key {
+	case messageParam, strictParam, trimParam, caseInsensitiveParam, mxTimeoutParam:
+		return true
+	}
+	return false
+}
+
+// keyedRuleParams lists the built-in rules that take several key=value parameters. Only
+// these keys continue such a rule, so that "regexp:pattern=x,min=3" stays two rules.
+var keyedRuleParams = map[string][]string{
+	"duration": {"min", "max"},
+	"bytesize": {"min", "max"},
+}
+
+// continuesRule reports whether a key=value segment is a further parameter of rule, which
+// must have been written in the "name:key=value" form.
+func continuesRule(rule Rule, key string) bool {
+	if _, positional := rule.Params["value"]; positional {
+		return false
+	}
+	return slices.Contains(keyedRuleParams[rule.Name], key)
+}
+
+// isRuleParameter reports whether a tag segment is a key=value parameter rather than a rule.
+// The key must be an identifier so that operator rules like ">=:5" are not mistaken for parameters.
+func isRuleParameter(segment string) bool {
+	eq := indexUnescaped(segment, '=')
+	if eq < 0 {
+		return false
+	}
+	if colon := indexUnescaped(segment, ':'); colon >= 0 && colon < eq {
+		return false
+	}
+	key := strings.TrimSpace(segment[:eq])
+	if key == "" {
+		return false
+	}
+	for i, c := range key {
+		isLetter := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_'
+		isDigit := c >= '0' && c <= '9'
+		if !isLetter && !(isDigit && i > 0) {
+			return false
+		}
+	}
+	return true
+}
+
 func parseSingleRule(ruleString string) Rule {
-	parts := strings.SplitN(ruleString, ":", 2)
-	ruleName := strings.TrimSpace(parts[0])
+	name, paramString, hasParams := cutUnescaped(ruleString, ':')
+	ruleName := unescapeSeparators(strings.TrimSpace(name))
 	params := make(map[string]string)
 
-	if len(parts) > 1 {
-		params = parseRuleParameters(parts[1])
+	if hasParams {
+		params = parseRuleParameters(paramString)
 	}
 
 	return Rule{
@@ -164,7 +402,7 @@ func parseSingleRule(ruleString string) Rule {
 func parseRuleParameters(paramString string) map[string]string {
 	params := make(map[string]string)
 
-	paramStrings := strings.Split(paramString, ",")
+	paramStrings := splitUnescaped(paramString, ',')
 	for _, paramString := range paramStrings {
 		paramString = strings.TrimSpace(paramString)
 		if paramString == "" {
@@ -181,12 +419,81 @@ func parseRuleParameters(paramString string) map[string]string {
 }
 
 func parseParameterKeyValue(paramString string) (key, value string) {
-	keyValue := strings.SplitN(paramString, "=", 2)
-	if len(keyValue) == 2 {
-		key := strings.TrimSpace(keyValue[0])
-		value := strings.TrimSpace(keyValue[1])
+	if k, v, ok := cutUnescaped(paramString, '='); ok {
+		key := unescapeSeparators(strings.TrimSpace(k))
+		value := unescapeSeparators(strings.TrimSpace(v))
 		return key, value
 	}
 
-	return "value", paramString
+	return "value", unescapeSeparators(paramString)
+}
+
+// indexUnescaped returns the index of the first sep in s not preceded by a backslash, or -1
+func indexUnescaped(s string, sep byte) int {
+	for i := 0; i < len(s); i++ {
+		if isEscapedSeparator(s, i) {
+			i++
+			continue
+		}
+		if s[i] == sep {
+			return i
+		}
+	}
+	return -1
+}
+
+// cutUnescaped slices s around the first unescaped sep, like strings.Cut
+func cutUnescaped(s string, sep byte) (before, after string, found bool) {
+	if i := indexUnescaped(s, sep); i >= 0 {
+		return s[:i], s[i+1:], true
+	}
+	return s, "", false
+}
+
+// splitUnescaped splits s on every unescaped sep, leaving escapes in place
+func splitUnescaped(s string, sep byte) []string {
+	var parts []string
+	for {
+		before, after, found := cutUnescaped(s, sep)
+		parts = append(parts, before)
+		if !found {
+			return parts
+		}
+		s = after
+	}
+}
+
+// unescapeSeparators removes the backslash from escaped separators. Other backslashes,
+// such as those in regexp classes like \d, are kept as-is.
+func unescapeSeparators(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if isEscapedSeparator(s, i) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// isEscapedSeparator reports whether s[i] is a backslash escaping one of escapableSeparators
+func isEscapedSeparator(s string, i int) bool {
+	return s[i] == '\\' && i+1 < len(s) && strings.IndexByte(escapableSeparators, s[i+1]) >= 0
+}
+
+// parseBoolParam parses an optional boolean rule parameter, defaulting to false when absent
+func parseBoolParam(params map[string]string, key string) (bool, error) {
+	raw, ok := params[key]
+	if !ok || raw == "" {
+		return false, nil
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s value: %s", key, raw)
+	}
+	return value, nil
 }
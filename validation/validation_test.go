@@ -0,0 +1,475 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate_CustomMessage(t *testing.T) {
+	type signup struct {
+		Age  int    `validate:"min:18,msg=You must be 18 or older"`
+		Name string `validate:"required,msg=Please tell us your name,min:2"`
+	}
+
+	result := Validate(signup{Age: 16, Name: ""})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 3)
+
+	assert.Equal(t, "Age", result.Errors[0].Field)
+	assert.Equal(t, "min", result.Errors[0].Rule)
+	assert.Equal(t, "You must be 18 or older", result.Errors[0].Message)
+
+	assert.Equal(t, "required", result.Errors[1].Rule)
+	assert.Equal(t, "Please tell us your name", result.Errors[1].Message)
+
+	// The message only applies to the rule it follows
+	assert.Equal(t, "min", result.Errors[2].Rule)
+	assert.Equal(t, "string length must be at least 2", result.Errors[2].Message)
+}
+
+func TestValidate_DefaultMessage(t *testing.T) {
+	type signup struct {
+		Age int `validate:"min:18"`
+	}
+
+	result := Validate(signup{Age: 16})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "value must be at least 18", result.Errors[0].Message)
+}
+
+func TestParseValidationRules_Parameters(t *testing.T) {
+	rules := parseValidationRules("required,min:18,msg=Too young: sorry,>=:5")
+	require.Len(t, rules, 3)
+
+	assert.Equal(t, "required", rules[0].Name)
+	assert.Equal(t, "min", rules[1].Name)
+	assert.Equal(t, map[string]string{"value": "18", "msg": "Too young: sorry"}, rules[1].Params)
+	assert.Equal(t, ">=", rules[2].Name)
+	assert.Equal(t, map[string]string{"value": "5"}, rules[2].Params)
+}
+
+func TestParseValidationRules_EqualsFormIsARule(t *testing.T) {
+	rules := parseValidationRules("required,min=18")
+	require.Len(t, rules, 2)
+	assert.Equal(t, "required", rules[0].Name)
+	assert.Empty(t, rules[0].Params)
+	assert.Equal(t, "min", rules[1].Name)
+	assert.Equal(t, map[string]string{"value": "18"}, rules[1].Params)
+
+	// key=value segments still continue a keyed parameter list
+	rules = parseValidationRules("required,duration:min=1s,max=5m,msg=bad")
+	require.Len(t, rules, 2)
+	assert.Equal(t, map[string]string{"min": "1s", "max": "5m", "msg": "bad"}, rules[1].Params)
+}
+
+func TestValidate_EqualsFormRules(t *testing.T) {
+	type account struct {
+		Age    int    `validate:"required,min=18"`
+		Status string `validate:"required,ne=deleted"`
+		Typo   string `validate:"required,mnimum=3"`
+	}
+
+	result := Validate(account{Age: 16, Status: "deleted", Typo: "x"})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 3)
+	assert.Equal(t, "min", result.Errors[0].Rule)
+	assert.Equal(t, "ne", result.Errors[1].Rule)
+	assert.Equal(t, "mnimum", result.Errors[2].Rule)
+	assert.Contains(t, result.Errors[2].Message, "unknown validation rule")
+
+	type member struct {
+		Age    int    `validate:"required,min=18"`
+		Status string `validate:"required,ne=deleted"`
+	}
+	assert.True(t, Validate(member{Age: 18, Status: "active"}).IsValid)
+}
+
+func TestValidate_ShorthandRuleAfterKeyedParams(t *testing.T) {
+	rules := parseValidationRules(`regexp:pattern=^[a-z]+$,min=3`)
+	require.Len(t, rules, 2)
+	assert.Equal(t, map[string]string{"pattern": "^[a-z]+$"}, rules[0].Params)
+	assert.Equal(t, Rule{Name: "min", Params: map[string]string{"value": "3"}}, rules[1])
+
+	rules = parseValidationRules("oneof:values=a|b,ne=b")
+	require.Len(t, rules, 2)
+	assert.Equal(t, Rule{Name: "ne", Params: map[string]string{"value": "b"}}, rules[1])
+
+	type profile struct {
+		Handle string `validate:"regexp:pattern=^[a-z]+$,min=3"`
+		Plan   string `validate:"oneof:values=a|b,ne=b"`
+	}
+	result := Validate(profile{Handle: "a", Plan: "b"})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, "min", result.Errors[0].Rule)
+	assert.Equal(t, "ne", result.Errors[1].Rule)
+	assert.True(t, Validate(profile{Handle: "abc", Plan: "a"}).IsValid)
+}
+
+func TestValidate_StrictTag(t *testing.T) {
+	type flags struct {
+		Enabled bool `validate:"min:1,strict=true"`
+		Lenient bool `validate:"min:1"`
+	}
+
+	result := Validate(flags{})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "Enabled", result.Errors[0].Field)
+	assert.Equal(t, "min not applicable to type bool", result.Errors[0].Message)
+}
+
+func TestValidate_CollectionLength(t *testing.T) {
+	type order struct {
+		Items  []string       `validate:"min:1"`
+		Labels map[string]int `validate:"max:5"`
+	}
+
+	result := Validate(order{
+		Items:  nil,
+		Labels: map[string]int{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5, "f": 6},
+	})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, "Items", result.Errors[0].Field)
+	assert.Equal(t, "length must be at least 1", result.Errors[0].Message)
+	assert.Equal(t, "Labels", result.Errors[1].Field)
+	assert.Equal(t, "length must be at most 5", result.Errors[1].Message)
+
+	assert.True(t, Validate(order{Items: []string{"a"}, Labels: map[string]int{}}).IsValid)
+}
+
+func TestValidate_PointerFields(t *testing.T) {
+	type profile struct {
+		Age      *int    `validate:"min:18"`
+		Nickname *string `validate:"required,min:3"`
+	}
+
+	adult, minor := 30, 16
+	nick, short := "neo", "x"
+
+	assert.True(t, Validate(profile{Age: &adult, Nickname: &nick}).IsValid)
+
+	result := Validate(profile{Age: &minor, Nickname: &short})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, "value must be at least 18", result.Errors[0].Message)
+	assert.Equal(t, 16, result.Errors[0].Value)
+	assert.Equal(t, "string length must be at least 3", result.Errors[1].Message)
+}
+
+func TestValidate_NilPointerFields(t *testing.T) {
+	type profile struct {
+		Age      *int    `validate:"min:18,strict=true"`
+		Nickname *string `validate:"required,min:3"`
+	}
+
+	result := Validate(profile{})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "Nickname", result.Errors[0].Field)
+	assert.Equal(t, "required", result.Errors[0].Rule)
+}
+
+// countingValidator fails for the string "bad" and counts how often it runs
+type countingValidator struct {
+	calls int
+}
+
+func (v *countingValidator) Validate(value any) error {
+	v.calls++
+	if value == "bad" {
+		return errors.New("value is bad")
+	}
+	return nil
+}
+
+func (v *countingValidator) New(params map[string]string) (Validator, error) {
+	return v, nil
+}
+
+func (v *countingValidator) Key() string {
+	return "counted"
+}
+
+func TestValidateFast_StopsAtFirstError(t *testing.T) {
+	type Inner struct {
+		Deep string `validate:"counted"`
+	}
+	type form struct {
+		First  string `validate:"counted"`
+		Second string `validate:"counted"`
+		Third  string `validate:"counted"`
+		Inner  `validate:"counted"`
+	}
+	target := form{First: "ok", Second: "bad", Third: "bad", Inner: Inner{Deep: "bad"}}
+
+	full := &countingValidator{}
+	fullRegistry := newValidatorRegistry()
+	fullRegistry.registerValidator(full)
+	result := validateWithRegistry(target, fullRegistry)
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 3)
+	assert.Equal(t, 5, full.calls)
+
+	fast := &countingValidator{}
+	fastRegistry := newValidatorRegistry()
+	fastRegistry.registerValidator(fast)
+	err := validateFastWithRegistry(target, fastRegistry)
+	require.Error(t, err)
+	assert.Equal(t, 2, fast.calls)
+
+	var validationErr Error
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, result.Errors[0], validationErr)
+	assert.Equal(t, "Second", validationErr.Field)
+}
+
+func TestValidateFast(t *testing.T) {
+	type signup struct {
+		Age  int    `validate:"min:18"`
+		Name string `validate:"required"`
+	}
+
+	assert.NoError(t, ValidateFast(signup{Age: 30, Name: "Ada"}))
+
+	err := ValidateFast(&signup{Age: 16})
+	require.Error(t, err)
+	assert.Equal(t, Validate(signup{Age: 16}).Errors[0], err)
+
+	err = ValidateFast("not a struct")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "object must be a struct")
+}
+
+func TestValidate_NamedNestedStruct(t *testing.T) {
+	type Address struct {
+		Street string `validate:"required"`
+		City   string `validate:"required"`
+	}
+	type customer struct {
+		Name    string `validate:"required"`
+		Address Address
+		Billing *Address
+	}
+
+	result := Validate(customer{Name: "Ada", Address: Address{City: "London"}, Billing: &Address{Street: "1 Main St"}})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, "Address.Street", result.Errors[0].Field)
+	assert.Equal(t, "required", result.Errors[0].Rule)
+	assert.Equal(t, "Billing.City", result.Errors[1].Field)
+}
+
+func TestValidate_NilNestedPointerSkipped(t *testing.T) {
+	type Address struct {
+		Street string `validate:"required"`
+	}
+	type customer struct {
+		Name    string   `validate:"required"`
+		Billing *Address // optional; nested rules only apply when set
+		Mailing *Address `validate:"required"`
+	}
+
+	result := Validate(customer{Name: "Ada"})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "Mailing", result.Errors[0].Field)
+	assert.Equal(t, "required", result.Errors[0].Rule)
+}
+
+func TestValidate_CyclicStruct(t *testing.T) {
+	type Node struct {
+		Name string `validate:"required"`
+		Next *Node
+	}
+
+	self := &Node{}
+	self.Next = self
+	result := Validate(self)
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "Name", result.Errors[0].Field)
+
+	// A two-node loop reaches the second node once before stopping
+	a, b := &Node{Name: "a"}, &Node{}
+	a.Next, b.Next = b, a
+	result = Validate(a)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "Next.Name", result.Errors[0].Field)
+
+	// Passed by value, the root is revisited once through its pointer
+	result = Validate(*a)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "Next.Name", result.Errors[0].Field)
+}
+
+func TestValidate_NestedStructSkipsUnexportedFields(t *testing.T) {
+	type Address struct {
+		Street string `validate:"required"`
+	}
+	type customer struct {
+		Name    string `validate:"required"`
+		address Address
+		Created time.Time
+	}
+
+	result := Validate(customer{Name: "Ada", address: Address{}})
+	assert.True(t, result.IsValid)
+}
+
+type paymentMethod struct {
+	Holder string `validate:"required"`
+	Card   string
+	IBAN   string
+	PayPal string
+}
+
+// exactlyOnePayment is a struct validator requiring exactly one payment method to be set
+func exactlyOnePayment(s any) []Error {
+	p := s.(paymentMethod)
+	set := 0
+	for _, v := range []string{p.Card, p.IBAN, p.PayPal} {
+		if v != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return []Error{NewValidationError("", "exclusive", "exactly one of Card, IBAN or PayPal must be set", set)}
+	}
+	return nil
+}
+
+func init() {
+	RegisterStructValidator(&paymentMethod{}, exactlyOnePayment)
+}
+
+func TestValidate_StructValidator(t *testing.T) {
+	assert.True(t, Validate(paymentMethod{Holder: "alice", IBAN: "DE89370400440532013000"}).IsValid)
+
+	result := Validate(&paymentMethod{Holder: "alice", IBAN: "DE89370400440532013000", PayPal: "a@b.c"})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "exclusive", result.Errors[0].Rule)
+	assert.Equal(t, "", result.Errors[0].Field)
+
+	result = Validate(paymentMethod{Holder: "alice"})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 1)
+}
+
+func TestValidate_StructValidatorRunsAfterFields(t *testing.T) {
+	result := Validate(paymentMethod{Card: "4111111111111111", PayPal: "a@b.c"})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, "required", result.Errors[0].Rule)
+	assert.Equal(t, "exclusive", result.Errors[1].Rule)
+
+	err := ValidateFast(paymentMethod{Card: "4111111111111111", PayPal: "a@b.c"})
+	require.Error(t, err)
+	assert.Equal(t, "required", err.(Error).Rule)
+}
+
+func TestValidate_StructValidatorNested(t *testing.T) {
+	type order struct {
+		ID      string `validate:"required"`
+		Payment paymentMethod
+	}
+
+	result := Validate(order{ID: "o-1", Payment: paymentMethod{Holder: "alice"}})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "Payment", result.Errors[0].Field)
+	assert.Equal(t, "exclusive", result.Errors[0].Rule)
+}
+
+func TestRegisterCustomValidatorAs(t *testing.T) {
+	RegisterCustomValidatorAs("gte", &ComparisonValidator{Operator: ">="})
+
+	assert.True(t, HasValidator("gte"))
+	assert.True(t, HasValidator(">="))
+
+	type listener struct {
+		Port    int `validate:"gte:1"`
+		Backlog int `validate:">=:1"`
+	}
+
+	assert.True(t, Validate(listener{Port: 8080, Backlog: 128}).IsValid)
+
+	result := Validate(listener{})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, "gte", result.Errors[0].Rule)
+	assert.Equal(t, "value must be >= 1", result.Errors[0].Message)
+	assert.Equal(t, ">=", result.Errors[1].Rule)
+	assert.Equal(t, "value must be >= 1", result.Errors[1].Message)
+}
+
+func TestParseValidationRules_EscapedSeparators(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		pattern string
+	}{
+		{"comma", `regexp:pattern=^a\,b$`, "^a,b$"},
+		{"quantifier", `regexp:pattern=^[0-9]{2\,4}$`, "^[0-9]{2,4}$"},
+		{"colon", `regexp:pattern=^\d{2}\:\d{2}$`, `^\d{2}:\d{2}$`},
+		{"equals", `regexp:pattern=^key\=value$`, "^key=value$"},
+		{"unescaped colon and equals in value", `regexp:pattern=^a:b=c$`, "^a:b=c$"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := parseValidationRules(tt.tag)
+			require.Len(t, rules, 1)
+			assert.Equal(t, "regexp", rules[0].Name)
+			assert.Equal(t, tt.pattern, rules[0].Params["pattern"])
+		})
+	}
+}
+
+func TestParseValidationRules_EscapedSeparatorsWithFollowingRules(t *testing.T) {
+	rules := parseValidationRules(`required,regexp:pattern=^a\,b$,msg=must be a\, then b`)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "required", rules[0].Name)
+	assert.Equal(t, map[string]string{"pattern": "^a,b$", "msg": "must be a, then b"}, rules[1].Params)
+}
+
+func TestValidate_EscapedRegexpTag(t *testing.T) {
+	type schedule struct {
+		Pair string `validate:"regexp:pattern=^[a-z]+\\,[a-z]+$"`
+		At   string `validate:"regexp:pattern=^\\d{2}\\:\\d{2}$"`
+	}
+
+	assert.True(t, Validate(schedule{Pair: "left,right", At: "09:30"}).IsValid)
+
+	result := Validate(schedule{Pair: "left;right", At: "0930"})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, "value does not match pattern: ^[a-z]+,[a-z]+$", result.Errors[0].Message)
+	assert.Equal(t, `value does not match pattern: ^\d{2}:\d{2}$`, result.Errors[1].Message)
+}
+
+func TestValidate_ErrorParams(t *testing.T) {
+	type order struct {
+		Quantity int    `validate:"min:1,msg=Order at least one"`
+		Discount int    `validate:"max:50"`
+		Currency string `validate:"oneof:values=USD|EUR,ci=true"`
+		Note     string `validate:"required"`
+	}
+
+	result := Validate(order{Quantity: 0, Discount: 75, Currency: "GBP"})
+	require.False(t, result.IsValid)
+	require.Len(t, result.Errors, 4)
+
+	assert.Equal(t, map[string]string{"value": "1"}, result.Errors[0].Params, "msg is not a rule parameter")
+	assert.Equal(t, "Order at least one", result.Errors[0].Message)
+	assert.Equal(t, map[string]string{"value": "50"}, result.Errors[1].Params)
+	assert.Equal(t, map[string]string{"values": "USD|EUR", "ci": "true"}, result.Errors[2].Params)
+	assert.Nil(t, result.Errors[3].Params)
+}